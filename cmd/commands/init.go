@@ -12,6 +12,7 @@ import (
 	"github.com/beatoz/beatoz-go/ctrlers/types"
 	"github.com/beatoz/beatoz-go/genesis"
 	"github.com/beatoz/beatoz-go/libs"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
 	btztypes "github.com/beatoz/beatoz-go/types"
 	acrypto "github.com/beatoz/beatoz-go/types/crypto"
 	"github.com/holiman/uint256"
@@ -101,6 +102,21 @@ func AddInitFlags(cmd *cobra.Command) {
 		"initial voting power at genesis, shared equally by all validators",
 	)
 
+	cmd.Flags().BoolVar(
+		&initParams.PermissionedDeploy,
+		"permissioned_deploy",
+		initParams.PermissionedDeploy,
+		"only the accounts in the allow-list can deploy contracts;\n"+
+			"the initial holders are in the allow-list at genesis and it can be changed by governance proposals.",
+	)
+
+	cmd.Flags().StringVar(
+		&initParams.KDF,
+		"kdf",
+		initParams.KDF,
+		"key derivation function encrypting the key files: pbkdf2 | scrypt | argon2id",
+	)
+
 	// consensus flags
 	cmd.Flags().BoolVar(
 		&initParams.CreateEmptyBlocks,
@@ -150,11 +166,16 @@ func initFiles(cmd *cobra.Command, args []string) error {
 func InitFilesWith(
 	config *cfg.Config,
 	params *InitParams,
-	callbacks ...func(*tmtypes.GenesisDoc),
+	callbacks ...func(*tmtypes.GenesisDoc) error,
 ) error {
 	if err := params.Validate(); err != nil {
 		return err
 	}
+	kdf := params.KDF
+	if kdf == "" {
+		kdf = acrypto.DefaultKDF
+	}
+
 	// private validator
 	privValKeyFile := config.PrivValidatorKeyFile()
 	privValStateFile := config.PrivValidatorStateFile()
@@ -178,6 +199,9 @@ func InitFilesWith(
 				"stateFile", _keyStateFilePath)
 		} else {
 			pv = acrypto.GenSFilePV(_keyFilePath, _keyStateFilePath)
+			if err := pv.SetKDF(kdf); err != nil {
+				return err
+			}
 			pv.SaveWith(params.ValSecret)
 			logger.Info("Generated private validator", "keyFile", _keyFilePath,
 				"stateFile", _keyStateFilePath)
@@ -258,7 +282,7 @@ func InitFilesWith(
 
 		//
 		// Initialize asset holders at genesis
-		walkeys, err := acrypto.CreateWalletKeyFiles(params.HolderSecret, params.HolderCnt, defaultWalkeyDirPath)
+		walkeys, err := acrypto.CreateWalletKeyFilesWithKDF(params.HolderSecret, params.HolderCnt, defaultWalkeyDirPath, kdf)
 		if err != nil {
 			return err
 		}
@@ -288,6 +312,12 @@ func InitFilesWith(
 			AssetHolders: holders,
 			GovParams:    govParams,
 		}
+		if params.PermissionedDeploy {
+			appState.PermissionedDeploy = true
+			for _, h := range holders {
+				appState.AllowedDeployers = append(appState.AllowedDeployers, h.Address)
+			}
+		}
 
 		//
 		// Create genesis
@@ -306,7 +336,15 @@ func InitFilesWith(
 		}
 
 		for _, cb := range callbacks {
-			cb(genDoc)
+			if err := cb(genDoc); err != nil {
+				return err
+			}
+		}
+		if len(callbacks) > 0 {
+			// the callbacks may change the app state, so the app hash is computed again from it.
+			if err := resetGenesisAppHash(genDoc); err != nil {
+				return err
+			}
 		}
 
 		if err := genDoc.SaveAs(genFile); err != nil {
@@ -318,6 +356,19 @@ func InitFilesWith(
 	return nil
 }
 
+func resetGenesisAppHash(genDoc *tmtypes.GenesisDoc) error {
+	appState := &genesis.GenesisAppState{}
+	if err := jsonx.Unmarshal(genDoc.AppState, appState); err != nil {
+		return err
+	}
+	appHash, err := appState.Hash()
+	if err != nil {
+		return err
+	}
+	genDoc.AppHash = appHash
+	return nil
+}
+
 type InitParams struct {
 	// for config.toml
 	CreateEmptyBlocks         bool
@@ -334,6 +385,13 @@ type InitParams struct {
 	MaxTotalSupply  int64
 	InitTotalSupply int64
 	InitVotingPower int64
+
+	// if it is true, only the initial holders can deploy contracts at genesis.
+	PermissionedDeploy bool
+
+	// key derivation function encrypting the validator and holder key files.
+	// if it is empty, `crypto.DefaultKDF` is used.
+	KDF string
 }
 
 func DefaultInitParams() *InitParams {
@@ -349,6 +407,7 @@ func DefaultInitParams() *InitParams {
 		MaxTotalSupply:  int64(700_000_000),
 		InitTotalSupply: int64(350_000_000),
 		InitVotingPower: int64(35_000_000),
+		KDF:             acrypto.DefaultKDF,
 	}
 }
 
@@ -371,5 +430,10 @@ func (params *InitParams) Validate() error {
 	if _, err := time.ParseDuration(params.CreateEmptyBlocksInterval); err != nil {
 		return fmt.Errorf("invalid create_empty_blocks_interval: %s", params.CreateEmptyBlocksInterval)
 	}
+	if params.KDF != "" {
+		if err := acrypto.ValidateKDF(params.KDF); err != nil {
+			return err
+		}
+	}
 	return nil
 }
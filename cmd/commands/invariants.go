@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/beatoz/beatoz-go/cmd/commands/web3"
+	"github.com/spf13/cobra"
+)
+
+var (
+	invRPCURL string
+	invHeight int64
+)
+
+// NewInvariantsCmd returns the command that checks the invariants of a running node on demand.
+func NewInvariantsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "invariants",
+		Short: "Check the invariants of the state committed at a height",
+		Args:  cobra.NoArgs,
+		RunE:  checkInvariants,
+	}
+	cmd.Flags().StringVar(&invRPCURL, "rpc", "http://localhost:26657", "RPC URL of the node")
+	cmd.Flags().Int64Var(&invHeight, "height", 0, "block height to check (0 means the latest height)")
+	return cmd
+}
+
+func checkInvariants(cmd *cobra.Command, args []string) error {
+	bzweb3 := web3.NewBeatozWeb3(web3.NewHttpProvider(invRPCURL))
+	results, err := bzweb3.QueryInvariants(invHeight)
+	if err != nil {
+		return err
+	}
+
+	nBroken := 0
+	for _, r := range results {
+		status := "ok"
+		if r.Broken {
+			status = "BROKEN"
+			nBroken++
+		}
+		fmt.Printf("[%s] %s/%s\n", status, r.Module, r.Route)
+		fmt.Printf("  %s\n", r.Msg)
+	}
+	if nBroken > 0 {
+		return fmt.Errorf("%d invariant(s) broken", nBroken)
+	}
+	return nil
+}
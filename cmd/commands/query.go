@@ -0,0 +1,485 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/beatoz/beatoz-go/cmd/commands/web3"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/rpc"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/spf13/cobra"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+var (
+	queryHeight     int64
+	queryPage       int
+	queryPerPage    int
+	queryStatus     string
+	queryTypes      string
+	queryFromHeight int64
+	queryToHeight   int64
+	querySortBy     string
+	queryOrderBy    string
+	queryProve      bool
+	queryFrom       string
+	queryData       string
+)
+
+// queryRoute is an RPC route which `query` can request.
+// If `abci` is true, the result is the ABCI query result and only its value is printed.
+type queryRoute struct {
+	use    string
+	short  string
+	method string
+	args   cobra.PositionalArgs
+	abci   bool
+	flags  func(cmd *cobra.Command)
+	params func(args []string) ([]interface{}, error)
+	print  func(result json.RawMessage) error
+}
+
+func NewQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "Query commands",
+		Long: `Query commands.
+Each command requests the RPC route of the same name and prints the result as JSON.
+'subscribe' and 'unsubscribe' are not provided since they need a websocket connection.`,
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&rpcUrl,
+		"rpcurl",
+		"http://localhost:26657",
+		"BEATOZ RPC URL")
+
+	for _, route := range queryRoutes() {
+		sub := &cobra.Command{
+			Use:   route.use,
+			Short: route.short,
+			Args:  route.args,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return runQuery(route, args)
+			},
+		}
+		if sub.Args == nil {
+			sub.Args = cobra.NoArgs
+		}
+		if route.flags != nil {
+			route.flags(sub)
+		}
+		cmd.AddCommand(sub)
+	}
+	return cmd
+}
+
+func queryRoutes() []*queryRoute {
+	return []*queryRoute{
+		{
+			use:    "status",
+			short:  "Show the status of the node",
+			method: "status",
+		},
+		{
+			use:    "tx [txhash]",
+			short:  "Show the tx and its result",
+			method: "tx",
+			args:   cobra.ExactArgs(1),
+			params: func(args []string) ([]interface{}, error) {
+				txhash, err := parseHexArg(args[0])
+				if err != nil {
+					return nil, fmt.Errorf("invalid txhash: %w", err)
+				}
+				return []interface{}{[]byte(txhash), false}, nil
+			},
+			print: printQueryTx,
+		},
+		queryAddrRoute("account", "Show the account"),
+		queryAddrRoute("token", "Show the native token"),
+		{
+			use:    "token-balance [token] [addr]",
+			short:  "Show the balance of the native token held by the account",
+			method: "token_balance",
+			args:   cobra.ExactArgs(2),
+			abci:   true,
+			flags:  addQueryHeightFlag,
+			params: func(args []string) ([]interface{}, error) {
+				token, err := types.HexToAddress(args[0])
+				if err != nil {
+					return nil, fmt.Errorf("invalid token address: %w", err)
+				}
+				addr, err := types.HexToAddress(args[1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid address: %w", err)
+				}
+				return []interface{}{token.String(), addr.String(), queryHeightArg()}, nil
+			},
+		},
+		queryAddrRoute("delegatee", "Show the delegatee(validator or candidate)"),
+		queryAddrRoute("stakes", "Show the stakes of the account"),
+		queryAddrRoute("reward", "Show the reward of the account"),
+		queryHeightRoute("total-power", "stakes/total_power", "Show the total power"),
+		queryHeightRoute("voting-power", "stakes/voting_power", "Show the voting power of the validators"),
+		queryHeightRoute("total-supply", "total_supply", "Show the total supply"),
+		{
+			use:    "total-txfee",
+			short:  "Show the total tx fee",
+			method: "total_txfee",
+			abci:   true,
+		},
+		{
+			use:    "proposals",
+			short:  "List the governance proposals",
+			method: "proposals",
+			abci:   true,
+			flags: func(cmd *cobra.Command) {
+				cmd.Flags().StringVar(&queryStatus, "status", "", "status of the proposals (voting|deposit|frozen); all if it is empty")
+				addQueryHeightFlag(cmd)
+				addQueryPageFlags(cmd)
+			},
+			params: func(args []string) ([]interface{}, error) {
+				return []interface{}{queryStatus, queryHeightArg(),
+					strconv.Itoa(queryPage), strconv.Itoa(queryPerPage)}, nil
+			},
+		},
+		{
+			use:    "proposal [txhash]",
+			short:  "Show the governance proposal",
+			method: "proposal",
+			args:   cobra.ExactArgs(1),
+			abci:   true,
+			flags:  addQueryHeightFlag,
+			params: func(args []string) ([]interface{}, error) {
+				if _, err := parseHexArg(args[0]); err != nil {
+					return nil, fmt.Errorf("invalid txhash: %w", err)
+				}
+				return []interface{}{args[0], queryHeightArg()}, nil
+			},
+		},
+		{
+			use:    "proposal-diff [txhash]",
+			short:  "Show the changes of the governance parameters proposed by each option",
+			method: "proposal_diff",
+			args:   cobra.ExactArgs(1),
+			abci:   true,
+			flags:  addQueryHeightFlag,
+			params: func(args []string) ([]interface{}, error) {
+				if _, err := parseHexArg(args[0]); err != nil {
+					return nil, fmt.Errorf("invalid txhash: %w", err)
+				}
+				return []interface{}{args[0], queryHeightArg()}, nil
+			},
+		},
+		{
+			use:    "allow-list [contract]",
+			short:  "Show the accounts allowed to call the contract, or to deploy contracts if the contract is omitted",
+			method: "allow_list",
+			args:   cobra.MaximumNArgs(1),
+			abci:   true,
+			flags:  addQueryHeightFlag,
+			params: func(args []string) ([]interface{}, error) {
+				target := types.ZeroAddress()
+				if len(args) > 0 {
+					addr, err := types.HexToAddress(args[0])
+					if err != nil {
+						return nil, fmt.Errorf("invalid address: %w", err)
+					}
+					target = addr
+				}
+				return []interface{}{target.String(), queryHeightArg()}, nil
+			},
+		},
+		queryHeightRoute("gov-params", "gov_params", "Show the governance parameters"),
+		{
+			use:    "tx-search [query]",
+			short:  "Search the txs matched with the query (e.g. \"tx.sender='...'\")",
+			method: "tx_search",
+			args:   cobra.ExactArgs(1),
+			flags: func(cmd *cobra.Command) {
+				cmd.Flags().BoolVar(&queryProve, "prove", false, "include the proofs of the txs")
+				cmd.Flags().StringVar(&queryOrderBy, "order_by", "asc", "order of the txs (asc|desc)")
+				addQueryPageFlags(cmd)
+			},
+			params: func(args []string) ([]interface{}, error) {
+				return []interface{}{args[0], queryProve,
+					queryOptIntArg(queryPage), queryOptIntArg(queryPerPage), queryOrderBy}, nil
+			},
+		},
+		{
+			use:    "validators",
+			short:  "List the validators",
+			method: "validators",
+			flags: func(cmd *cobra.Command) {
+				addQueryHeightFlag(cmd)
+				addQueryPageFlags(cmd)
+			},
+			params: func(args []string) ([]interface{}, error) {
+				return []interface{}{queryHeightArg(),
+					queryOptIntArg(queryPage), queryOptIntArg(queryPerPage)}, nil
+			},
+		},
+		queryVMRoute("vm-call", "vm_call", "Call the contract without sending a tx"),
+		queryVMRoute("vm-estimate-gas", "vm_estimate_gas", "Estimate the gas to execute the contract"),
+		{
+			use:    "txn",
+			short:  "Show the number of txs",
+			method: "txn",
+			abci:   true,
+		},
+		queryHeightRoute("module-hashes", "module_hashes", "Show the hashes of the modules"),
+		queryHeightRoute("invariants", "invariants", "Check the invariants of the modules"),
+		queryHistoryRoute("account-history", "account_history", "List the history of the account"),
+		queryHistoryRoute("staking-history", "staking_history", "List the staking history of the account"),
+		{
+			use:    "delegatees",
+			short:  "List the delegatees",
+			method: "delegatees",
+			abci:   true,
+			flags: func(cmd *cobra.Command) {
+				addQueryHeightFlag(cmd)
+				addQueryPageFlags(cmd)
+			},
+			params: func(args []string) ([]interface{}, error) {
+				return []interface{}{queryHeightArg(), strconv.Itoa(queryPage), strconv.Itoa(queryPerPage)}, nil
+			},
+		},
+		{
+			use:    "delegators [addr]",
+			short:  "List the delegators of the delegatee",
+			method: "delegators",
+			args:   cobra.ExactArgs(1),
+			abci:   true,
+			flags: func(cmd *cobra.Command) {
+				addQueryHeightFlag(cmd)
+				addQueryPageFlags(cmd)
+			},
+			params: func(args []string) ([]interface{}, error) {
+				addr, err := types.HexToAddress(args[0])
+				if err != nil {
+					return nil, fmt.Errorf("invalid address: %w", err)
+				}
+				return []interface{}{addr.String(), queryHeightArg(), strconv.Itoa(queryPage), strconv.Itoa(queryPerPage)}, nil
+			},
+		},
+		{
+			use:    "frozen-powers [addr]",
+			short:  "List the frozen powers (only the powers refunded to the account if it is given)",
+			method: "frozen_powers",
+			args:   cobra.MaximumNArgs(1),
+			abci:   true,
+			flags: func(cmd *cobra.Command) {
+				addQueryHeightFlag(cmd)
+				addQueryPageFlags(cmd)
+			},
+			params: func(args []string) ([]interface{}, error) {
+				var addr types.Address
+				if len(args) > 0 {
+					var err error
+					if addr, err = types.HexToAddress(args[0]); err != nil {
+						return nil, fmt.Errorf("invalid address: %w", err)
+					}
+				}
+				return []interface{}{addr.String(), queryHeightArg(), strconv.Itoa(queryPage), strconv.Itoa(queryPerPage)}, nil
+			},
+		},
+		{
+			use:    "rewards",
+			short:  "List the rewards",
+			method: "rewards",
+			abci:   true,
+			flags: func(cmd *cobra.Command) {
+				addQueryHeightFlag(cmd)
+				addQueryPageFlags(cmd)
+			},
+			params: func(args []string) ([]interface{}, error) {
+				return []interface{}{queryHeightArg(), strconv.Itoa(queryPage), strconv.Itoa(queryPerPage)}, nil
+			},
+		},
+	}
+}
+
+func queryAddrRoute(method, short string) *queryRoute {
+	return &queryRoute{
+		use:    method + " [addr]",
+		short:  short,
+		method: method,
+		args:   cobra.ExactArgs(1),
+		abci:   true,
+		flags:  addQueryHeightFlag,
+		params: func(args []string) ([]interface{}, error) {
+			addr, err := types.HexToAddress(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid address: %w", err)
+			}
+			return []interface{}{addr.String(), queryHeightArg()}, nil
+		},
+	}
+}
+
+func queryHeightRoute(use, method, short string) *queryRoute {
+	return &queryRoute{
+		use:    use,
+		short:  short,
+		method: method,
+		abci:   true,
+		flags:  addQueryHeightFlag,
+		params: func(args []string) ([]interface{}, error) {
+			return []interface{}{queryHeightArg()}, nil
+		},
+	}
+}
+
+func queryVMRoute(use, method, short string) *queryRoute {
+	return &queryRoute{
+		use:    use + " [contract addr]",
+		short:  short,
+		method: method,
+		args:   cobra.ExactArgs(1),
+		abci:   true,
+		flags: func(cmd *cobra.Command) {
+			cmd.Flags().StringVar(&queryFrom, "from", "", "address of the caller")
+			cmd.Flags().StringVar(&queryData, "data", "", "input data of the call (hex string)")
+			addQueryHeightFlag(cmd)
+			_ = cmd.MarkFlagRequired("from")
+		},
+		params: func(args []string) ([]interface{}, error) {
+			from, err := types.HexToAddress(queryFrom)
+			if err != nil {
+				return nil, fmt.Errorf("invalid from: %w", err)
+			}
+			to, err := types.HexToAddress(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid contract address: %w", err)
+			}
+			data, err := parseHexArg(queryData)
+			if err != nil {
+				return nil, fmt.Errorf("invalid data: %w", err)
+			}
+			return []interface{}{from, to, queryHeightArg(), []byte(data)}, nil
+		},
+	}
+}
+
+func queryHistoryRoute(use, method, short string) *queryRoute {
+	return &queryRoute{
+		use:    use + " [addr]",
+		short:  short,
+		method: method,
+		args:   cobra.ExactArgs(1),
+		abci:   true,
+		flags: func(cmd *cobra.Command) {
+			cmd.Flags().StringVar(&queryTypes, "types", "", "comma separated types of the txs (e.g. transfer,staking); all if it is empty")
+			cmd.Flags().Int64Var(&queryFromHeight, "from_height", 0, "lowest height of the records")
+			cmd.Flags().Int64Var(&queryToHeight, "to_height", 0, "highest height of the records (0 for the latest)")
+			cmd.Flags().StringVar(&querySortBy, "sort_by", "", "field to sort the records by")
+			cmd.Flags().StringVar(&queryOrderBy, "order_by", "asc", "order of the records (asc|desc)")
+			addQueryPageFlags(cmd)
+		},
+		params: func(args []string) ([]interface{}, error) {
+			addr, err := types.HexToAddress(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid address: %w", err)
+			}
+			return []interface{}{addr.String(), queryTypes,
+				strconv.FormatInt(queryFromHeight, 10), strconv.FormatInt(queryToHeight, 10),
+				querySortBy, queryOrderBy,
+				strconv.Itoa(queryPage), strconv.Itoa(queryPerPage)}, nil
+		},
+	}
+}
+
+func addQueryHeightFlag(cmd *cobra.Command) {
+	cmd.Flags().Int64Var(&queryHeight, "height", 0, "height at which the state is queried (0 for the latest)")
+}
+
+func addQueryPageFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&queryPage, "page", 0, "page number (0 for the default)")
+	cmd.Flags().IntVar(&queryPerPage, "per_page", 0, "number of items per page (0 for the default)")
+}
+
+func queryHeightArg() string {
+	return strconv.FormatInt(queryHeight, 10)
+}
+
+// queryOptIntArg returns nil for 0, so that the RPC applies its default value.
+func queryOptIntArg(v int) interface{} {
+	if v == 0 {
+		return nil
+	}
+	return strconv.Itoa(v)
+}
+
+func runQuery(route *queryRoute, args []string) error {
+	var params []interface{}
+	if route.params != nil {
+		var err error
+		if params, err = route.params(args); err != nil {
+			return err
+		}
+	}
+
+	req, err := web3.NewRequest(0, route.method, params...)
+	if err != nil {
+		return err
+	}
+	resp, err := web3.NewHttpProvider(rpcUrl).Call(req)
+	if err != nil {
+		return err
+	} else if resp.Error != nil {
+		return fmt.Errorf("provider error: %s", resp.Error)
+	}
+
+	result := resp.Result
+	if route.abci {
+		queryResp := &rpc.QueryResult{}
+		if err := tmjson.Unmarshal(resp.Result, queryResp); err != nil {
+			return err
+		}
+		if queryResp.Code != 0 {
+			return fmt.Errorf("query failed: %v", queryResp.Log)
+		}
+		result = queryResp.Value
+	}
+	if route.print != nil {
+		return route.print(result)
+	}
+	return printRawJSON(result)
+}
+
+// printQueryTx prints the result of the `tx` route with the decoded tx.
+func printQueryTx(result json.RawMessage) error {
+	resultTx := &coretypes.ResultTx{}
+	if err := tmjson.Unmarshal(result, resultTx); err != nil {
+		return err
+	}
+	tx := &ctrlertypes.Trx{}
+	if err := tx.Decode(resultTx.Tx); err != nil {
+		return err
+	}
+	return printJSON(&web3.TrxResult{
+		Hash:     resultTx.Hash,
+		Height:   resultTx.Height,
+		Index:    resultTx.Index,
+		TxResult: resultTx.TxResult,
+		Proof:    resultTx.Proof,
+		Tx:       tx,
+	})
+}
+
+func printRawJSON(raw json.RawMessage) error {
+	if len(raw) == 0 {
+		fmt.Println("null")
+		return nil
+	}
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, raw, "", "  "); err != nil {
+		return err
+	}
+	fmt.Println(buf.String())
+	return nil
+}
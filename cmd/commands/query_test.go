@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/cmd/commands/web3"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/rpc"
+	"github.com/beatoz/beatoz-go/types"
+	acrypto "github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type fakeNodeReq struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// fakeNode serves the JSON-RPC requests with the results returned by `handle`.
+type fakeNode struct {
+	t      *testing.T
+	reqs   []*fakeNodeReq
+	handle func(req *fakeNodeReq) interface{}
+}
+
+func (fn *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bz, err := io.ReadAll(r.Body)
+	require.NoError(fn.t, err)
+	req := &fakeNodeReq{}
+	require.NoError(fn.t, json.Unmarshal(bz, req))
+	fn.reqs = append(fn.reqs, req)
+
+	result, err := tmjson.Marshal(fn.handle(req))
+	require.NoError(fn.t, err)
+	resp, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": "0", "result": json.RawMessage(result)})
+	require.NoError(fn.t, err)
+	_, _ = w.Write(resp)
+}
+
+func abciQueryResult(t *testing.T, code uint32, log string, value interface{}) *rpc.QueryResult {
+	var bz []byte
+	if value != nil {
+		var err error
+		bz, err = tmjson.Marshal(value)
+		require.NoError(t, err)
+	}
+	return &rpc.QueryResult{ResponseQuery: abcitypes.ResponseQuery{Code: code, Log: log, Value: bz}}
+}
+
+func runQueryCmd(t *testing.T, args ...string) error {
+	cmd := NewQueryCmd()
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
+
+func Test_Query(t *testing.T) {
+	addr := types.RandAddress()
+	tx := web3.NewTrxTransfer(addr, types.RandAddress(), 1, 100_000, uint256.NewInt(10), uint256.NewInt(1000))
+	txbz, err := tx.Encode()
+	require.NoError(t, err)
+
+	node := &fakeNode{t: t}
+	node.handle = func(req *fakeNodeReq) interface{} {
+		switch req.Method {
+		case "tx":
+			return &coretypes.ResultTx{Hash: acrypto.DefaultHash(txbz), Height: 3, Tx: txbz}
+		case "account":
+			return abciQueryResult(t, 0, "", map[string]string{"address": addr.String(), "nonce": "1"})
+		case "delegatee":
+			return abciQueryResult(t, 1, "not found delegatee", nil)
+		default:
+			return abciQueryResult(t, 0, "", map[string]string{})
+		}
+	}
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+
+	require.NoError(t, runQueryCmd(t, "account", addr.String(), "--height", "10", "--rpcurl", srv.URL))
+	require.Equal(t, "account", node.reqs[0].Method)
+	require.JSONEq(t, `["`+addr.String()+`","10"]`, string(node.reqs[0].Params))
+
+	// the error code of the ABCI query is returned as an error.
+	require.ErrorContains(t, runQueryCmd(t, "delegatee", addr.String(), "--rpcurl", srv.URL), "not found delegatee")
+	// the arguments are checked before the request.
+	require.Error(t, runQueryCmd(t, "account", "xyz", "--rpcurl", srv.URL))
+	require.Len(t, node.reqs, 2)
+
+	require.NoError(t, runQueryCmd(t, "staking-history", addr.String(),
+		"--types", "staking,unstaking", "--order_by", "desc", "--page", "2", "--per_page", "5", "--rpcurl", srv.URL))
+	require.Equal(t, "staking_history", node.reqs[2].Method)
+	require.JSONEq(t, `["`+addr.String()+`","staking,unstaking","0","0","","desc","2","5"]`, string(node.reqs[2].Params))
+
+	require.NoError(t, runQueryCmd(t, "total-power", "--rpcurl", srv.URL))
+	require.Equal(t, "stakes/total_power", node.reqs[3].Method)
+
+	require.NoError(t, runQueryCmd(t, "tx", hex.EncodeToString(acrypto.DefaultHash(txbz)), "--rpcurl", srv.URL))
+	require.Equal(t, "tx", node.reqs[4].Method)
+}
+
+func Test_TxSend(t *testing.T) {
+	dir := t.TempDir()
+	secret := "tx-send-test-secret"
+	t.Setenv("BEATOZ_WALKEY_SECRET", secret)
+
+	wks, err := acrypto.CreateWalletKeyFiles([]byte(secret), 1, dir)
+	require.NoError(t, err)
+	sender := wks[0]
+	senderKeyFile := acrypto.WalletKeyFilePath(dir, sender.Address)
+	to := types.RandAddress()
+	govParams := ctrlertypes.DefaultGovParams()
+
+	var sentTx *ctrlertypes.Trx
+	node := &fakeNode{t: t}
+	node.handle = func(req *fakeNodeReq) interface{} {
+		switch req.Method {
+		case "genesis":
+			return &coretypes.ResultGenesis{Genesis: &tmtypes.GenesisDoc{ChainID: "0xabc"}}
+		case "gov_params":
+			return abciQueryResult(t, 0, "", govParams)
+		case "account":
+			return abciQueryResult(t, 0, "", map[string]string{"address": sender.Address.String(), "nonce": "7", "balance": "0"})
+		case "broadcast_tx_commit":
+			var params [][]byte
+			require.NoError(t, json.Unmarshal(req.Params, &params))
+			sentTx = &ctrlertypes.Trx{}
+			require.NoError(t, sentTx.Decode(params[0]))
+			return &coretypes.ResultBroadcastTxCommit{}
+		default:
+			t.Fatalf("unexpected method: %v", req.Method)
+			return nil
+		}
+	}
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+
+	cmd := NewTxCmd()
+	cmd.SetArgs([]string{"transfer", "--key", senderKeyFile, "--to", to.String(), "--amount", "1000", "--rpcurl", srv.URL})
+	cmd.SilenceUsage = true
+	txBzweb3, txGovParams = nil, nil
+	defer func() { txBzweb3, txGovParams = nil, nil }()
+	require.NoError(t, cmd.Execute())
+
+	// the nonce, gas and gas price are filled in from the node.
+	require.NotNil(t, sentTx)
+	require.Equal(t, ctrlertypes.TRX_TRANSFER, sentTx.Type)
+	require.Equal(t, sender.Address, sentTx.From)
+	require.Equal(t, to, sentTx.To)
+	require.Equal(t, int64(7), sentTx.Nonce)
+	require.Equal(t, govParams.MinTrxGas(), sentTx.Gas)
+	require.Equal(t, govParams.GasPrice(), sentTx.GasPrice)
+	require.Equal(t, uint256.NewInt(1000), sentTx.Amount)
+
+	ctrlertypes.InitSigner(uint256.NewInt(0xabc))
+	addr, _, xerr := ctrlertypes.VerifyTrxRLP(sentTx)
+	require.NoError(t, xerr)
+	require.Equal(t, sender.Address, addr)
+}
@@ -23,6 +23,8 @@ import (
 var (
 	genesisHash             []byte
 	privValSecretFeederAddr string
+	invCheckPeriod          int64
+	invCheckHalt            bool
 )
 
 // AddNodeFlags exposes some common configuration options on the command-line
@@ -35,7 +37,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String(
 		"priv_validator_laddr",
 		rootConfig.PrivValidatorListenAddr,
-		"socket address to listen on for connections from external priv_validator process")
+		"socket address to listen on for connections from external priv_validator process (e.g. `beatoz signer` or tmkms)")
 
 	cmd.Flags().StringVar(
 		&privValSecretFeederAddr,
@@ -50,6 +52,16 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"genesis_hash",
 		[]byte{},
 		"optional SHA-256 hash of the genesis file")
+	cmd.Flags().Int64Var(
+		&invCheckPeriod,
+		"inv_check_period",
+		0,
+		"block interval at which the invariants are checked (0 means never)")
+	cmd.Flags().BoolVar(
+		&invCheckHalt,
+		"inv_check_halt",
+		false,
+		"halt the node when any invariant is broken")
 	cmd.Flags().Int64("consensus.double_sign_check_height", rootConfig.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the beatoz's "+
 			"consensus votes before joining consensus")
@@ -121,10 +133,15 @@ func NewRunNodeCmd(nodeProvider node.Provider) *cobra.Command {
 				return fmt.Errorf("can't parse genesis file: %w", err)
 			}
 			rootConfig.SetChainId(genDoc.ChainID)
+			rootConfig.InvCheckPeriod = invCheckPeriod
+			rootConfig.InvCheckHalt = invCheckHalt
 			logger.Info("BEATOZ Blockchain", "ChainId", rootConfig.ChainId())
 
 			var s []byte
-			if privValSecretFeederAddr != "" {
+			if rootConfig.PrivValidatorListenAddr != "" {
+				// the validator key is held by the external signer process.
+				logger.Info("Waiting for the external signer", "addr", rootConfig.PrivValidatorListenAddr)
+			} else if privValSecretFeederAddr != "" {
 				wkf, err := crypto.OpenWalletKey(libs.NewFileReader(rootConfig.PrivValidatorKeyFile()))
 				if err != nil {
 					return err
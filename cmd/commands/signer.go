@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/beatoz/beatoz-go/libs"
+	"github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	signerAddr       string
+	signerChainID    string
+	signerRetryWait  time.Duration
+	signerMaxRetries int
+)
+
+// NewSignerCmd returns the command that serves the validator key of this home directory
+// to the node over the Tendermint privval socket protocol.
+// It is the reference implementation of the external signer process like tmkms.
+func NewSignerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signer",
+		Short: "Run the remote signer serving the validator key file",
+		Long: `Run the remote signer serving the validator key file ('priv_validator_key.json') of the home directory.
+The signer connects to the node started with '--priv_validator_laddr' and signs the votes and proposals.
+The last sign state ('priv_validator_state.json') is kept by the signer to protect the key from double signing.
+Only the socket protocols ('tcp://' and 'unix://') are supported.`,
+		Args: cobra.NoArgs,
+		RunE: runSigner,
+	}
+	cmd.Flags().StringVar(&signerAddr, "addr", "tcp://127.0.0.1:26659", "address of the node listening with '--priv_validator_laddr'")
+	cmd.Flags().StringVar(&signerChainID, "chain_id", "", "chain ID (default: the chain ID in the genesis file)")
+	cmd.Flags().DurationVar(&signerRetryWait, "retry_wait", time.Second, "interval between the retries to connect to the node")
+	cmd.Flags().IntVar(&signerMaxRetries, "max_retries", 0, "maximum number of the retries to connect to the node (0 means infinite)")
+	return cmd
+}
+
+func runSigner(cmd *cobra.Command, args []string) error {
+	chainID := signerChainID
+	if chainID == "" {
+		genDoc, err := types.GenesisDocFromFile(rootConfig.GenesisFile())
+		if err != nil {
+			return fmt.Errorf("can't get chain ID from genesis file: %w", err)
+		}
+		chainID = genDoc.ChainID
+	}
+
+	var s []byte
+	if _secret := os.Getenv("BEATOZ_VALIDATOR_SECRET"); _secret != "" {
+		s = []byte(_secret)
+	} else {
+		s = libs.ReadCredential(fmt.Sprintf("Passphrase for %v: ", filepath.Base(rootConfig.PrivValidatorKeyFile())))
+	}
+	pv := crypto.LoadSFilePV(rootConfig.PrivValidatorKeyFile(), rootConfig.PrivValidatorStateFile(), s)
+	libs.ClearCredential(s)
+
+	ss, err := NewSignerServer(signerAddr, chainID, pv, signerRetryWait, signerMaxRetries, logger)
+	if err != nil {
+		return err
+	}
+	if err := ss.Start(); err != nil {
+		return fmt.Errorf("failed to start signer: %w", err)
+	}
+	logger.Info("Started signer", "addr", signerAddr, "chainId", chainID, "validator", pv.GetAddress())
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	trapSignal(logger, func() {
+		if ss.IsRunning() {
+			if err := ss.Stop(); err != nil {
+				logger.Error("unable to stop the signer", "error", err)
+			}
+		}
+	})
+
+	// Run forever.
+	select {}
+}
+
+// NewSignerServer returns the signer server which dials `addr` and signs the requests for `chainID` with `pv`.
+// If `maxRetries` is 0, it retries to connect to the node infinitely.
+func NewSignerServer(addr, chainID string, pv types.PrivValidator, retryWait time.Duration, maxRetries int, logger log.Logger) (*privval.SignerServer, error) {
+	var dialer privval.SocketDialer
+	protocol, address := tmnet.ProtocolAndAddress(addr)
+	switch protocol {
+	case "unix":
+		dialer = privval.DialUnixFn(address)
+	case "tcp":
+		dialer = privval.DialTCPFn(address, 3*time.Second, ed25519.GenPrivKey())
+	default:
+		return nil, fmt.Errorf("wrong address: expected either 'tcp' or 'unix' protocols, got %s", protocol)
+	}
+
+	if maxRetries <= 0 {
+		maxRetries = math.MaxInt
+	}
+	endpoint := privval.NewSignerDialerEndpoint(
+		logger.With("module", "privval"),
+		dialer,
+		privval.SignerDialerEndpointRetryWaitInterval(retryWait),
+		privval.SignerDialerEndpointConnRetries(maxRetries),
+	)
+	return privval.NewSignerServer(endpoint, chainID, pv), nil
+}
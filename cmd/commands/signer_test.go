@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/beatoz/beatoz-go/types/bytes"
+	acrypto "github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func Test_Signer(t *testing.T) {
+	chainID := "signer-test-chain"
+	secret := bytes.RandBytes(12)
+	keyFile := filepath.Join(t.TempDir(), "priv_validator_key.json")
+	stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+	acrypto.GenSFilePV(keyFile, stateFile).SaveWith(secret)
+	pv := acrypto.LoadSFilePV(keyFile, stateFile, secret)
+
+	for _, addr := range []string{
+		"tcp://" + privval.GetFreeLocalhostAddrPort(),
+		"unix://" + filepath.Join(t.TempDir(), "signer.sock"),
+	} {
+		// the node side
+		listener, err := privval.NewSignerListener(addr, tmlog.NewNopLogger())
+		require.NoError(t, err)
+
+		// the signer side
+		ss, err := NewSignerServer(addr, chainID, pv, 10*time.Millisecond, 0, tmlog.NewNopLogger())
+		require.NoError(t, err)
+		require.NoError(t, ss.Start())
+
+		client, err := privval.NewSignerClient(listener, chainID)
+		require.NoError(t, err)
+
+		pubKey, err := client.GetPubKey()
+		require.NoError(t, err)
+		require.Equal(t, pv.Key.PubKey, pubKey)
+
+		height := int64(len(addr)) // each loop signs at a new height.
+		vote := &tmproto.Vote{
+			Type:             tmproto.PrecommitType,
+			Height:           height,
+			BlockID:          tmproto.BlockID{Hash: bytes.RandBytes(32), PartSetHeader: tmproto.PartSetHeader{Total: 1, Hash: bytes.RandBytes(32)}},
+			Timestamp:        time.Now(),
+			ValidatorAddress: pubKey.Address(),
+		}
+		require.NoError(t, client.SignVote(chainID, vote))
+		require.True(t, pubKey.VerifySignature(tmtypes.VoteSignBytes(chainID, vote), vote.Signature))
+
+		// the signer refuses to sign the conflicting vote.
+		conflict := *vote
+		conflict.BlockID = tmproto.BlockID{Hash: bytes.RandBytes(32), PartSetHeader: tmproto.PartSetHeader{Total: 1, Hash: bytes.RandBytes(32)}}
+		conflict.Signature = nil
+		require.Error(t, client.SignVote(chainID, &conflict))
+
+		// the signer refuses to sign for other chains.
+		other := *vote
+		other.Height++
+		other.Signature = nil
+		require.Error(t, client.SignVote("other-chain", &other))
+
+		require.NoError(t, ss.Stop())
+		require.NoError(t, client.Close())
+	}
+
+	// the last sign state is persisted by the signer.
+	reloaded := acrypto.LoadSFilePV(keyFile, stateFile, secret)
+	require.Equal(t, pv.LastSignState.Height, reloaded.LastSignState.Height)
+}
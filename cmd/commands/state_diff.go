@@ -0,0 +1,273 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beatoz/beatoz-go/cmd/commands/web3"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/ctrlers/vm/evm"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	abytes "github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/spf13/cobra"
+	tmcfg "github.com/tendermint/tendermint/config"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+var (
+	diffHeight     int64
+	diffMaxKeys    int
+	diffShowValues bool
+
+	// the database name of the ledger for each module.
+	// the vm module is not here because its state is not stored in the iavl tree.
+	moduleLedgerNames = map[string]string{
+		"gov":    "gov",
+		"acct":   "accounts",
+		"supply": "supply",
+		"vpower": "vpows",
+	}
+)
+
+// NewStateDiffCmd returns the command that compares the states of two nodes.
+func NewStateDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff <rpc-url|home-dir> <rpc-url|home-dir>",
+		Short: "Compare the module hashes of two nodes at a height and list the differing ledger keys",
+		Long: `Compare the module hashes of two nodes at a height.
+Each source is the RPC URL (e.g. http://localhost:26657) of a running node
+or the home directory of a stopped node.
+The differing ledger keys are listed only when both sources are home directories.`,
+		Args: cobra.ExactArgs(2),
+		RunE: stateDiff,
+	}
+	cmd.Flags().Int64Var(&diffHeight, "height", 0, "block height to compare (0 means the latest height)")
+	cmd.Flags().IntVar(&diffMaxKeys, "max-keys", 100, "maximum number of differing keys to list per module (0 means no limit)")
+	cmd.Flags().BoolVar(&diffShowValues, "values", false, "show the values of the differing keys")
+	return cmd
+}
+
+func stateDiff(cmd *cobra.Command, args []string) error {
+	src0, err := openStateSource(args[0])
+	if err != nil {
+		return err
+	}
+	defer src0.close()
+
+	src1, err := openStateSource(args[1])
+	if err != nil {
+		return err
+	}
+	defer src1.close()
+
+	height := diffHeight
+	if height <= 0 {
+		h0, h1 := src0.lastHeight(), src1.lastHeight()
+		height = min(h0, h1)
+	}
+
+	mh0, err := src0.moduleHashes(height)
+	if err != nil {
+		return fmt.Errorf("%s: %v", args[0], err)
+	}
+	mh1, err := src1.moduleHashes(height)
+	if err != nil {
+		return fmt.Errorf("%s: %v", args[1], err)
+	}
+
+	fmt.Printf("height: %d\n", height)
+	fmt.Printf("%-8s %-66s %-66s\n", "module", args[0], args[1])
+	fmt.Printf("%-8s %-66s %-66s\n", "app", mh0.AppHash, mh1.AppHash)
+	for _, name := range ctrlertypes.ModuleNames {
+		var h0, h1 abytes.HexBytes
+		if h := mh0.Find(name); h != nil {
+			h0 = h.Hash
+		}
+		if h := mh1.Find(name); h != nil {
+			h1 = h.Hash
+		}
+		mark := ""
+		if !bytes.Equal(h0, h1) {
+			mark = "*"
+		}
+		fmt.Printf("%-8s %-66s %-66s %s\n", name, h0, h1, mark)
+	}
+
+	diffs := mh0.Diff(mh1)
+	if len(diffs) == 0 {
+		fmt.Println("no difference")
+		return nil
+	}
+
+	dir0, ok0 := src0.(*dirStateSource)
+	dir1, ok1 := src1.(*dirStateSource)
+	if !ok0 || !ok1 {
+		fmt.Println("differing ledger keys can be listed only when both sources are home directories")
+		return nil
+	}
+
+	for _, name := range diffs {
+		if _, ok := moduleLedgerNames[name]; !ok {
+			fmt.Printf("[%s] the key-level difference is not supported\n", name)
+			continue
+		}
+		if err := diffModuleLedger(name, height, dir0, dir1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func diffModuleLedger(name string, height int64, dir0, dir1 *dirStateSource) error {
+	l0, err := dir0.ledger(name)
+	if err != nil {
+		return err
+	}
+	l1, err := dir1.ledger(name)
+	if err != nil {
+		return err
+	}
+
+	errStop := xerrors.NewOrdinary("stop")
+	cnt := 0
+	xerr := v1.DiffAt(height, l0, l1, func(key v1.LedgerKey, val0, val1 []byte) xerrors.XError {
+		if diffMaxKeys > 0 && cnt >= diffMaxKeys {
+			return errStop
+		}
+		cnt++
+
+		status := "changed"
+		if val0 == nil {
+			status = "only in " + dir1.home
+		} else if val1 == nil {
+			status = "only in " + dir0.home
+		}
+		fmt.Printf("[%s] %s %X (%s)\n", name, v1.KeyPrefixName(key), key, status)
+		if diffShowValues {
+			fmt.Printf("  %X\n  %X\n", val0, val1)
+		}
+		return nil
+	})
+	if xerr != nil && xerr != errStop {
+		return xerr
+	}
+	if diffMaxKeys > 0 && cnt >= diffMaxKeys {
+		fmt.Printf("[%s] more differing keys may exist (max-keys: %d)\n", name, diffMaxKeys)
+	}
+	return nil
+}
+
+type stateSource interface {
+	lastHeight() int64
+	moduleHashes(height int64) (*ctrlertypes.ModuleHashes, error)
+	close()
+}
+
+func openStateSource(src string) (stateSource, error) {
+	if strings.Contains(src, "://") {
+		return &rpcStateSource{
+			bzweb3: web3.NewBeatozWeb3(web3.NewHttpProvider(src)),
+		}, nil
+	}
+
+	conf := tmcfg.DefaultConfig()
+	conf.SetRoot(src)
+	if _, err := os.Stat(conf.DBDir()); err != nil {
+		return nil, err
+	}
+	return &dirStateSource{
+		home:    src,
+		dbDir:   conf.DBDir(),
+		ledgers: make(map[string]*v1.MutableLedger),
+	}, nil
+}
+
+type rpcStateSource struct {
+	bzweb3 *web3.BeatozWeb3
+}
+
+func (src *rpcStateSource) lastHeight() int64 {
+	status, err := src.bzweb3.Status()
+	if err != nil {
+		return 0
+	}
+	return status.SyncInfo.LatestBlockHeight
+}
+
+func (src *rpcStateSource) moduleHashes(height int64) (*ctrlertypes.ModuleHashes, error) {
+	return src.bzweb3.QueryModuleHashes(height)
+}
+
+func (src *rpcStateSource) close() {}
+
+type dirStateSource struct {
+	home    string
+	dbDir   string
+	ledgers map[string]*v1.MutableLedger
+}
+
+func (src *dirStateSource) ledger(name string) (*v1.MutableLedger, error) {
+	if l, ok := src.ledgers[name]; ok {
+		return l, nil
+	}
+
+	dbName := moduleLedgerNames[name]
+	if _, err := os.Stat(filepath.Join(src.dbDir, dbName+".db")); err != nil {
+		return nil, err
+	}
+	l, xerr := v1.NewMutableLedger(dbName, src.dbDir, 1000, nil, tmlog.NewNopLogger())
+	if xerr != nil {
+		return nil, xerr
+	}
+	src.ledgers[name] = l
+	return l, nil
+}
+
+func (src *dirStateSource) lastHeight() int64 {
+	l, err := src.ledger("gov")
+	if err != nil {
+		return 0
+	}
+	return l.Version()
+}
+
+func (src *dirStateSource) moduleHashes(height int64) (*ctrlertypes.ModuleHashes, error) {
+	ret := ctrlertypes.NewModuleHashes(height)
+	hasher := crypto.DefaultHasher()
+	for _, name := range ctrlertypes.ModuleNames {
+		var hash []byte
+		if name == "vm" {
+			h, xerr := evm.RootHashAt(src.dbDir, height)
+			if xerr != nil {
+				return nil, xerr
+			}
+			hash = h
+		} else {
+			l, err := src.ledger(name)
+			if err != nil {
+				return nil, err
+			}
+			tree, xerr := l.GetReadOnlyTree(height)
+			if xerr != nil {
+				return nil, xerr
+			}
+			hash = tree.Hash()
+		}
+		_, _ = hasher.Write(hash)
+		ret.Add(name, hash)
+	}
+	ret.AppHash = hasher.Sum(nil)
+	return ret, nil
+}
+
+func (src *dirStateSource) close() {
+	for _, l := range src.ledgers {
+		_ = l.Close()
+	}
+	src.ledgers = nil
+}
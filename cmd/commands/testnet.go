@@ -0,0 +1,394 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cfg "github.com/beatoz/beatoz-go/cmd/config"
+	"github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/genesis"
+	"github.com/beatoz/beatoz-go/libs"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	acrypto "github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/spf13/cobra"
+	tmcfg "github.com/tendermint/tendermint/config"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/p2p"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	testnetPortStep   = 10
+	testnetDockerHome = "/root/.beatoz"
+)
+
+var (
+	testnetParams = DefaultTestnetParams()
+)
+
+// NewTestnetCmd returns the command that generates the home directories of a local multi-validator network.
+func NewTestnetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "testnet",
+		Short: "Initialize the files for a local multi-validator testnet",
+		Long: `Initialize the home directories of the validator nodes for a local testnet.
+Each node directory has its own priv_validator_key.json and node key and
+all nodes share one genesis including every validator.
+The nodes are wired to each other via persistent_peers.
+The holder's wallet key files and the validator's key files are saved at <output>/walkeys.
+
+Example:
+	beatoz testnet --validators 4 --output ./net --docker_compose
+`,
+		Args: cobra.NoArgs,
+		RunE: testnetFiles,
+	}
+	AddTestnetFlags(cmd)
+	return cmd
+}
+
+func AddTestnetFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&testnetParams.ValCnt,
+		"validators",
+		testnetParams.ValCnt,
+		"the number of validator nodes to be generated")
+	cmd.Flags().StringVar(
+		&testnetParams.OutputDir,
+		"output",
+		testnetParams.OutputDir,
+		"the directory where the home directories of the nodes are generated")
+	cmd.Flags().StringVar(
+		&testnetParams.NodeDirPrefix,
+		"node_dir_prefix",
+		testnetParams.NodeDirPrefix,
+		"the prefix of the node's home directory name and hostname (e.g. node0, node1, ...)")
+	cmd.Flags().StringVar(
+		&testnetParams.ChainID,
+		"chain_id",
+		testnetParams.ChainID,
+		"the ID of the chain to be created;\nit must be a decimal or 0x-prefixed hexadecimal number")
+	cmd.Flags().IntVar(
+		&testnetParams.HolderCnt,
+		"holders",
+		testnetParams.HolderCnt,
+		"the number of pre-funded holder's account files to be generated at <output>/walkeys")
+	cmd.Flags().Int64Var(
+		&testnetParams.InitTotalSupply,
+		"init_total_supply",
+		testnetParams.InitTotalSupply,
+		"initial total supply at genesis, shared equally by all holders; it includes the initial voting power")
+	cmd.Flags().Int64Var(
+		&testnetParams.InitVotingPower,
+		"init_voting_power",
+		testnetParams.InitVotingPower,
+		"initial voting power at genesis, shared equally by all validators")
+	cmd.Flags().StringVar(
+		&testnetParams.GovParamsFile,
+		"gov_params",
+		testnetParams.GovParamsFile,
+		"the JSON file of the governance parameters overriding the default ones;\n"+
+			"the parameters not specified in the file keep the default values")
+	cmd.Flags().StringVar(
+		&testnetParams.CreateEmptyBlocksInterval,
+		"consensus.create_empty_blocks_interval",
+		testnetParams.CreateEmptyBlocksInterval,
+		"the possible interval between empty blocks")
+	cmd.Flags().IntVar(
+		&testnetParams.StartingPort,
+		"starting_port",
+		testnetParams.StartingPort,
+		fmt.Sprintf("the p2p port of the first node;\n"+
+			"the ports of the i-th node are shifted by i*%d.\n"+
+			"when docker_compose is set, all nodes use the same ports in their containers", testnetPortStep))
+	cmd.Flags().BoolVar(
+		&testnetParams.DockerCompose,
+		"docker_compose",
+		testnetParams.DockerCompose,
+		"write <output>/docker-compose.yml running the nodes with the image built from the Dockerfile")
+}
+
+func testnetFiles(cmd *cobra.Command, args []string) error {
+	var s0, s1 []byte
+
+	_secret := os.Getenv("BEATOZ_VALIDATOR_SECRET")
+	if _secret == "" {
+		s0 = libs.ReadCredential("Passphrase for validator's key files: ")
+	} else {
+		s0 = []byte(_secret)
+	}
+
+	_secret = os.Getenv("BEATOZ_HOLDER_SECRET")
+	if _secret == "" {
+		s1 = libs.ReadCredential("Passphrase for initial holder's accounts: ")
+	} else {
+		s1 = []byte(_secret)
+	}
+
+	defer func() {
+		libs.ClearCredential(s0)
+		libs.ClearCredential(s1)
+	}()
+
+	testnetParams.ValSecret = s0
+	testnetParams.HolderSecret = s1
+
+	_, err := InitTestnetWith(testnetParams)
+	return err
+}
+
+// InitTestnetWith generates the home directories of `params.ValCnt` validator nodes under `params.OutputDir`.
+// The genesis is created once by `InitFilesWith` on the first node and shared by all nodes.
+// It returns the configs of the generated nodes.
+func InitTestnetWith(params *TestnetParams) ([]*cfg.Config, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var overrides []byte
+	if params.GovParamsFile != "" {
+		bz, err := os.ReadFile(params.GovParamsFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := jsonx.Unmarshal(bz, &types.GovParams{}); err != nil {
+			return nil, fmt.Errorf("invalid gov_params: %v", err)
+		}
+		overrides = bz
+	}
+
+	interval, _ := time.ParseDuration(params.CreateEmptyBlocksInterval)
+
+	var configs []*cfg.Config
+	var nodeIDs []p2p.ID
+	for i := 0; i < params.ValCnt; i++ {
+		config := cfg.DefaultConfig(params.ChainID)
+		config.SetRoot(filepath.Join(params.OutputDir, params.NodeName(i)))
+		tmcfg.EnsureRoot(config.RootDir)
+
+		config.Moniker = params.NodeName(i)
+		config.Consensus.CreateEmptyBlocks = params.CreateEmptyBlocks
+		config.Consensus.CreateEmptyBlocksInterval = interval
+		config.P2P.AddrBookStrict = false
+		config.P2P.AllowDuplicateIP = true
+
+		if i == 0 {
+			// the validator keys of all nodes, the holder's wallet keys and the genesis are generated here.
+			err := InitFilesWith(config, params.InitParams, func(genDoc *tmtypes.GenesisDoc) error {
+				if overrides == nil {
+					return nil
+				}
+				appState := &genesis.GenesisAppState{}
+				if err := jsonx.Unmarshal(genDoc.AppState, appState); err != nil {
+					return err
+				}
+				// the parameters not specified in the file keep the default values.
+				merged, xerr := types.MergeGovParamsJSON(appState.GovParams, overrides)
+				if xerr != nil {
+					return fmt.Errorf("invalid gov_params: %w", xerr)
+				}
+				// the merged parameters are validated in the same way as a governance parameters proposal.
+				if xerr := merged.Validate(); xerr != nil {
+					return fmt.Errorf("invalid gov_params: %w", xerr)
+				}
+				appState.GovParams = merged
+
+				bz, err := jsonx.Marshal(appState)
+				if err != nil {
+					return err
+				}
+				genDoc.AppState = bz
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			if err := copyValidatorFiles(configs[0], config, i); err != nil {
+				return nil, err
+			}
+			if err := copyFile(configs[0].GenesisFile(), config.GenesisFile()); err != nil {
+				return nil, err
+			}
+		}
+
+		nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+		nodeIDs = append(nodeIDs, nodeKey.ID())
+	}
+
+	// All key files are moved out of the first node's home,
+	// so that no node has the validator keys of the other nodes.
+	walkeyDir := filepath.Join(params.OutputDir, acrypto.DefaultWalletKeyDir)
+	_ = os.RemoveAll(walkeyDir)
+	if err := os.Rename(filepath.Join(configs[0].RootDir, acrypto.DefaultWalletKeyDir), walkeyDir); err != nil {
+		return nil, err
+	}
+
+	for i, config := range configs {
+		_, p2pPort, rpcPort := params.nodeAddress(i)
+		config.P2P.ListenAddress = fmt.Sprintf("tcp://0.0.0.0:%d", p2pPort)
+		config.RPC.ListenAddress = fmt.Sprintf("tcp://%s:%d", params.rpcListenHost(), rpcPort)
+		config.ProxyApp = fmt.Sprintf("tcp://127.0.0.1:%d", p2pPort+2)
+		config.Instrumentation.PrometheusListenAddr = fmt.Sprintf(":%d", p2pPort+4)
+
+		var peers []string
+		for j, id := range nodeIDs {
+			if j == i {
+				continue
+			}
+			peerHost, peerPort, _ := params.nodeAddress(j)
+			peers = append(peers, fmt.Sprintf("%s@%s:%d", id, peerHost, peerPort))
+		}
+		config.P2P.PersistentPeers = strings.Join(peers, ",")
+
+		if err := config.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("error in config of %s: %v", config.Moniker, err)
+		}
+		tmcfg.WriteConfigFile(filepath.Join(config.RootDir, "config", "config.toml"), config.Config)
+		logger.Info("Generated testnet node", "home", config.RootDir, "nodeID", nodeIDs[i])
+	}
+
+	if params.DockerCompose {
+		if err := writeDockerCompose(params); err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
+// copyValidatorFiles copies the `idx`-th validator's key files generated in the home of `src`
+// to the priv_validator files of `dst`.
+func copyValidatorFiles(src, dst *cfg.Config, idx int) error {
+	valDir := filepath.Join(src.RootDir, acrypto.DefaultValKeyDir)
+	for _, f := range []string{src.PrivValidatorKeyFile(), src.PrivValidatorStateFile()} {
+		ext := filepath.Ext(f)
+		from := filepath.Join(valDir, fmt.Sprintf("%s%d%s", strings.TrimSuffix(filepath.Base(f), ext), idx, ext))
+		to := dst.PrivValidatorKeyFile()
+		if f == src.PrivValidatorStateFile() {
+			to = dst.PrivValidatorStateFile()
+		}
+		if err := copyFile(from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(from, to string) error {
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(to, data, libs.DefaultSFilePerm)
+}
+
+func writeDockerCompose(params *TestnetParams) error {
+	sb := &strings.Builder{}
+	sb.WriteString("services:\n")
+	for i := 0; i < params.ValCnt; i++ {
+		name := params.NodeName(i)
+		_, p2pPort, rpcPort := params.nodeAddress(i)
+		hostRPCPort := params.StartingPort + 1 + i*testnetPortStep
+
+		fmt.Fprintf(sb, "  %s:\n", name)
+		fmt.Fprintf(sb, "    build:\n")
+		fmt.Fprintf(sb, "      context: %s\n", params.DockerContext)
+		fmt.Fprintf(sb, "      dockerfile: Dockerfile\n")
+		fmt.Fprintf(sb, "    image: beatoz/beatoz-testnet\n")
+		fmt.Fprintf(sb, "    container_name: %s\n", name)
+		fmt.Fprintf(sb, "    hostname: %s\n", name)
+		fmt.Fprintf(sb, "    environment:\n")
+		fmt.Fprintf(sb, "      - BEATOZ_VALIDATOR_SECRET=${BEATOZ_VALIDATOR_SECRET}\n")
+		fmt.Fprintf(sb, "    command: [\"start\", \"--home\", \"%s\", \"--rpc.laddr\", \"tcp://0.0.0.0:%d\", \"--rpc.cors_allowed_origins\", \"*\"]\n", testnetDockerHome, rpcPort)
+		fmt.Fprintf(sb, "    ports:\n")
+		fmt.Fprintf(sb, "      - \"%d:%d\"\n", hostRPCPort, rpcPort)
+		fmt.Fprintf(sb, "    expose:\n")
+		fmt.Fprintf(sb, "      - \"%d\"\n", p2pPort)
+		fmt.Fprintf(sb, "    volumes:\n")
+		fmt.Fprintf(sb, "      - ./%s:%s\n", name, testnetDockerHome)
+		fmt.Fprintf(sb, "    networks:\n")
+		fmt.Fprintf(sb, "      - beatoz-testnet\n")
+	}
+	sb.WriteString("\nnetworks:\n")
+	sb.WriteString("  beatoz-testnet:\n")
+	sb.WriteString("    driver: bridge\n")
+
+	path := filepath.Join(params.OutputDir, "docker-compose.yml")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return err
+	}
+	logger.Info("Generated docker-compose file", "path", path)
+	return nil
+}
+
+type TestnetParams struct {
+	*InitParams
+
+	OutputDir     string
+	NodeDirPrefix string
+	GovParamsFile string
+	StartingPort  int
+	DockerCompose bool
+	// DockerContext is the build context of the Dockerfile relative to OutputDir.
+	DockerContext string
+}
+
+func DefaultTestnetParams() *TestnetParams {
+	initParams := DefaultInitParams()
+	initParams.ChainID = "0x1234"
+	initParams.ValCnt = 4
+	return &TestnetParams{
+		InitParams:    initParams,
+		OutputDir:     "./net",
+		NodeDirPrefix: "node",
+		StartingPort:  26656,
+		DockerContext: "..",
+	}
+}
+
+func (params *TestnetParams) NodeName(i int) string {
+	return fmt.Sprintf("%s%d", params.NodeDirPrefix, i)
+}
+
+// nodeAddress returns the host, the p2p port and the rpc port of the `i`-th node.
+// In docker, every node runs in its own container and uses the same ports.
+func (params *TestnetParams) nodeAddress(i int) (string, int, int) {
+	if params.DockerCompose {
+		return params.NodeName(i), params.StartingPort, params.StartingPort + 1
+	}
+	p2pPort := params.StartingPort + i*testnetPortStep
+	return "127.0.0.1", p2pPort, p2pPort + 1
+}
+
+func (params *TestnetParams) rpcListenHost() string {
+	if params.DockerCompose {
+		return "0.0.0.0"
+	}
+	return "127.0.0.1"
+}
+
+func (params *TestnetParams) Validate() error {
+	if params.ValCnt < 1 {
+		return fmt.Errorf("invalid number of validators: %d", params.ValCnt)
+	}
+	if params.OutputDir == "" {
+		return fmt.Errorf("output directory is not specified")
+	}
+	if params.NodeDirPrefix == "" {
+		return fmt.Errorf("node_dir_prefix is not specified")
+	}
+	if params.StartingPort <= 0 || params.StartingPort+params.ValCnt*testnetPortStep > 65535 {
+		return fmt.Errorf("invalid starting_port: %d", params.StartingPort)
+	}
+	if tmos.FileExists(filepath.Join(params.OutputDir, params.NodeName(0))) {
+		return fmt.Errorf("%s already exists", filepath.Join(params.OutputDir, params.NodeName(0)))
+	}
+	return params.InitParams.Validate()
+}
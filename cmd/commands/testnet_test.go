@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/genesis"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	acrypto "github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/p2p"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func Test_InitTestnet(t *testing.T) {
+	logger = tmlog.NewNopLogger()
+
+	govParamsFile := filepath.Join(t.TempDir(), "gov_params.json")
+	require.NoError(t, os.WriteFile(govParamsFile, []byte(`{"inflationCycleBlocks": 10}`), 0o644))
+
+	params := DefaultTestnetParams()
+	params.ValCnt = 3
+	params.HolderCnt = 5
+	params.ValSecret = bytes.RandBytes(12)
+	params.HolderSecret = bytes.RandBytes(12)
+	params.OutputDir = t.TempDir()
+	params.GovParamsFile = govParamsFile
+	params.DockerCompose = true
+
+	configs, err := InitTestnetWith(params)
+	require.NoError(t, err)
+	require.Len(t, configs, params.ValCnt)
+
+	genJZ, err := os.ReadFile(configs[0].GenesisFile())
+	require.NoError(t, err)
+	genDoc, err := tmtypes.GenesisDocFromJSON(genJZ)
+	require.NoError(t, err)
+	require.Len(t, genDoc.Validators, params.ValCnt)
+
+	appState := &genesis.GenesisAppState{}
+	require.NoError(t, jsonx.Unmarshal(genDoc.AppState, appState))
+	require.Len(t, appState.AssetHolders, params.HolderCnt)
+	require.EqualValues(t, 10, appState.GovParams.InflationCycleBlocks())
+	// the app hash is computed from the overridden app state.
+	appHash, err := appState.Hash()
+	require.NoError(t, err)
+	require.EqualValues(t, appHash, genDoc.AppHash)
+	// the parameters not overridden keep the default values.
+	require.Equal(t, DefaultTestnetParams().CreateEmptyBlocksInterval, fmt.Sprintf("%ds", appState.GovParams.EmptyBlockIntervalSecs()))
+
+	nodeIDs := make(map[p2p.ID]bool)
+	for i, config := range configs {
+		// all nodes share the same genesis.
+		jz, err := os.ReadFile(config.GenesisFile())
+		require.NoError(t, err)
+		require.Equal(t, genJZ, jz)
+
+		// each node has its own validator key included in the genesis.
+		pv := acrypto.LoadSFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), params.ValSecret)
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		require.Equal(t, genDoc.Validators[i].Address, pubKey.Address())
+		require.False(t, tmos.FileExists(filepath.Join(config.RootDir, acrypto.DefaultWalletKeyDir)))
+
+		nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+		require.NoError(t, err)
+		require.False(t, nodeIDs[nodeKey.ID()])
+		nodeIDs[nodeKey.ID()] = true
+
+		// config.toml is wired to the other nodes.
+		peers := strings.Split(readPersistentPeers(t, filepath.Join(config.RootDir, "config", "config.toml")), ",")
+		require.Len(t, peers, params.ValCnt-1)
+		for _, peer := range peers {
+			require.NotContains(t, peer, string(nodeKey.ID()))
+		}
+	}
+	for i := 0; i < params.ValCnt; i++ {
+		nodeKey, err := p2p.LoadNodeKey(configs[i].NodeKeyFile())
+		require.NoError(t, err)
+		for j, config := range configs {
+			peers := readPersistentPeers(t, filepath.Join(config.RootDir, "config", "config.toml"))
+			require.Equal(t, i != j, strings.Contains(peers, fmt.Sprintf("%s@%s:", nodeKey.ID(), params.NodeName(i))))
+		}
+	}
+
+	require.True(t, tmos.FileExists(filepath.Join(params.OutputDir, acrypto.DefaultValKeyDir)))
+	compose, err := os.ReadFile(filepath.Join(params.OutputDir, "docker-compose.yml"))
+	require.NoError(t, err)
+	for i := 0; i < params.ValCnt; i++ {
+		require.Contains(t, string(compose), fmt.Sprintf("./%s:%s", params.NodeName(i), testnetDockerHome))
+	}
+
+	// the output directory which already has nodes can not be overwritten.
+	_, err = InitTestnetWith(params)
+	require.Error(t, err)
+
+	// the gov_params is validated in the same way as a governance parameters proposal.
+	require.NoError(t, os.WriteFile(govParamsFile, []byte(`{"txFeeRewardRate": 101}`), 0o644))
+	params.OutputDir = t.TempDir()
+	_, err = InitTestnetWith(params)
+	require.ErrorContains(t, err, "txFeeRewardRate")
+}
+
+func readPersistentPeers(t *testing.T, file string) string {
+	bz, err := os.ReadFile(file)
+	require.NoError(t, err)
+	for _, line := range strings.Split(string(bz), "\n") {
+		if strings.HasPrefix(line, "persistent_peers = ") {
+			return strings.Trim(strings.TrimPrefix(line, "persistent_peers = "), `"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,840 @@
+package commands
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beatoz/beatoz-go/cmd/commands/web3"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/holiman/uint256"
+	"github.com/spf13/cobra"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+var (
+	txChainID  string
+	txFrom     string
+	txTo       string
+	txPayer    string
+	txNonce    int64
+	txGas      int64
+	txGasPrice string
+	txAmount   string
+	txOutput   string
+	txKeyFile  string
+	txMode     string
+
+	txHashArg     string
+	txChoice      int32
+	txMessage     string
+	txStartHeight int64
+	txPeriod      int64
+	txApplyHeight int64
+	txOptType     int32
+	txOptions     []string
+	txData        string
+	txDocName     string
+	txDocURL      string
+	txP256PubKey  string
+	txWithdrawAmt string
+	txToken       string
+	txTokenName   string
+	txTokenSymbol string
+	txTokenDecs   int32
+	txMaxSupply   string
+	txInitSupply  string
+	txMintAuth    string
+	txBzweb3      *web3.BeatozWeb3
+	txGovParams   *ctrlertypes.GovParams
+)
+
+// txFile is the file format which `tx build`, `tx sign`, `tx sign-payer` and `tx broadcast` work on.
+// The chain ID is kept with the tx, so the tx can be signed on the machine without RPC access.
+type txFile struct {
+	ChainID string           `json:"chain_id"`
+	Tx      *ctrlertypes.Trx `json:"tx"`
+}
+
+// txKind is a type of transaction which `tx build` can build.
+type txKind struct {
+	use   string
+	short string
+	flags func(cmd *cobra.Command)
+	build func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error)
+}
+
+func NewTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Transaction commands",
+		Long: `Transaction commands.
+Each type of tx can be built, signed with a wallet key file and broadcast at once (e.g. 'tx transfer').
+'build', 'sign', 'sign-payer' and 'broadcast' work on the tx files, so the tx can be signed on the machine without RPC access.`,
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&rpcUrl,
+		"rpcurl",
+		"http://localhost:26657",
+		"BEATOZ RPC URL")
+
+	cmd.AddCommand(
+		newTxBuildCmd(),
+		newTxSignCmd(),
+		newTxSignPayerCmd(),
+		newTxBroadcastCmd(),
+	)
+	cmd.AddCommand(newTxSendCmds()...)
+	return cmd
+}
+
+func newTxBuildCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Build an unsigned tx file",
+		Long: `Build an unsigned tx file.
+The chain ID, nonce, gas and gas price are fetched through '--rpcurl' unless they are provided.
+If all of them are provided, the tx is built without RPC access.`,
+	}
+	cmd.PersistentFlags().StringVar(&txChainID, "chain_id", "", "chain ID (default: fetched from the node)")
+	cmd.PersistentFlags().StringVar(&txFrom, "from", "", "address of the sender")
+	cmd.PersistentFlags().StringVar(&txPayer, "payer", "", "address of the payer covering the fee (optional)")
+	cmd.PersistentFlags().Int64Var(&txNonce, "nonce", 0, "nonce of the sender (default: fetched from the node)")
+	cmd.PersistentFlags().Int64Var(&txGas, "gas", 0, "gas limit (default: the minimum gas of the governance parameters or the estimated gas of the contract)")
+	cmd.PersistentFlags().StringVar(&txGasPrice, "gas_price", "", "gas price (default: the gas price of the governance parameters)")
+	cmd.PersistentFlags().StringVar(&txOutput, "output", "", "path of the tx file to write (default: stdout)")
+	_ = cmd.MarkPersistentFlagRequired("from")
+
+	for _, kind := range txKinds() {
+		sub := &cobra.Command{
+			Use:   kind.use,
+			Short: kind.short,
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return runTxBuild(cmd, kind)
+			},
+		}
+		kind.flags(sub)
+		cmd.AddCommand(sub)
+	}
+	return cmd
+}
+
+func txKinds() []*txKind {
+	return []*txKind{
+		{
+			use:   "transfer",
+			short: "Transfer the amount to an account",
+			flags: func(cmd *cobra.Command) {
+				addTxToFlag(cmd, true)
+				addTxAmountFlag(cmd, true)
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				to, amt, err := parseTxToAmount()
+				if err != nil {
+					return nil, err
+				}
+				return web3.NewTrxTransfer(from, to, nonce, gas, gasPrice, amt), nil
+			},
+		},
+		{
+			use:   "stake",
+			short: "Stake the amount to a validator (to itself for self-bonding)",
+			flags: func(cmd *cobra.Command) {
+				addTxToFlag(cmd, true)
+				addTxAmountFlag(cmd, true)
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				to, amt, err := parseTxToAmount()
+				if err != nil {
+					return nil, err
+				}
+				return web3.NewTrxStaking(from, to, nonce, gas, gasPrice, amt), nil
+			},
+		},
+		{
+			use:   "unstake",
+			short: "Unstake the voting power bonded by a staking tx",
+			flags: func(cmd *cobra.Command) {
+				addTxToFlag(cmd, true)
+				addTxHashFlag(cmd, "hash of the staking tx to unstake")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				to, err := types.HexToAddress(txTo)
+				if err != nil {
+					return nil, fmt.Errorf("invalid to: %w", err)
+				}
+				txhash, err := parseHexArg(txHashArg)
+				if err != nil {
+					return nil, fmt.Errorf("invalid txhash: %w", err)
+				}
+				return web3.NewTrxUnstaking(from, to, nonce, gas, gasPrice, txhash), nil
+			},
+		},
+		{
+			use:   "withdraw",
+			short: "Withdraw the reward",
+			flags: func(cmd *cobra.Command) {
+				cmd.Flags().StringVar(&txWithdrawAmt, "amount", "", "amount of the reward to withdraw (decimal number)")
+				_ = cmd.MarkFlagRequired("amount")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				amt, err := uint256.FromDecimal(txWithdrawAmt)
+				if err != nil {
+					return nil, fmt.Errorf("invalid amount: %w", err)
+				}
+				return web3.NewTrxWithdraw(from, from, nonce, gas, gasPrice, amt), nil
+			},
+		},
+		{
+			use:   "propose",
+			short: "Submit a governance proposal with the initial deposit",
+			flags: func(cmd *cobra.Command) {
+				addTxAmountFlag(cmd, false)
+				cmd.Flags().StringVar(&txMessage, "message", "", "message of the proposal")
+				cmd.Flags().Int64Var(&txStartHeight, "start_height", 0, "height at which the voting starts")
+				cmd.Flags().Int64Var(&txPeriod, "period", 0, "voting period in blocks")
+				cmd.Flags().Int64Var(&txApplyHeight, "applying_height", 0, "height at which the proposal is applied")
+				cmd.Flags().Int32Var(&txOptType, "opt_type", 0, "type of the options (257: governance parameters, 258: treasury spend, 259: allow-list, 512: common)")
+				cmd.Flags().StringArrayVar(&txOptions, "option", nil, "option of the proposal (e.g. the JSON of governance parameters); repeatable")
+				_ = cmd.MarkFlagRequired("start_height")
+				_ = cmd.MarkFlagRequired("period")
+				_ = cmd.MarkFlagRequired("applying_height")
+				_ = cmd.MarkFlagRequired("opt_type")
+				_ = cmd.MarkFlagRequired("option")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				options := make([][]byte, len(txOptions))
+				for i, opt := range txOptions {
+					options[i] = []byte(opt)
+				}
+				amt, err := uint256.FromDecimal(txAmount)
+				if err != nil {
+					return nil, fmt.Errorf("invalid amount: %w", err)
+				}
+				tx := web3.NewTrxProposal(from, types.ZeroAddress(), nonce, gas, gasPrice,
+					txMessage, txStartHeight, txPeriod, txApplyHeight, txOptType, options...)
+				tx.Amount = amt
+				return tx, nil
+			},
+		},
+		{
+			use:   "deposit",
+			short: "Add a deposit to a governance proposal",
+			flags: func(cmd *cobra.Command) {
+				addTxHashFlag(cmd, "hash of the proposal tx")
+				addTxAmountFlag(cmd, true)
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				txhash, err := parseHexArg(txHashArg)
+				if err != nil {
+					return nil, fmt.Errorf("invalid txhash: %w", err)
+				}
+				amt, err := uint256.FromDecimal(txAmount)
+				if err != nil {
+					return nil, fmt.Errorf("invalid amount: %w", err)
+				}
+				return web3.NewTrxDeposit(from, nonce, gas, gasPrice, amt, txhash), nil
+			},
+		},
+		{
+			use:   "cancel",
+			short: "Cancel a governance proposal submitted by the sender",
+			flags: func(cmd *cobra.Command) {
+				addTxHashFlag(cmd, "hash of the proposal tx")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				txhash, err := parseHexArg(txHashArg)
+				if err != nil {
+					return nil, fmt.Errorf("invalid txhash: %w", err)
+				}
+				return web3.NewTrxCancelProposal(from, nonce, gas, gasPrice, txhash), nil
+			},
+		},
+		{
+			use:   "vote",
+			short: "Vote on a governance proposal",
+			flags: func(cmd *cobra.Command) {
+				addTxHashFlag(cmd, "hash of the proposal tx")
+				cmd.Flags().Int32Var(&txChoice, "choice", 0, "index of the option to vote for, -2 to abstain or -3 to veto")
+				_ = cmd.MarkFlagRequired("choice")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				txhash, err := parseHexArg(txHashArg)
+				if err != nil {
+					return nil, fmt.Errorf("invalid txhash: %w", err)
+				}
+				return web3.NewTrxVoting(from, types.ZeroAddress(), nonce, gas, gasPrice, txhash, txChoice), nil
+			},
+		},
+		{
+			use:   "contract",
+			short: "Deploy or call a contract",
+			flags: func(cmd *cobra.Command) {
+				addTxToFlag(cmd, false)
+				addTxAmountFlag(cmd, false)
+				cmd.Flags().StringVar(&txData, "data", "", "hex of the contract bytecode to deploy or the input data to call")
+				_ = cmd.MarkFlagRequired("data")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				to, amt, data, err := parseTxContract()
+				if err != nil {
+					return nil, err
+				}
+				return web3.NewTrxContract(from, to, nonce, gas, gasPrice, amt, data), nil
+			},
+		},
+		{
+			use:   "setdoc",
+			short: "Set the name and the document URL of the account",
+			flags: func(cmd *cobra.Command) {
+				cmd.Flags().StringVar(&txDocName, "name", "", "name of the account")
+				cmd.Flags().StringVar(&txDocURL, "url", "", "URL of the document of the account")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				return web3.NewTrxSetDoc(from, nonce, gas, gasPrice, txDocName, txDocURL), nil
+			},
+		},
+		{
+			use:   "setpubkey",
+			short: "Register the P-256 public key(passkey) to the account",
+			flags: func(cmd *cobra.Command) {
+				cmd.Flags().StringVar(&txP256PubKey, "pubkey", "", "hex of the compressed or uncompressed P-256 public key (empty to remove)")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				pubKey, err := parseHexArg(txP256PubKey)
+				if err != nil {
+					return nil, fmt.Errorf("invalid pubkey: %w", err)
+				}
+				return web3.NewTrxSetPubKey(from, nonce, gas, gasPrice, pubKey), nil
+			},
+		},
+		{
+			use:   "token-issue",
+			short: "Issue a native token whose initial supply is minted to the sender",
+			flags: func(cmd *cobra.Command) {
+				cmd.Flags().StringVar(&txTokenName, "name", "", "name of the token")
+				cmd.Flags().StringVar(&txTokenSymbol, "symbol", "", "symbol of the token")
+				cmd.Flags().Int32Var(&txTokenDecs, "decimals", 18, "decimals of the token")
+				cmd.Flags().StringVar(&txMaxSupply, "max_supply", "0", "max supply of the token (decimal number, 0 for no cap)")
+				cmd.Flags().StringVar(&txInitSupply, "initial_supply", "0", "initial supply minted to the sender (decimal number)")
+				cmd.Flags().StringVar(&txMintAuth, "mint_authority", "", "address allowed to mint the token (empty for no more minting)")
+				_ = cmd.MarkFlagRequired("symbol")
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				maxSupply, err := uint256.FromDecimal(txMaxSupply)
+				if err != nil {
+					return nil, fmt.Errorf("invalid max_supply: %w", err)
+				}
+				initSupply, err := uint256.FromDecimal(txInitSupply)
+				if err != nil {
+					return nil, fmt.Errorf("invalid initial_supply: %w", err)
+				}
+				var mintAuth types.Address
+				if txMintAuth != "" {
+					if mintAuth, err = types.HexToAddress(txMintAuth); err != nil {
+						return nil, fmt.Errorf("invalid mint_authority: %w", err)
+					}
+				}
+				return web3.NewTrxTokenIssue(from, nonce, gas, gasPrice,
+					txTokenName, txTokenSymbol, txTokenDecs, maxSupply, initSupply, mintAuth), nil
+			},
+		},
+		{
+			use:   "token-mint",
+			short: "Mint a native token to the receiver (only by the mint authority)",
+			flags: func(cmd *cobra.Command) {
+				addTxToFlag(cmd, true)
+				addTxTokenFlags(cmd)
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				to, amt, err := parseTxToAmount()
+				if err != nil {
+					return nil, err
+				}
+				token, err := types.HexToAddress(txToken)
+				if err != nil {
+					return nil, fmt.Errorf("invalid token: %w", err)
+				}
+				return web3.NewTrxTokenMint(from, to, nonce, gas, gasPrice, token, amt), nil
+			},
+		},
+		{
+			use:   "token-burn",
+			short: "Burn a native token held by the sender",
+			flags: func(cmd *cobra.Command) {
+				addTxTokenFlags(cmd)
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				token, err := types.HexToAddress(txToken)
+				if err != nil {
+					return nil, fmt.Errorf("invalid token: %w", err)
+				}
+				amt, err := uint256.FromDecimal(txAmount)
+				if err != nil {
+					return nil, fmt.Errorf("invalid amount: %w", err)
+				}
+				return web3.NewTrxTokenBurn(from, nonce, gas, gasPrice, token, amt), nil
+			},
+		},
+		{
+			use:   "token-transfer",
+			short: "Transfer a native token to the receiver",
+			flags: func(cmd *cobra.Command) {
+				addTxToFlag(cmd, true)
+				addTxTokenFlags(cmd)
+			},
+			build: func(from types.Address, nonce, gas int64, gasPrice *uint256.Int) (*ctrlertypes.Trx, error) {
+				to, amt, err := parseTxToAmount()
+				if err != nil {
+					return nil, err
+				}
+				token, err := types.HexToAddress(txToken)
+				if err != nil {
+					return nil, fmt.Errorf("invalid token: %w", err)
+				}
+				return web3.NewTrxTokenTransfer(from, to, nonce, gas, gasPrice, token, amt), nil
+			},
+		},
+	}
+}
+
+func addTxToFlag(cmd *cobra.Command, required bool) {
+	cmd.Flags().StringVar(&txTo, "to", "", "address of the receiver")
+	if required {
+		_ = cmd.MarkFlagRequired("to")
+	}
+}
+
+func addTxAmountFlag(cmd *cobra.Command, required bool) {
+	cmd.Flags().StringVar(&txAmount, "amount", "0", "amount to send (decimal number, converted to uint256)")
+	if required {
+		_ = cmd.MarkFlagRequired("amount")
+	}
+}
+
+// addTxTokenFlags adds `--token` and `--amount`, where the amount is of the token, not of the native coin.
+func addTxTokenFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&txToken, "token", "", "address of the native token")
+	cmd.Flags().StringVar(&txAmount, "amount", "", "amount of the token (decimal number, converted to uint256)")
+	_ = cmd.MarkFlagRequired("token")
+	_ = cmd.MarkFlagRequired("amount")
+}
+
+func addTxHashFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().StringVar(&txHashArg, "txhash", "", usage)
+	_ = cmd.MarkFlagRequired("txhash")
+}
+
+func parseTxToAmount() (types.Address, *uint256.Int, error) {
+	to, err := types.HexToAddress(txTo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid to: %w", err)
+	}
+	amt, err := uint256.FromDecimal(txAmount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	return to, amt, nil
+}
+
+// parseTxContract returns `nil` as the receiver if `--to` is empty, which means the contract deployment.
+func parseTxContract() (types.Address, *uint256.Int, bytes.HexBytes, error) {
+	var to types.Address
+	if txTo != "" {
+		addr, err := types.HexToAddress(txTo)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid to: %w", err)
+		}
+		to = addr
+	}
+	amt, err := uint256.FromDecimal(txAmount)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	data, err := parseHexArg(txData)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid data: %w", err)
+	}
+	return to, amt, data, nil
+}
+
+func parseHexArg(s string) (bytes.HexBytes, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+}
+
+func parseChainID(chainID string) (*uint256.Int, error) {
+	if strings.HasPrefix(chainID, "0x") {
+		return uint256.FromHex(chainID)
+	}
+	return uint256.FromDecimal(chainID)
+}
+
+func txBuildWeb3() (*web3.BeatozWeb3, *ctrlertypes.GovParams, error) {
+	if txBzweb3 == nil {
+		bzweb3 := web3.NewBeatozWeb3(web3.NewHttpProvider(rpcUrl))
+		gp, err := bzweb3.QueryGovParams()
+		if err != nil {
+			return nil, nil, err
+		}
+		txBzweb3, txGovParams = bzweb3, gp
+	}
+	return txBzweb3, txGovParams, nil
+}
+
+func runTxBuild(cmd *cobra.Command, kind *txKind) error {
+	from, err := types.HexToAddress(txFrom)
+	if err != nil {
+		return fmt.Errorf("invalid from: %w", err)
+	}
+
+	chainID := txChainID
+	if chainID == "" {
+		bzweb3, _, err := txBuildWeb3()
+		if err != nil {
+			return err
+		}
+		chainID = bzweb3.ChainID()
+	}
+	if _, err := parseChainID(chainID); err != nil {
+		return fmt.Errorf("invalid chain ID: %w", err)
+	}
+
+	nonce, gas, gasPrice, err := fillTxParams(cmd, kind, from)
+	if err != nil {
+		return err
+	}
+
+	tx, err := kind.build(from, nonce, gas, gasPrice)
+	if err != nil {
+		return err
+	}
+	if txPayer != "" {
+		payer, err := types.HexToAddress(txPayer)
+		if err != nil {
+			return fmt.Errorf("invalid payer: %w", err)
+		}
+		tx.Payer = payer
+	}
+
+	return writeTxFile(txOutput, &txFile{ChainID: chainID, Tx: tx})
+}
+
+// fillTxParams returns the nonce, gas and gas price of the tx sent by `from`.
+// The values not provided by the flags are fetched through '--rpcurl'.
+func fillTxParams(cmd *cobra.Command, kind *txKind, from types.Address) (int64, int64, *uint256.Int, error) {
+	nonce := txNonce
+	if !cmd.Flags().Changed("nonce") {
+		bzweb3, _, err := txBuildWeb3()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		acct, err := bzweb3.QueryAccount(from)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		nonce = acct.GetNonce()
+	}
+
+	var gasPrice *uint256.Int
+	var err error
+	if txGasPrice != "" {
+		if gasPrice, err = uint256.FromDecimal(txGasPrice); err != nil {
+			return 0, 0, nil, fmt.Errorf("invalid gas price: %w", err)
+		}
+	} else {
+		_, gp, err := txBuildWeb3()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		gasPrice = gp.GasPrice()
+	}
+
+	gas := txGas
+	if !cmd.Flags().Changed("gas") {
+		bzweb3, gp, err := txBuildWeb3()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		gas = gp.MinTrxGas()
+		if kind.use == "contract" {
+			to, _, data, err := parseTxContract()
+			if err != nil {
+				return 0, 0, nil, err
+			}
+			ret, err := bzweb3.VmEstimateGas(from, to, 0, data)
+			if err != nil {
+				return 0, 0, nil, err
+			}
+			if ret.UsedGas > gas {
+				gas = ret.UsedGas
+			}
+		}
+	}
+
+	return nonce, gas, gasPrice, nil
+}
+
+// newTxSendCmds returns the commands which build, sign and broadcast each type of tx at once.
+func newTxSendCmds() []*cobra.Command {
+	var cmds []*cobra.Command
+	for _, kind := range txKinds() {
+		sub := &cobra.Command{
+			Use:   kind.use,
+			Short: kind.short,
+			Long: kind.short + `.
+The tx is signed with the wallet key file and broadcast through '--rpcurl'.
+The nonce, gas and gas price are fetched from the node unless they are provided.`,
+			Args: cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return runTxSend(cmd, kind)
+			},
+		}
+		sub.Flags().StringVar(&txKeyFile, "key", "", "path of the wallet key file of the sender")
+		sub.Flags().Int64Var(&txNonce, "nonce", 0, "nonce of the sender (default: fetched from the node)")
+		sub.Flags().Int64Var(&txGas, "gas", 0, "gas limit (default: the minimum gas of the governance parameters or the estimated gas of the contract)")
+		sub.Flags().StringVar(&txGasPrice, "gas_price", "", "gas price (default: the gas price of the governance parameters)")
+		sub.Flags().StringVar(&txMode, "mode", "commit", "broadcast mode (async|sync|commit)")
+		_ = sub.MarkFlagRequired("key")
+		kind.flags(sub)
+		cmds = append(cmds, sub)
+	}
+	return cmds
+}
+
+func runTxSend(cmd *cobra.Command, kind *txKind) error {
+	wk, err := parseWalletKeyFile(txKeyFile)
+	if err != nil {
+		return err
+	}
+	defer wk.Lock()
+
+	bzweb3, _, err := txBuildWeb3()
+	if err != nil {
+		return err
+	}
+	nonce, gas, gasPrice, err := fillTxParams(cmd, kind, wk.Address)
+	if err != nil {
+		return err
+	}
+	tx, err := kind.build(wk.Address, nonce, gas, gasPrice)
+	if err != nil {
+		return err
+	}
+	if _, err := ctrlertypes.NewSignerV1(bzweb3.ChainIDInt()).SignSender(tx, wk.PrvKey()); err != nil {
+		return err
+	}
+	return broadcastTx(bzweb3, tx, txMode)
+}
+
+func newTxSignCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign [tx file]",
+		Short: "Sign the tx file as the sender with a wallet key file",
+		Long: `Sign the tx file as the sender with a wallet key file.
+It does not need RPC access. If the tx has been signed by the payer, the payer's signature is removed
+since it covers the sender's signature.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTxSign,
+	}
+	cmd.Flags().StringVar(&txKeyFile, "key", "", "path of the wallet key file of the sender")
+	cmd.Flags().StringVar(&txOutput, "output", "", "path of the signed tx file to write (default: stdout)")
+	_ = cmd.MarkFlagRequired("key")
+	return cmd
+}
+
+func runTxSign(cmd *cobra.Command, args []string) error {
+	txf, err := readTxFile(args[0])
+	if err != nil {
+		return err
+	}
+	wk, err := parseWalletKeyFile(txKeyFile)
+	if err != nil {
+		return err
+	}
+	defer wk.Lock()
+
+	if err := signTxFile(txf, wk.Address, wk.PrvKey(), false); err != nil {
+		return err
+	}
+	return writeTxFile(txOutput, txf)
+}
+
+func newTxSignPayerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-payer [tx file]",
+		Short: "Sign the tx file signed by the sender as the payer covering the fee",
+		Long: `Sign the tx file signed by the sender as the payer covering the fee.
+It does not need RPC access. If the payer of the tx is empty, it is set to the address of the wallet key.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTxSignPayer,
+	}
+	cmd.Flags().StringVar(&txKeyFile, "key", "", "path of the wallet key file of the payer")
+	cmd.Flags().StringVar(&txOutput, "output", "", "path of the signed tx file to write (default: stdout)")
+	_ = cmd.MarkFlagRequired("key")
+	return cmd
+}
+
+func runTxSignPayer(cmd *cobra.Command, args []string) error {
+	txf, err := readTxFile(args[0])
+	if err != nil {
+		return err
+	}
+	wk, err := parseWalletKeyFile(txKeyFile)
+	if err != nil {
+		return err
+	}
+	defer wk.Lock()
+
+	if err := signTxFile(txf, wk.Address, wk.PrvKey(), true); err != nil {
+		return err
+	}
+	return writeTxFile(txOutput, txf)
+}
+
+// signTxFile signs the tx of `txf` with `prvKey` of `addr` as the sender or the payer.
+func signTxFile(txf *txFile, addr types.Address, prvKey []byte, isPayer bool) error {
+	chainID, err := parseChainID(txf.ChainID)
+	if err != nil {
+		return fmt.Errorf("invalid chain ID: %w", err)
+	}
+	tx := txf.Tx
+	signer := ctrlertypes.NewSignerV1(chainID)
+
+	if !isPayer {
+		if !bytes.Equal(tx.From, addr) {
+			return fmt.Errorf("the wallet key(%v) is not the sender(%v)", addr, tx.From)
+		}
+		tx.PayerSig = nil
+		_, err = signer.SignSender(tx, prvKey)
+		return err
+	}
+
+	if len(tx.Sig) == 0 {
+		return fmt.Errorf("the tx is not signed by the sender yet")
+	}
+	ctrlertypes.InitSigner(chainID)
+	if _, _, xerr := ctrlertypes.VerifyTrxRLP(tx); xerr != nil {
+		return fmt.Errorf("the sender's signature is invalid: %w", xerr)
+	}
+	if len(tx.Payer) == 0 {
+		tx.Payer = addr
+	} else if !bytes.Equal(tx.Payer, addr) {
+		return fmt.Errorf("the wallet key(%v) is not the payer(%v)", addr, tx.Payer)
+	}
+	_, err = signer.SignPayer(tx, prvKey)
+	return err
+}
+
+func newTxBroadcastCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast [tx file]",
+		Short: "Broadcast the signed tx file",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTxBroadcast,
+	}
+	cmd.Flags().StringVar(&txMode, "mode", "commit", "broadcast mode (async|sync|commit)")
+	return cmd
+}
+
+func runTxBroadcast(cmd *cobra.Command, args []string) error {
+	txf, err := readTxFile(args[0])
+	if err != nil {
+		return err
+	}
+	if len(txf.Tx.Sig) == 0 {
+		return fmt.Errorf("the tx is not signed by the sender")
+	}
+	if len(txf.Tx.Payer) > 0 && len(txf.Tx.PayerSig) == 0 {
+		return fmt.Errorf("the tx is not signed by the payer(%v)", txf.Tx.Payer)
+	}
+
+	bzweb3 := web3.NewBeatozWeb3(web3.NewHttpProvider(rpcUrl))
+	if bzweb3.ChainID() != txf.ChainID {
+		return fmt.Errorf("wrong chain ID: the tx is for %v, but the node is on %v", txf.ChainID, bzweb3.ChainID())
+	}
+	return broadcastTx(bzweb3, txf.Tx, txMode)
+}
+
+func broadcastTx(bzweb3 *web3.BeatozWeb3, tx *ctrlertypes.Trx, mode string) error {
+	switch mode {
+	case "async", "sync":
+		send := bzweb3.SendTransactionSync
+		if mode == "async" {
+			send = bzweb3.SendTransactionAsync
+		}
+		retBroadcast, err := send(tx)
+		if err != nil {
+			return err
+		}
+		if err := printJSON(retBroadcast); err != nil {
+			return err
+		}
+		if retBroadcast.Code != 0 {
+			return fmt.Errorf("check tx failed: %v", retBroadcast.Log)
+		}
+		return nil
+	case "commit":
+		retCommit, err := bzweb3.SendTransactionCommit(tx)
+		if err != nil {
+			return err
+		}
+		if err := printJSON(retCommit); err != nil {
+			return err
+		}
+		if retCommit.CheckTx.Code != 0 {
+			return fmt.Errorf("check tx failed: %v", retCommit.CheckTx.Log)
+		}
+		if retCommit.DeliverTx.Code != 0 {
+			return fmt.Errorf("deliver tx failed: %v", retCommit.DeliverTx.Log)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown broadcast mode: %v", mode)
+	}
+}
+
+func printJSON(v interface{}) error {
+	jz, err := tmjson.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jz))
+	return nil
+}
+
+func readTxFile(path string) (*txFile, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	txf := &txFile{}
+	if err := json.Unmarshal(bz, txf); err != nil {
+		return nil, fmt.Errorf("invalid tx file: %w", err)
+	}
+	if txf.Tx == nil {
+		return nil, fmt.Errorf("invalid tx file: no tx")
+	}
+	return txf, nil
+}
+
+func writeTxFile(path string, txf *txFile) error {
+	jz, err := json.MarshalIndent(txf, "", "  ")
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		fmt.Println(string(jz))
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, jz, 0o600)
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
+	acrypto "github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TxOfflineSign(t *testing.T) {
+	dir := t.TempDir()
+	secret := "tx-test-secret"
+	t.Setenv("BEATOZ_WALKEY_SECRET", secret)
+
+	wks, err := acrypto.CreateWalletKeyFiles([]byte(secret), 3, dir)
+	require.NoError(t, err)
+	sender, payer, other := wks[0], wks[1], wks[2]
+	senderKeyFile := acrypto.WalletKeyFilePath(dir, sender.Address)
+	payerKeyFile := acrypto.WalletKeyFilePath(dir, payer.Address)
+	otherKeyFile := acrypto.WalletKeyFilePath(dir, other.Address)
+
+	unsignedFile := filepath.Join(dir, "unsigned.json")
+	signedFile := filepath.Join(dir, "signed.json")
+	payerSignedFile := filepath.Join(dir, "payer_signed.json")
+	to := types.RandAddress()
+
+	// build without RPC access
+	require.NoError(t, runTxCmd(t, "build", "transfer",
+		"--chain_id", "0xabc", "--from", sender.Address.String(), "--nonce", "3", "--gas", "100000", "--gas_price", "10000000000",
+		"--to", to.String(), "--amount", "1000", "--output", unsignedFile))
+
+	txf, err := readTxFile(unsignedFile)
+	require.NoError(t, err)
+	require.Equal(t, "0xabc", txf.ChainID)
+	require.Equal(t, ctrlertypes.TRX_TRANSFER, txf.Tx.Type)
+	require.Equal(t, sender.Address, txf.Tx.From)
+	require.Equal(t, to, txf.Tx.To)
+	require.Equal(t, int64(3), txf.Tx.Nonce)
+	require.Equal(t, int64(100_000), txf.Tx.Gas)
+	require.Equal(t, uint256.NewInt(10_000_000_000), txf.Tx.GasPrice)
+	require.Equal(t, uint256.NewInt(1000), txf.Tx.Amount)
+	require.Empty(t, txf.Tx.Sig)
+
+	// the payer can not sign before the sender.
+	require.Error(t, runTxCmd(t, "sign-payer", unsignedFile, "--key", payerKeyFile, "--output", payerSignedFile))
+	// only the sender's key can sign.
+	require.Error(t, runTxCmd(t, "sign", unsignedFile, "--key", otherKeyFile, "--output", signedFile))
+
+	require.NoError(t, runTxCmd(t, "sign", unsignedFile, "--key", senderKeyFile, "--output", signedFile))
+	txf, err = readTxFile(signedFile)
+	require.NoError(t, err)
+	ctrlertypes.InitSigner(uint256.NewInt(0xabc))
+	addr, _, xerr := ctrlertypes.VerifyTrxRLP(txf.Tx)
+	require.NoError(t, xerr)
+	require.Equal(t, sender.Address, addr)
+
+	require.NoError(t, runTxCmd(t, "sign-payer", signedFile, "--key", payerKeyFile, "--output", payerSignedFile))
+	txf, err = readTxFile(payerSignedFile)
+	require.NoError(t, err)
+	require.Equal(t, payer.Address, txf.Tx.Payer)
+	addr, _, xerr = ctrlertypes.VerifyTrxRLP(txf.Tx)
+	require.NoError(t, xerr)
+	require.Equal(t, sender.Address, addr)
+	addr, _, xerr = ctrlertypes.VerifyPayerTrxRLP(txf.Tx)
+	require.NoError(t, xerr)
+	require.Equal(t, payer.Address, addr)
+
+	// the payer is fixed once it is set.
+	require.Error(t, runTxCmd(t, "sign-payer", payerSignedFile, "--key", otherKeyFile, "--output", payerSignedFile))
+
+	// the tx is valid after encoding.
+	bz, xerr := txf.Tx.Encode()
+	require.NoError(t, xerr)
+	decoded := &ctrlertypes.Trx{}
+	require.NoError(t, decoded.Decode(bz))
+	require.True(t, txf.Tx.Equal(decoded))
+}
+
+func runTxCmd(t *testing.T, args ...string) error {
+	cmd := NewTxCmd()
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/beatoz/beatoz-go/ctrlers/fxverify"
+	"github.com/beatoz/beatoz-go/libs/fxnum"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyCorpusFile string
+	verifyGoldenDir  string
+	verifyStrict     bool
+	verifyVerbose    bool
+)
+
+// NewVerifyMathCmd returns the command that checks the fxnum based computations of this binary
+// against the reference outputs of both arithmetic backends.
+func NewVerifyMathCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-math",
+		Short: "Verify the Wa/Wi/Sd computations of this binary against the reference outputs",
+		Long: `Run the Wa/Wi/Sd computations over the recorded corpus and compare the results
+bit for bit against the reference outputs recorded with both arithmetic backends
+('fixed' by default and 'decimal' with '-tags decimal').
+The command fails if the results differ from the reference outputs of the backend of this binary.
+The differences from the other backend mean that this binary cannot be in the same network
+with the nodes built with the other backend.`,
+		Args: cobra.NoArgs,
+		RunE: verifyMath,
+	}
+	cmd.Flags().StringVar(&verifyCorpusFile, "corpus", "", "corpus file (default: the corpus embedded in the binary)")
+	cmd.Flags().StringVar(&verifyGoldenDir, "golden", "", "directory of the reference output files (default: the files embedded in the binary)")
+	cmd.Flags().BoolVar(&verifyStrict, "strict", false, "fail also if the results differ from the reference outputs of the other backend")
+	cmd.Flags().BoolVar(&verifyVerbose, "verbose", false, "print every mismatched output")
+	return cmd
+}
+
+func verifyMath(cmd *cobra.Command, args []string) error {
+	corpus, err := fxverify.DefaultCorpus()
+	if verifyCorpusFile != "" {
+		var bz []byte
+		if bz, err = os.ReadFile(verifyCorpusFile); err == nil {
+			corpus, err = fxverify.DecodeCorpus(bz)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	actual, err := fxverify.Compute(corpus)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("backend: %s, outputs: %d\n", fxnum.Backend, len(actual.Outputs))
+
+	var failed []string
+	for _, backend := range fxverify.Backends {
+		expected, err := loadGolden(backend)
+		if err != nil {
+			return err
+		}
+		mismatches := fxverify.Verify(expected, actual)
+
+		status := "ok"
+		if len(mismatches) > 0 {
+			status = "MISMATCH"
+			if backend == fxnum.Backend || verifyStrict {
+				failed = append(failed, backend)
+			}
+		}
+		fmt.Printf("[%s] %s: %d/%d mismatched\n", status, backend, len(mismatches), len(actual.Outputs))
+		for i, m := range mismatches {
+			if !verifyVerbose && i >= 5 {
+				fmt.Printf("  ... (use --verbose to see all)\n")
+				break
+			}
+			fmt.Printf("  %s: expected %q, actual %q\n", m.Key, m.Expected, m.Actual)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("the results differ from the reference outputs of %v", failed)
+	}
+	return nil
+}
+
+func loadGolden(backend string) (*fxverify.Golden, error) {
+	if verifyGoldenDir == "" {
+		return fxverify.DefaultGolden(backend)
+	}
+	bz, err := os.ReadFile(filepath.Join(verifyGoldenDir, fxverify.GoldenFile(backend)))
+	if err != nil {
+		return nil, err
+	}
+	return fxverify.DecodeGolden(bz)
+}
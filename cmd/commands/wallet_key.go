@@ -11,10 +11,21 @@ import (
 	"github.com/beatoz/beatoz-go/types/bytes"
 	"github.com/beatoz/beatoz-go/types/crypto"
 	"github.com/spf13/cobra"
+	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 var (
 	changePass bool
+
+	walletKeyOutputDir string
+	withMnemonic       bool
+	mnemonicWords      int
+	hdIndex            uint32
+	hdCount            uint32
+	hdPath             string
+	keystoreOutput     string
+	keystoreLight      bool
+	walletKeyKDF       string
 )
 
 func AddWalletKeyCmdFlag(cmd *cobra.Command) {
@@ -37,10 +48,340 @@ func NewWalletKeyCmd() *cobra.Command {
 	}
 
 	AddWalletKeyCmdFlag(cmd)
+	cmd.AddCommand(newWalletKeyNewCmd(), newWalletKeyRecoverCmd(), newWalletKeyImportCmd(), newWalletKeyExportCmd(), newWalletKeyRekeyCmd())
+
+	return cmd
+}
+
+func addKDFCmdFlag(cmd *cobra.Command, defVal, usage string) {
+	cmd.Flags().StringVar(
+		&walletKeyKDF,
+		"kdf",
+		defVal,
+		usage+": pbkdf2 | scrypt | argon2id")
+}
+
+func addHDWalletKeyCmdFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&walletKeyOutputDir,
+		"output",
+		"",
+		"Directory where the wallet key files are created (default: {home}/walkeys)")
+	cmd.Flags().Uint32Var(
+		&hdIndex,
+		"index",
+		0,
+		fmt.Sprintf("Index of the account derived at the path '%s/{index}'", crypto.DefaultHDPathPrefix))
+	cmd.Flags().StringVar(
+		&hdPath,
+		"hd_path",
+		"",
+		"Full derivation path of the account (e.g. \"m/44'/60'/0'/0/0\"). If it is set, --index and --count are ignored")
+	addKDFCmdFlag(cmd, crypto.DefaultKDF, "Key derivation function encrypting the wallet key files")
+}
+
+func newWalletKeyNewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "new",
+		Short: "Create a new wallet key file",
+		Long: `Create a new wallet key file.
+With --mnemonic, the key is derived from a new BIP-39 mnemonic at the BIP-44 path of ethereum accounts,
+so the same accounts can be restored with 'wallet-key recover' or any compatible wallet (e.g. MetaMask).`,
+		Args: cobra.NoArgs,
+		RunE: handleWalletKeyNew,
+	}
+	addHDWalletKeyCmdFlag(cmd)
+	cmd.Flags().BoolVar(
+		&withMnemonic,
+		"mnemonic",
+		false,
+		"Derive the key from a new mnemonic")
+	cmd.Flags().IntVar(
+		&mnemonicWords,
+		"words",
+		crypto.DefaultMnemonicBits*3/32,
+		"Number of the mnemonic words (12, 15, 18, 21 or 24)")
+	return cmd
+}
 
+func newWalletKeyRecoverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover",
+		Short: "Recover wallet key files from a BIP-39 mnemonic",
+		Long: `Recover wallet key files from a BIP-39 mnemonic.
+The mnemonic is read from the terminal or the environment variable 'BEATOZ_MNEMONIC'.`,
+		Args: cobra.NoArgs,
+		RunE: handleWalletKeyRecover,
+	}
+	addHDWalletKeyCmdFlag(cmd)
+	cmd.Flags().Uint32Var(
+		&hdCount,
+		"count",
+		1,
+		"Number of the accounts recovered from --index")
 	return cmd
 }
 
+func newWalletKeyImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [keystore file]",
+		Short: "Import a keystore v3 file (e.g. of geth, MetaMask or Foundry) as a wallet key file",
+		Long: `Import a Web3 Secret Storage (keystore v3) file as a wallet key file.
+The passphrase of the keystore file is read from the terminal or the environment variable 'BEATOZ_KEYSTORE_SECRET'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: handleWalletKeyImport,
+	}
+	cmd.Flags().StringVar(
+		&walletKeyOutputDir,
+		"output",
+		"",
+		"Directory where the wallet key file is created (default: {home}/walkeys)")
+	addKDFCmdFlag(cmd, crypto.DefaultKDF, "Key derivation function encrypting the wallet key file")
+	return cmd
+}
+
+func newWalletKeyExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [wallet key file]",
+		Short: "Export a wallet key file as a keystore v3 file (e.g. for geth, MetaMask or Foundry)",
+		Long: `Export a wallet key file as a Web3 Secret Storage (keystore v3) file.
+The passphrase of the keystore file is read from the terminal or the environment variable 'BEATOZ_KEYSTORE_SECRET'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: handleWalletKeyExport,
+	}
+	cmd.Flags().StringVar(
+		&keystoreOutput,
+		"output",
+		"",
+		"Keystore file to be created (default: print to stdout)")
+	cmd.Flags().BoolVar(
+		&keystoreLight,
+		"light",
+		false,
+		"Use the lighter scrypt parameters which take less time and memory to decrypt")
+	return cmd
+}
+
+func newWalletKeyRekeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rekey [wallet key file]",
+		Short: "Encrypt a wallet key file again with a new key derivation function and passphrase",
+		Long: `Encrypt a wallet key file again with a new key derivation function (e.g. the memory-hard scrypt or argon2id)
+and a new passphrase. The private key is not exposed and the address is not changed.
+The validator key file ('priv_validator_key.json') can also be migrated.`,
+		Args: cobra.ExactArgs(1),
+		RunE: handleWalletKeyRekey,
+	}
+	addKDFCmdFlag(cmd, "", "Key derivation function (default: the current function of the file)")
+	return cmd
+}
+
+func handleWalletKeyImport(cmd *cobra.Command, args []string) error {
+	if err := crypto.ValidateKDF(walletKeyKDF); err != nil {
+		return err
+	}
+	keyJSON, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	ksPass := libs.ReadKeystoreCredential(fmt.Sprintf("Passphrase for %v: ", filepath.Base(args[0])))
+	defer libs.ClearCredential(ksPass)
+
+	// the keystore is decrypted without the passphrase of the wallet key file
+	// to check `ksPass` before reading the passphrase of the wallet key file.
+	wk, err := crypto.ImportKeystore(keyJSON, ksPass, nil)
+	if err != nil {
+		return err
+	}
+	defer wk.Lock()
+
+	s := libs.ReadCredential("Passphrase for the wallet key file: ")
+	defer libs.ClearCredential(s)
+
+	newWk, err := crypto.NewWalletKeyWithKDF(wk.PrvKey(), s, walletKeyKDF)
+	if err != nil {
+		return err
+	}
+	return saveWalletKeyFile(newWk)
+}
+
+func handleWalletKeyRekey(cmd *cobra.Command, args []string) error {
+	if walletKeyKDF != "" {
+		if err := crypto.ValidateKDF(walletKeyKDF); err != nil {
+			return err
+		}
+	}
+
+	path := args[0]
+	wk, err := crypto.OpenWalletKey(libs.NewFileReader(path))
+	if err != nil {
+		return err
+	}
+
+	pass0 := libs.ReadCredential(fmt.Sprintf("Current Passphrase for %v: ", filepath.Base(path)))
+	defer libs.ClearCredential(pass0)
+	if err := wk.Unlock(pass0); err != nil {
+		return err
+	}
+	defer wk.Lock()
+
+	pass1 := libs.ReadCredential(fmt.Sprintf("New Passphrase for %v: ", filepath.Base(path)))
+	defer libs.ClearCredential(pass1)
+
+	if walletKeyKDF == "" {
+		wk.LockWith(pass1)
+	} else if err := wk.LockWithKDF(pass1, walletKeyKDF); err != nil {
+		return err
+	}
+
+	if _, err := wk.Save(libs.NewFileWriter(path)); err != nil {
+		return err
+	}
+	fmt.Println("wallet file :", path)
+	fmt.Println("address     :", wk.Address.String())
+	fmt.Println("kdf         :", wk.DKParams.Algo)
+	return nil
+}
+
+func handleWalletKeyExport(cmd *cobra.Command, args []string) error {
+	wk, err := parseWalletKeyFile(args[0])
+	if err != nil {
+		return err
+	}
+	defer wk.Lock()
+
+	ksPass := libs.ReadKeystoreCredential("Passphrase for the keystore file: ")
+	defer libs.ClearCredential(ksPass)
+
+	keyJSON, err := wk.ExportKeystore(ksPass, keystoreLight)
+	if err != nil {
+		return err
+	}
+	if keystoreOutput == "" {
+		fmt.Println(string(keyJSON))
+		return nil
+	}
+	if _, err := os.Stat(keystoreOutput); err == nil {
+		return fmt.Errorf("the keystore file already exists: %s", keystoreOutput)
+	}
+	if err := os.WriteFile(keystoreOutput, keyJSON, libs.DefaultSFilePerm); err != nil {
+		return err
+	}
+	fmt.Println("keystore file :", keystoreOutput)
+	fmt.Println("address       :", wk.Address.String())
+	return nil
+}
+
+func handleWalletKeyNew(cmd *cobra.Command, args []string) error {
+	if err := crypto.ValidateKDF(walletKeyKDF); err != nil {
+		return err
+	}
+	if !withMnemonic {
+		s := libs.ReadCredential("Passphrase for the new wallet key: ")
+		defer libs.ClearCredential(s)
+
+		wk, err := crypto.NewWalletKeyWithKDF(tmsecp256k1.GenPrivKey(), s, walletKeyKDF)
+		if err != nil {
+			return err
+		}
+		return saveWalletKeyFile(wk)
+	}
+
+	if mnemonicWords%3 != 0 {
+		return fmt.Errorf("wrong number of the mnemonic words: %d", mnemonicWords)
+	}
+	mnemonic, err := crypto.NewMnemonic(mnemonicWords * 32 / 3)
+	if err != nil {
+		return err
+	}
+	if err := recoverWalletKeyFiles(mnemonic); err != nil {
+		return err
+	}
+
+	fmt.Println("")
+	fmt.Println("**Important** write this mnemonic down in a safe place.")
+	fmt.Println("It is the only way to recover your accounts if you lose the wallet key files or forget the passphrase.")
+	fmt.Println("")
+	fmt.Println(mnemonic)
+	return nil
+}
+
+func handleWalletKeyRecover(cmd *cobra.Command, args []string) error {
+	mnemonic := libs.ReadMnemonic("Mnemonic: ")
+	defer libs.ClearCredential(mnemonic)
+
+	return recoverWalletKeyFiles(string(mnemonic))
+}
+
+func recoverWalletKeyFiles(mnemonic string) error {
+	paths := []string{hdPath}
+	if hdPath == "" {
+		paths = nil
+		for i := uint32(0); i < max(hdCount, 1); i++ {
+			paths = append(paths, crypto.HDPath(hdIndex+i))
+		}
+	}
+
+	// check the mnemonic, the paths and the kdf before reading the passphrase.
+	if err := crypto.ValidateKDF(walletKeyKDF); err != nil {
+		return err
+	}
+	seed, err := crypto.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return err
+	}
+	defer libs.ClearCredential(seed)
+	for _, path := range paths {
+		if _, err := crypto.ParseHDPath(path); err != nil {
+			return err
+		}
+	}
+
+	s := libs.ReadCredential("Passphrase for the wallet key files: ")
+	defer libs.ClearCredential(s)
+
+	for _, path := range paths {
+		prvKey, err := crypto.DeriveHDPrvKey(seed, path)
+		if err != nil {
+			return err
+		}
+		wk, err := crypto.NewWalletKeyWithKDF(prvKey, s, walletKeyKDF)
+		libs.ClearCredential(prvKey)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("path        :", path)
+		if err := saveWalletKeyFile(wk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func saveWalletKeyFile(wk *crypto.WalletKey) error {
+	dir := walletKeyOutputDir
+	if dir == "" {
+		dir = filepath.Join(rootConfig.RootDir, crypto.DefaultWalletKeyDir)
+	}
+	if err := os.MkdirAll(dir, crypto.DefaultWalletKeyDirPerm); err != nil {
+		return err
+	}
+
+	path := crypto.WalletKeyFilePath(dir, wk.Address)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("the wallet key file already exists: %s", path)
+	}
+	if _, err := wk.Save(libs.NewFileWriter(path)); err != nil {
+		return err
+	}
+
+	fmt.Println("wallet file :", path)
+	fmt.Println("address     :", wk.Address.String())
+	return nil
+}
+
 func handleWalletKey(cmd *cobra.Command, args []string) error {
 	for _, arg := range args {
 		if strings.HasPrefix(arg, "~") {
@@ -0,0 +1,63 @@
+package web3
+
+import (
+	"fmt"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/holiman/uint256"
+)
+
+// TrxTypedData returns the EIP-712 typed data of `tx` to be signed by the sender.
+// It can be passed to the wallets as the parameter of `eth_signTypedData_v4`.
+func TrxTypedData(tx *ctrlertypes.Trx, chainId *uint256.Int) (*apitypes.TypedData, error) {
+	return ctrlertypes.NewSignerEIP712(chainId).TypedDataSender(tx)
+}
+
+// PayerTrxTypedData returns the EIP-712 typed data of `tx` to be signed by the payer.
+// The sender's signature should be set to `tx` before it is called.
+func PayerTrxTypedData(tx *ctrlertypes.Trx, chainId *uint256.Int) (*apitypes.TypedData, error) {
+	return ctrlertypes.NewSignerEIP712(chainId).TypedDataPayer(tx)
+}
+
+// SetTrxEIP712Sig sets the sender's signature of the typed data returned by a wallet to `tx`.
+func SetTrxEIP712Sig(tx *ctrlertypes.Trx, sig bytes.HexBytes) error {
+	_sig, err := toEIP712Sig(sig)
+	if err != nil {
+		return err
+	}
+	tx.Sig = _sig
+	return nil
+}
+
+// SetPayerTrxEIP712Sig sets the payer's signature of the typed data returned by a wallet to `tx`.
+func SetPayerTrxEIP712Sig(tx *ctrlertypes.Trx, sig bytes.HexBytes) error {
+	_sig, err := toEIP712Sig(sig)
+	if err != nil {
+		return err
+	}
+	tx.PayerSig = _sig
+	return nil
+}
+
+// toEIP712Sig moves `v` of the wallet's signature (0/1 or 27/28) to 29/30.
+func toEIP712Sig(sig bytes.HexBytes) (bytes.HexBytes, error) {
+	if len(sig) != ethcrypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length - expected: %d, actual: %d", ethcrypto.SignatureLength, len(sig))
+	}
+	ret := make(bytes.HexBytes, len(sig))
+	copy(ret, sig)
+
+	switch v := ret[64]; v {
+	case 0, 1:
+		ret[64] = v + ctrlertypes.EIP712SigVOffset
+	case 27, 28:
+		ret[64] = v - 27 + ctrlertypes.EIP712SigVOffset
+	case ctrlertypes.EIP712SigVOffset, ctrlertypes.EIP712SigVOffset + 1:
+	default:
+		return nil, fmt.Errorf("invalid signature v - %v", v)
+	}
+	return ret, nil
+}
@@ -37,3 +37,62 @@ type RespQueryReward struct {
 	Cumulated string        `json:"cumulated,omitempty"`
 	Height    int64         `json:"height,omitempty"`
 }
+
+type RespDelegateeStatus struct {
+	Addr           btztypes.Address  `json:"address"`
+	PubKey         btzbytes.HexBytes `json:"pubKey"`
+	SelfPower      int64             `json:"selfPower,string"`
+	TotalPower     int64             `json:"totalPower,string"`
+	DelegatorCount int               `json:"delegatorCount,string"`
+	Status         string            `json:"status"`
+}
+
+type RespQueryDelegatees struct {
+	Total   int                    `json:"total,string"`
+	Page    int                    `json:"page,string"`
+	PerPage int                    `json:"perPage,string"`
+	Items   []*RespDelegateeStatus `json:"items"`
+}
+
+type RespDelegator struct {
+	Addr       btztypes.Address `json:"address"`
+	Power      int64            `json:"power,string"`
+	ChunkCount int              `json:"chunkCount,string"`
+}
+
+type RespQueryDelegators struct {
+	Total   int              `json:"total,string"`
+	Page    int              `json:"page,string"`
+	PerPage int              `json:"perPage,string"`
+	Items   []*RespDelegator `json:"items"`
+}
+
+type RespFrozenPower struct {
+	From         btztypes.Address `json:"from"`
+	RefundHeight int64            `json:"refundHeight,string"`
+	RefundPower  int64            `json:"refundPower,string"`
+	ChunkCount   int              `json:"chunkCount,string"`
+}
+
+type RespQueryFrozenPowers struct {
+	Total   int                `json:"total,string"`
+	Page    int                `json:"page,string"`
+	PerPage int                `json:"perPage,string"`
+	Items   []*RespFrozenPower `json:"items"`
+}
+
+type RespReward struct {
+	Address   btztypes.Address `json:"address"`
+	Issued    string           `json:"issued"`
+	Withdrawn string           `json:"withdrawn"`
+	Slashed   string           `json:"slashed"`
+	Cumulated string           `json:"cumulated"`
+	Height    int64            `json:"height,string"`
+}
+
+type RespQueryRewards struct {
+	Total   int           `json:"total,string"`
+	Page    int           `json:"page,string"`
+	PerPage int           `json:"perPage,string"`
+	Items   []*RespReward `json:"items"`
+}
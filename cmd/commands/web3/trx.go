@@ -83,6 +83,32 @@ func NewTrxVoting(from, to types.Address, nonce, gas int64, gasPrice *uint256.In
 		})
 }
 
+func NewTrxDeposit(from types.Address, nonce, gas int64, gasPrice, amt *uint256.Int, txHash bytes.HexBytes) *ctrlertypes.Trx {
+	return ctrlertypes.NewTrx(
+		1,
+		from, types.ZeroAddress(),
+		nonce,
+		gas,
+		gasPrice,
+		amt,
+		&ctrlertypes.TrxPayloadDeposit{
+			TxHash: txHash,
+		})
+}
+
+func NewTrxCancelProposal(from types.Address, nonce, gas int64, gasPrice *uint256.Int, txHash bytes.HexBytes) *ctrlertypes.Trx {
+	return ctrlertypes.NewTrx(
+		1,
+		from, types.ZeroAddress(),
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&ctrlertypes.TrxPayloadCancelProposal{
+			TxHash: txHash,
+		})
+}
+
 func NewTrxContract(from, to types.Address, nonce, gas int64, gasPrice, amt *uint256.Int, data bytes.HexBytes) *ctrlertypes.Trx {
 	return ctrlertypes.NewTrx(
 		1,
@@ -107,3 +133,70 @@ func NewTrxSetDoc(from types.Address, nonce, gas int64, gasPrice *uint256.Int, n
 		&ctrlertypes.TrxPayloadSetDoc{name, docUrl},
 	)
 }
+
+func NewTrxSetPubKey(from types.Address, nonce, gas int64, gasPrice *uint256.Int, pubKey bytes.HexBytes) *ctrlertypes.Trx {
+	return ctrlertypes.NewTrx(
+		1,
+		from, types.ZeroAddress(),
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&ctrlertypes.TrxPayloadSetPubKey{PubKey: pubKey},
+	)
+}
+
+func NewTrxTokenIssue(from types.Address, nonce, gas int64, gasPrice *uint256.Int, name, symbol string, decimals int32, maxSupply, initialSupply *uint256.Int, mintAuthority types.Address) *ctrlertypes.Trx {
+	return ctrlertypes.NewTrx(
+		1,
+		from, types.ZeroAddress(),
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&ctrlertypes.TrxPayloadTokenIssue{
+			Name:          name,
+			Symbol:        symbol,
+			Decimals:      decimals,
+			MaxSupply:     maxSupply,
+			InitialSupply: initialSupply,
+			MintAuthority: mintAuthority,
+		},
+	)
+}
+
+func NewTrxTokenMint(from, to types.Address, nonce, gas int64, gasPrice *uint256.Int, token types.Address, amt *uint256.Int) *ctrlertypes.Trx {
+	return ctrlertypes.NewTrx(
+		1,
+		from, to,
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&ctrlertypes.TrxPayloadTokenMint{TokenAmount: ctrlertypes.TokenAmount{Token: token, Amount: amt}},
+	)
+}
+
+func NewTrxTokenBurn(from types.Address, nonce, gas int64, gasPrice *uint256.Int, token types.Address, amt *uint256.Int) *ctrlertypes.Trx {
+	return ctrlertypes.NewTrx(
+		1,
+		from, types.ZeroAddress(),
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&ctrlertypes.TrxPayloadTokenBurn{TokenAmount: ctrlertypes.TokenAmount{Token: token, Amount: amt}},
+	)
+}
+
+func NewTrxTokenTransfer(from, to types.Address, nonce, gas int64, gasPrice *uint256.Int, token types.Address, amt *uint256.Int) *ctrlertypes.Trx {
+	return ctrlertypes.NewTrx(
+		1,
+		from, to,
+		nonce,
+		gas,
+		gasPrice,
+		uint256.NewInt(0),
+		&ctrlertypes.TrxPayloadTokenTransfer{TokenAmount: ctrlertypes.TokenAmount{Token: token, Amount: amt}},
+	)
+}
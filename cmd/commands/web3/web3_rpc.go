@@ -237,6 +237,162 @@ func (bzweb3 *BeatozWeb3) QueryProposal(txhash []byte, height int64) (*QueryProp
 	}
 }
 
+func (bzweb3 *BeatozWeb3) QueryModuleHashes(height int64) (*ctrlertypes.ModuleHashes, error) {
+	ret := &ctrlertypes.ModuleHashes{}
+	queryResp := &rpc.QueryResult{}
+	if req, err := bzweb3.NewRequest("module_hashes", strconv.FormatInt(height, 10)); err != nil {
+		panic(err)
+	} else if resp, err := bzweb3.provider.Call(req); err != nil {
+		return nil, err
+	} else if resp.Error != nil {
+		return nil, errors.New("provider error: " + string(resp.Error))
+	} else if err := tmjson.Unmarshal(resp.Result, queryResp); err != nil {
+		return nil, err
+	} else if queryResp.Code != 0 {
+		return nil, errors.New(queryResp.Log)
+	} else if err := tmjson.Unmarshal(queryResp.Value, ret); err != nil {
+		return nil, err
+	} else {
+		return ret, nil
+	}
+}
+
+func (bzweb3 *BeatozWeb3) QueryInvariants(height int64) ([]*ctrlertypes.InvariantResult, error) {
+	var ret []*ctrlertypes.InvariantResult
+	queryResp := &rpc.QueryResult{}
+	if req, err := bzweb3.NewRequest("invariants", strconv.FormatInt(height, 10)); err != nil {
+		panic(err)
+	} else if resp, err := bzweb3.provider.Call(req); err != nil {
+		return nil, err
+	} else if resp.Error != nil {
+		return nil, errors.New("provider error: " + string(resp.Error))
+	} else if err := tmjson.Unmarshal(resp.Result, queryResp); err != nil {
+		return nil, err
+	} else if queryResp.Code != 0 {
+		return nil, errors.New(queryResp.Log)
+	} else if err := tmjson.Unmarshal(queryResp.Value, &ret); err != nil {
+		return nil, err
+	} else {
+		return ret, nil
+	}
+}
+
+type QueryProposalsResult struct {
+	Total   int                    `json:"total,string"`
+	Page    int                    `json:"page,string"`
+	PerPage int                    `json:"perPage,string"`
+	Items   []*QueryProposalResult `json:"items"`
+}
+
+// QueryProposals returns the proposals in the page.
+// `status` is one of `voting` and `frozen`. If it is empty, all proposals are returned.
+func (bzweb3 *BeatozWeb3) QueryProposals(status string, height int64, page, perPage int) (*QueryProposalsResult, error) {
+	ret := &QueryProposalsResult{}
+	if err := bzweb3.queryList("proposals", ret,
+		status, strconv.FormatInt(height, 10), strconv.Itoa(page), strconv.Itoa(perPage)); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (bzweb3 *BeatozWeb3) QueryDelegatees(height int64, page, perPage int) (*RespQueryDelegatees, error) {
+	ret := &RespQueryDelegatees{}
+	if err := bzweb3.queryList("delegatees", ret,
+		strconv.FormatInt(height, 10), strconv.Itoa(page), strconv.Itoa(perPage)); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (bzweb3 *BeatozWeb3) QueryDelegators(addr btztypes.Address, height int64, page, perPage int) (*RespQueryDelegators, error) {
+	ret := &RespQueryDelegators{}
+	if err := bzweb3.queryList("delegators", ret,
+		addr.String(), strconv.FormatInt(height, 10), strconv.Itoa(page), strconv.Itoa(perPage)); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// QueryFrozenPowers returns the frozen powers in the page.
+// If `addr` is not nil, only the powers to be refunded to `addr` are returned.
+func (bzweb3 *BeatozWeb3) QueryFrozenPowers(addr btztypes.Address, height int64, page, perPage int) (*RespQueryFrozenPowers, error) {
+	ret := &RespQueryFrozenPowers{}
+	if err := bzweb3.queryList("frozen_powers", ret,
+		addr.String(), strconv.FormatInt(height, 10), strconv.Itoa(page), strconv.Itoa(perPage)); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (bzweb3 *BeatozWeb3) QueryRewards(height int64, page, perPage int) (*RespQueryRewards, error) {
+	ret := &RespQueryRewards{}
+	if err := bzweb3.queryList("rewards", ret,
+		strconv.FormatInt(height, 10), strconv.Itoa(page), strconv.Itoa(perPage)); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (bzweb3 *BeatozWeb3) queryList(method string, ret interface{}, args ...interface{}) error {
+	queryResp := &rpc.QueryResult{}
+	if req, err := bzweb3.NewRequest(method, args...); err != nil {
+		panic(err)
+	} else if resp, err := bzweb3.provider.Call(req); err != nil {
+		return err
+	} else if resp.Error != nil {
+		return errors.New("provider error: " + string(resp.Error))
+	} else if err := tmjson.Unmarshal(resp.Result, queryResp); err != nil {
+		return err
+	} else if queryResp.Code != 0 {
+		return errors.New(queryResp.Log)
+	} else if err := tmjson.Unmarshal(queryResp.Value, ret); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (bzweb3 *BeatozWeb3) QueryAccountHistory(filter *ctrlertypes.HistoryFilter) (*ctrlertypes.HistoryResult, error) {
+	return bzweb3.queryHistory("account_history", filter)
+}
+
+func (bzweb3 *BeatozWeb3) QueryStakingHistory(filter *ctrlertypes.HistoryFilter) (*ctrlertypes.HistoryResult, error) {
+	return bzweb3.queryHistory("staking_history", filter)
+}
+
+func (bzweb3 *BeatozWeb3) queryHistory(method string, filter *ctrlertypes.HistoryFilter) (*ctrlertypes.HistoryResult, error) {
+	orderBy := "asc"
+	if filter.Desc {
+		orderBy = "desc"
+	}
+
+	ret := &ctrlertypes.HistoryResult{}
+	queryResp := &rpc.QueryResult{}
+	if req, err := bzweb3.NewRequest(method,
+		filter.Address.String(),
+		strings.Join(filter.Types, ","),
+		strconv.FormatInt(filter.FromHeight, 10),
+		strconv.FormatInt(filter.ToHeight, 10),
+		filter.SortBy,
+		orderBy,
+		strconv.Itoa(filter.Page),
+		strconv.Itoa(filter.PerPage),
+	); err != nil {
+		panic(err)
+	} else if resp, err := bzweb3.provider.Call(req); err != nil {
+		return nil, err
+	} else if resp.Error != nil {
+		return nil, errors.New("provider error: " + string(resp.Error))
+	} else if err := tmjson.Unmarshal(resp.Result, queryResp); err != nil {
+		return nil, err
+	} else if queryResp.Code != 0 {
+		return nil, errors.New(queryResp.Log)
+	} else if err := tmjson.Unmarshal(queryResp.Value, ret); err != nil {
+		return nil, err
+	} else {
+		return ret, nil
+	}
+}
+
 func (bzweb3 *BeatozWeb3) SendTransactionAsync(tx *ctrlertypes.Trx) (*coretypes.ResultBroadcastTx, error) {
 	resp, err := bzweb3.sendTransaction(tx, "broadcast_tx_async")
 	if err != nil {
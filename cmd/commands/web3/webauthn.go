@@ -0,0 +1,47 @@
+package web3
+
+import (
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/holiman/uint256"
+)
+
+// TrxWebAuthnChallenge returns the challenge of `tx` to be signed by the sender's passkey.
+// It can be passed to the authenticator as `publicKey.challenge` of `navigator.credentials.get()`.
+func TrxWebAuthnChallenge(tx *ctrlertypes.Trx, chainId *uint256.Int) (bytes.HexBytes, error) {
+	return ctrlertypes.NewSignerWebAuthn(chainId).ChallengeSender(tx)
+}
+
+// PayerTrxWebAuthnChallenge returns the challenge of `tx` to be signed by the payer's passkey.
+// The sender's signature should be set to `tx` before it is called.
+func PayerTrxWebAuthnChallenge(tx *ctrlertypes.Trx, chainId *uint256.Int) (bytes.HexBytes, error) {
+	return ctrlertypes.NewSignerWebAuthn(chainId).ChallengePayer(tx)
+}
+
+// SetTrxWebAuthnSig sets the sender's WebAuthn assertion returned by the authenticator to `tx`.
+func SetTrxWebAuthnSig(tx *ctrlertypes.Trx, authData, clientDataJSON, sig bytes.HexBytes) error {
+	bz, xerr := (&ctrlertypes.WebAuthnSig{
+		AuthenticatorData: authData,
+		ClientDataJSON:    clientDataJSON,
+		Signature:         sig,
+	}).Encode()
+	if xerr != nil {
+		return xerr
+	}
+	tx.Sig = bz
+	return nil
+}
+
+// SetPayerTrxWebAuthnSig sets the payer's WebAuthn assertion returned by the authenticator to `tx`.
+func SetPayerTrxWebAuthnSig(tx *ctrlertypes.Trx, authData, clientDataJSON, sig bytes.HexBytes) error {
+	bz, xerr := (&ctrlertypes.WebAuthnSig{
+		AuthenticatorData: authData,
+		ClientDataJSON:    clientDataJSON,
+		Signature:         sig,
+	}).Encode()
+	if xerr != nil {
+		return xerr
+	}
+	tx.PayerSig = bz
+	return nil
+}
@@ -10,6 +10,13 @@ import (
 type Config struct {
 	*tmcfg.Config
 	chainId *uint256.Int
+
+	// InvCheckPeriod is the block interval at which the invariants are checked.
+	// 0 means that the invariants are never checked at EndBlock.
+	InvCheckPeriod int64
+	// InvCheckHalt makes the node halt when any invariant is broken.
+	// If it is false, only an event for the broken invariant is emitted.
+	InvCheckHalt bool
 }
 
 func DefaultConfig(chainId ...string) *Config {
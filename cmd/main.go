@@ -14,9 +14,16 @@ func main() {
 		commands.ResetPrivValidatorCmd,
 		commands.ResetAllCmd,
 		commands.NewRunNodeCmd(node.NewBeatozNode),
+		commands.NewSignerCmd(),
 		commands.ShowNodeIDCmd,
 		commands.NewWalletKeyCmd(),
 		commands.NewValidatorCmd(),
+		commands.NewTxCmd(),
+		commands.NewQueryCmd(),
+		commands.NewStateDiffCmd(),
+		commands.NewInvariantsCmd(),
+		commands.NewTestnetCmd(),
+		commands.NewVerifyMathCmd(),
 		commands.VersionCmd,
 	)
 
@@ -3,6 +3,7 @@ package account_test
 import (
 	"github.com/beatoz/beatoz-go/ctrlers/account"
 	account2 "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
 	"github.com/beatoz/beatoz-go/types/crypto"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -22,6 +23,14 @@ func TestAccountEncoding(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t, acct0, assetAcct1)
+
+	acct0.SetPubKeyP256(bytes.RandBytes(33))
+	encoded, err = account.EncodeAccount(acct0)
+	require.NoError(t, err)
+
+	assetAcct1, err = account.DecodeAccount(encoded)
+	require.NoError(t, err)
+	require.Equal(t, acct0.PubKeyP256, assetAcct1.PubKeyP256)
 }
 
 func BenchmarkAccountEncoding(b *testing.B) {
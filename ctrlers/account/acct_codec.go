@@ -25,6 +25,8 @@ func ToProto(acct *types2.Account) *types2.AcctProto {
 		Nonce:    acct.GetNonce(),
 		XBalance: acct.GetBalance().Bytes(),
 		XCode:    acct.GetCode(),
+
+		P256PubKey: acct.GetPubKeyP256(),
 	}
 }
 
@@ -35,5 +37,7 @@ func FromProto(pm *types2.AcctProto) *types2.Account {
 		Nonce:   pm.Nonce,
 		Balance: new(uint256.Int).SetBytes(pm.XBalance),
 		Code:    pm.XCode,
+
+		PubKeyP256: pm.P256PubKey,
 	}
 }
@@ -27,7 +27,7 @@ type AcctCtrler struct {
 func NewAcctCtrler(config *cfg.Config, logger tmlog.Logger) (*AcctCtrler, error) {
 	lg := logger.With("module", "beatoz_AcctCtrler")
 
-	if _state, xerr := v1.NewStateLedger("accounts", config.DBDir(), 10000, func(key v1.LedgerKey) v1.ILedgerItem { return &btztypes.Account{} }, lg); xerr != nil {
+	if _state, xerr := v1.NewStateLedger("accounts", config.DBDir(), 10000, newAcctLedgerItem, lg); xerr != nil {
 		return nil, xerr
 	} else {
 		return &AcctCtrler{
@@ -72,6 +72,17 @@ func (ctrler *AcctCtrler) ValidateTrx(ctx *btztypes.TrxContext) xerrors.XError {
 		if len(url) > btztypes.MAX_ACCT_DOCURL {
 			return xerrors.ErrInvalidTrxPayloadParams.Wrapf("too long url. it should be less than %d.", btztypes.MAX_ACCT_DOCURL)
 		}
+	case btztypes.TRX_SETPUBKEY:
+		pubKey := ctx.Tx.Payload.(*btztypes.TrxPayloadSetPubKey).PubKey
+		if len(pubKey) > 0 {
+			if _, xerr := btztypes.ParseP256PubKey(pubKey); xerr != nil {
+				return xerrors.ErrInvalidTrxPayloadParams.Wrap(xerr)
+			}
+		}
+	case btztypes.TRX_TOKEN_ISSUE, btztypes.TRX_TOKEN_MINT, btztypes.TRX_TOKEN_BURN, btztypes.TRX_TOKEN_TRANSFER:
+		if xerr := ctrler.validateTokenTrx(ctx); xerr != nil {
+			return xerr
+		}
 	}
 
 	return nil
@@ -90,6 +101,12 @@ func (ctrler *AcctCtrler) ExecuteTrx(ctx *btztypes.TrxContext) xerrors.XError {
 		ctrler.setDoc(ctx.Sender,
 			ctx.Tx.Payload.(*btztypes.TrxPayloadSetDoc).Name,
 			ctx.Tx.Payload.(*btztypes.TrxPayloadSetDoc).URL)
+	case btztypes.TRX_SETPUBKEY:
+		ctx.Sender.SetPubKeyP256(ctx.Tx.Payload.(*btztypes.TrxPayloadSetPubKey).PubKey)
+	case btztypes.TRX_TOKEN_ISSUE, btztypes.TRX_TOKEN_MINT, btztypes.TRX_TOKEN_BURN, btztypes.TRX_TOKEN_TRANSFER:
+		if xerr := ctrler.executeTokenTrx(ctx); xerr != nil {
+			return xerr
+		}
 	}
 
 	_ = ctrler.setAccount(ctx.Sender, ctx.Exec)
@@ -2,6 +2,7 @@ package account
 
 import (
 	btztypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
 	"github.com/beatoz/beatoz-go/types/xerrors"
 	"github.com/holiman/uint256"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
@@ -29,10 +30,25 @@ func (ctrler *AcctCtrler) EndBlock(bctx *btztypes.BlockContext) ([]abcitypes.Eve
 			return nil, xerr
 		}
 
+		//
+		// Transfer the GovParams.TreasuryFeeRate % of txs fee to the treasury.
+		// It can not exceed the remaining fee after rewarding.
+		remainAmt := new(uint256.Int).Sub(sumFee, rwdAmt)
+		trsyAmt := new(uint256.Int).Mul(sumFee, uint256.NewInt(uint64(bctx.GovHandler.TreasuryFeeRate())))
+		trsyAmt = new(uint256.Int).Div(trsyAmt, uint256.NewInt(100))
+		if trsyAmt.Gt(remainAmt) {
+			trsyAmt = remainAmt
+		}
+		if trsyAmt.Sign() > 0 {
+			if xerr := bctx.AcctHandler.AddBalance(types.TreasuryAddress(), trsyAmt, true); xerr != nil {
+				return nil, xerr
+			}
+		}
+
 		//
 		// Fee Draining: transfer the remaining fee to DEAD Address
 		// this is not burning. it is just to transfer to the zero address.
-		deadAmt := new(uint256.Int).Sub(sumFee, rwdAmt)
+		deadAmt := new(uint256.Int).Sub(remainAmt, trsyAmt)
 		if xerr := bctx.AcctHandler.AddBalance(bctx.GovHandler.DeadAddress(), deadAmt, true); xerr != nil {
 			return nil, xerr
 		}
@@ -41,9 +57,10 @@ func (ctrler *AcctCtrler) EndBlock(bctx *btztypes.BlockContext) ([]abcitypes.Eve
 			Attributes: []abcitypes.EventAttribute{
 				{Key: []byte("dead"), Value: []byte(deadAmt.Dec()), Index: false},
 				{Key: []byte("reward"), Value: []byte(rwdAmt.Dec()), Index: false},
+				{Key: []byte("treasury"), Value: []byte(trsyAmt.Dec()), Index: false},
 			},
 		})
-		ctrler.logger.Debug("txs's fee is processed", "total.fee", sumFee.Dec(), "reward", rwdAmt.Dec(), "dead", deadAmt.Dec())
+		ctrler.logger.Debug("txs's fee is processed", "total.fee", sumFee.Dec(), "reward", rwdAmt.Dec(), "treasury", trsyAmt.Dec(), "dead", deadAmt.Dec())
 	}
 	return evts, nil
 }
@@ -0,0 +1,261 @@
+package account
+
+import (
+	btztypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// newAcctLedgerItem returns the item for `key` of the account ledger,
+// which has the native token and its balances as well as the account.
+func newAcctLedgerItem(key v1.LedgerKey) v1.ILedgerItem {
+	switch key[0] {
+	case v1.KeyPrefixToken[0]:
+		return &btztypes.Token{}
+	case v1.KeyPrefixTokenBalance[0]:
+		return &btztypes.TokenBalance{}
+	default:
+		return &btztypes.Account{}
+	}
+}
+
+func (ctrler *AcctCtrler) validateTokenTrx(ctx *btztypes.TrxContext) xerrors.XError {
+	if ctx.Tx.Amount.Sign() != 0 {
+		return xerrors.ErrInvalidAmount.Wrapf("the amount of %s tx should be 0", ctx.Tx.TypeString())
+	}
+
+	if ctx.Tx.GetType() == btztypes.TRX_TOKEN_ISSUE {
+		txpayload, ok := ctx.Tx.Payload.(*btztypes.TrxPayloadTokenIssue)
+		if !ok {
+			return xerrors.ErrInvalidTrxPayloadType
+		}
+		return ctrler.validateTokenIssue(ctx, txpayload)
+	}
+
+	var ta *btztypes.TokenAmount
+	switch txpayload := ctx.Tx.Payload.(type) {
+	case *btztypes.TrxPayloadTokenMint:
+		ta = &txpayload.TokenAmount
+	case *btztypes.TrxPayloadTokenBurn:
+		ta = &txpayload.TokenAmount
+	case *btztypes.TrxPayloadTokenTransfer:
+		ta = &txpayload.TokenAmount
+	default:
+		return xerrors.ErrInvalidTrxPayloadType
+	}
+	if ta.Amount == nil || ta.Amount.Sign() <= 0 {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("the token amount should be greater than 0")
+	}
+
+	token := ctrler.FindToken(ta.Token, ctx.Exec)
+	if token == nil {
+		return xerrors.ErrNotFoundToken.Wrapf("token: %v", ta.Token)
+	}
+
+	switch ctx.Tx.GetType() {
+	case btztypes.TRX_TOKEN_MINT:
+		if types.IsZeroAddress(token.MintAuthority) || token.MintAuthority.Compare(ctx.Tx.From) != 0 {
+			return xerrors.ErrNoRight.Wrapf("%v is not the mint authority of %v", ctx.Tx.From, ta.Token)
+		}
+		if types.IsZeroAddress(ctx.Tx.To) {
+			return xerrors.ErrInvalidAddress.Wrapf("the receiver of the minted token should not be the zero address")
+		}
+		if xerr := token.CheckMint(ta.Amount); xerr != nil {
+			return xerr
+		}
+	case btztypes.TRX_TOKEN_TRANSFER:
+		if types.IsZeroAddress(ctx.Tx.To) {
+			return xerrors.ErrInvalidAddress.Wrapf("the receiver of the token should not be the zero address")
+		}
+		fallthrough
+	case btztypes.TRX_TOKEN_BURN:
+		if bal := ctrler.TokenBalanceOf(ta.Token, ctx.Tx.From, ctx.Exec); bal.Lt(ta.Amount) {
+			return xerrors.ErrInsufficientFund.Wrapf("the token balance(%v) of %v is less than %v", bal.Dec(), ctx.Tx.From, ta.Amount.Dec())
+		}
+	}
+	return nil
+}
+
+func (ctrler *AcctCtrler) validateTokenIssue(ctx *btztypes.TrxContext, txpayload *btztypes.TrxPayloadTokenIssue) xerrors.XError {
+	if !types.IsZeroAddress(ctx.Tx.To) {
+		return xerrors.ErrInvalidAddress.Wrapf("the 'to' field in TRX_TOKEN_ISSUE should be zero address")
+	}
+	if len(txpayload.Name) > btztypes.MAX_TOKEN_NAME {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("too long name. it should be less than %d.", btztypes.MAX_TOKEN_NAME)
+	}
+	if len(txpayload.Symbol) == 0 || len(txpayload.Symbol) > btztypes.MAX_TOKEN_SYMBOL {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong symbol. its length should be between 1 and %d.", btztypes.MAX_TOKEN_SYMBOL)
+	}
+	if txpayload.Decimals < 0 || txpayload.Decimals > btztypes.MAX_TOKEN_DECIMALS {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong decimals. it should be between 0 and %d.", btztypes.MAX_TOKEN_DECIMALS)
+	}
+	if txpayload.MaxSupply == nil || txpayload.InitialSupply == nil {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("max supply and initial supply should be given")
+	}
+	if !txpayload.MaxSupply.IsZero() && txpayload.InitialSupply.Gt(txpayload.MaxSupply) {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("initial supply(%v) exceeds max supply(%v)", txpayload.InitialSupply.Dec(), txpayload.MaxSupply.Dec())
+	}
+	if len(txpayload.MintAuthority) != 0 && len(txpayload.MintAuthority) != types.AddrSize {
+		return xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong mint authority: %v", txpayload.MintAuthority)
+	}
+	if ctrler.FindToken(btztypes.TokenAddress(ctx.Tx.From, ctx.Tx.Nonce), ctx.Exec) != nil {
+		return xerrors.ErrDuplicatedKey.Wrapf("token: %v", btztypes.TokenAddress(ctx.Tx.From, ctx.Tx.Nonce))
+	}
+	return nil
+}
+
+// executeTokenTrx runs the token tx. `ctrler.mtx` must be locked by the caller.
+func (ctrler *AcctCtrler) executeTokenTrx(ctx *btztypes.TrxContext) xerrors.XError {
+	var token *btztypes.Token
+	var amt *uint256.Int
+	var action string
+
+	switch txpayload := ctx.Tx.Payload.(type) {
+	case *btztypes.TrxPayloadTokenIssue:
+		mintAuth := txpayload.MintAuthority
+		if len(mintAuth) == 0 {
+			mintAuth = types.ZeroAddress()
+		}
+		token = btztypes.NewToken(
+			btztypes.TokenAddress(ctx.Tx.From, ctx.Tx.Nonce), ctx.Tx.From,
+			txpayload.Name, txpayload.Symbol, txpayload.Decimals, txpayload.MaxSupply, mintAuth)
+		amt, action = txpayload.InitialSupply, "issue"
+		if xerr := token.Mint(amt); xerr != nil {
+			return xerr
+		}
+		if xerr := ctrler.addTokenBalance(token.Address, ctx.Tx.From, amt, ctx.Exec); xerr != nil {
+			return xerr
+		}
+		// like the contract creation, the tx returns the address of the new token.
+		ctx.RetData = token.Address
+	case *btztypes.TrxPayloadTokenMint:
+		if token = ctrler.findToken(txpayload.Token, ctx.Exec); token == nil {
+			return xerrors.ErrNotFoundToken.Wrapf("token: %v", txpayload.Token)
+		}
+		amt, action = txpayload.Amount, "mint"
+		if xerr := token.Mint(amt); xerr != nil {
+			return xerr
+		}
+		if xerr := ctrler.addTokenBalance(token.Address, ctx.Tx.To, amt, ctx.Exec); xerr != nil {
+			return xerr
+		}
+	case *btztypes.TrxPayloadTokenBurn:
+		if token = ctrler.findToken(txpayload.Token, ctx.Exec); token == nil {
+			return xerrors.ErrNotFoundToken.Wrapf("token: %v", txpayload.Token)
+		}
+		amt, action = txpayload.Amount, "burn"
+		if xerr := ctrler.subTokenBalance(token.Address, ctx.Tx.From, amt, ctx.Exec); xerr != nil {
+			return xerr
+		}
+		if xerr := token.Burn(amt); xerr != nil {
+			return xerr
+		}
+	case *btztypes.TrxPayloadTokenTransfer:
+		if token = ctrler.findToken(txpayload.Token, ctx.Exec); token == nil {
+			return xerrors.ErrNotFoundToken.Wrapf("token: %v", txpayload.Token)
+		}
+		amt, action = txpayload.Amount, "transfer"
+		if xerr := ctrler.subTokenBalance(token.Address, ctx.Tx.From, amt, ctx.Exec); xerr != nil {
+			return xerr
+		}
+		if xerr := ctrler.addTokenBalance(token.Address, ctx.Tx.To, amt, ctx.Exec); xerr != nil {
+			return xerr
+		}
+	default:
+		return xerrors.ErrInvalidTrxPayloadType
+	}
+
+	if action != "transfer" {
+		// the total supply of `token` has been changed.
+		if xerr := ctrler.acctState.Set(v1.LedgerKeyToken(token.Address), token, ctx.Exec); xerr != nil {
+			return xerr
+		}
+	}
+
+	ctx.Events = append(ctx.Events, abcitypes.Event{
+		Type: "token",
+		Attributes: []abcitypes.EventAttribute{
+			{Key: []byte("address"), Value: []byte(token.Address.String()), Index: true},
+			{Key: []byte("action"), Value: []byte(action), Index: false},
+			{Key: []byte("amount"), Value: []byte(amt.Dec()), Index: false},
+		},
+	})
+	return nil
+}
+
+func (ctrler *AcctCtrler) FindToken(addr types.Address, exec bool) *btztypes.Token {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	return ctrler.findToken(addr, exec)
+}
+
+func (ctrler *AcctCtrler) findToken(addr types.Address, exec bool) *btztypes.Token {
+	if item, xerr := ctrler.acctState.Get(v1.LedgerKeyToken(addr), exec); xerr != nil {
+		return nil
+	} else {
+		return item.(*btztypes.Token)
+	}
+}
+
+// TokenBalanceOf returns the balance of `token` held by `holder`. It is 0 if there is no balance.
+func (ctrler *AcctCtrler) TokenBalanceOf(token, holder types.Address, exec bool) *uint256.Int {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	return ctrler.tokenBalanceOf(token, holder, exec)
+}
+
+func (ctrler *AcctCtrler) tokenBalanceOf(token, holder types.Address, exec bool) *uint256.Int {
+	if item, xerr := ctrler.acctState.Get(v1.LedgerKeyTokenBalance(token, holder), exec); xerr != nil {
+		return uint256.NewInt(0)
+	} else {
+		return item.(*btztypes.TokenBalance).Clone()
+	}
+}
+
+func (ctrler *AcctCtrler) addTokenBalance(token, holder types.Address, amt *uint256.Int, exec bool) xerrors.XError {
+	bal, overflow := new(uint256.Int).AddOverflow(ctrler.tokenBalanceOf(token, holder, exec), amt)
+	if overflow {
+		return xerrors.ErrOverFlow.Wrapf("token balance of %v", holder)
+	}
+	return ctrler.acctState.Set(v1.LedgerKeyTokenBalance(token, holder), btztypes.NewTokenBalance(bal), exec)
+}
+
+// subTokenBalance decreases the balance of `holder`. The balance which becomes 0 is removed from the ledger.
+func (ctrler *AcctCtrler) subTokenBalance(token, holder types.Address, amt *uint256.Int, exec bool) xerrors.XError {
+	bal := ctrler.tokenBalanceOf(token, holder, exec)
+	if bal.Lt(amt) {
+		return xerrors.ErrInsufficientFund.Wrapf("the token balance(%v) of %v is less than %v", bal.Dec(), holder, amt.Dec())
+	}
+	bal = new(uint256.Int).Sub(bal, amt)
+	if bal.IsZero() {
+		return ctrler.acctState.Del(v1.LedgerKeyTokenBalance(token, holder), exec)
+	}
+	return ctrler.acctState.Set(v1.LedgerKeyTokenBalance(token, holder), btztypes.NewTokenBalance(bal), exec)
+}
+
+func (memCtrler *SimuAcctCtrler) FindToken(addr types.Address, exec bool) *btztypes.Token {
+	memCtrler.mtx.RLock()
+	defer memCtrler.mtx.RUnlock()
+
+	if item, xerr := memCtrler.simuLedger.Get(v1.LedgerKeyToken(addr)); xerr != nil {
+		return nil
+	} else {
+		return item.(*btztypes.Token)
+	}
+}
+
+func (memCtrler *SimuAcctCtrler) TokenBalanceOf(token, holder types.Address, exec bool) *uint256.Int {
+	memCtrler.mtx.RLock()
+	defer memCtrler.mtx.RUnlock()
+
+	if item, xerr := memCtrler.simuLedger.Get(v1.LedgerKeyTokenBalance(token, holder)); xerr != nil {
+		return uint256.NewInt(0)
+	} else {
+		return item.(*btztypes.TokenBalance).Clone()
+	}
+}
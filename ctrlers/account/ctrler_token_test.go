@@ -0,0 +1,144 @@
+package account
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	btzcfg "github.com/beatoz/beatoz-go/cmd/config"
+	"github.com/beatoz/beatoz-go/ctrlers/crisis"
+	"github.com/beatoz/beatoz-go/ctrlers/mocks"
+	govmock "github.com/beatoz/beatoz-go/ctrlers/mocks/gov"
+	"github.com/beatoz/beatoz-go/ctrlers/types"
+	btztypes "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+func Test_NativeToken(t *testing.T) {
+	rootDir := filepath.Join(os.TempDir(), "native-token-test")
+	config := btzcfg.DefaultConfig()
+	config.SetRoot(rootDir)
+	require.NoError(t, os.RemoveAll(config.RootDir))
+	defer os.RemoveAll(config.RootDir)
+
+	govMock := govmock.NewGovHandlerMock(types.NewGovParams(1))
+	ctrler, xerr := NewAcctCtrler(config, tmlog.NewNopLogger())
+	require.NoError(t, xerr)
+
+	_ = mocks.InitBlockCtxWith("", 1, govMock, ctrler, nil, nil, nil)
+	require.NoError(t, mocks.DoBeginBlock(ctrler))
+
+	issuer, alice, bob := btztypes.RandAddress(), btztypes.RandAddress(), btztypes.RandAddress()
+	nonce := int64(0)
+	newTrxCtx := func(from, to btztypes.Address, payload types.ITrxPayload) *types.TrxContext {
+		nonce++
+		tx := types.NewTrx(1, from, to, nonce, govMock.MinTrxGas(), govMock.GasPrice(), uint256.NewInt(0), payload)
+		var receiver *types.Account
+		if !btztypes.IsZeroAddress(to) {
+			receiver = ctrler.FindOrNewAccount(to, true)
+		}
+		return &types.TrxContext{
+			Tx:       tx,
+			TxHash:   btztypes.RandAddress(),
+			Exec:     true,
+			Sender:   ctrler.FindOrNewAccount(from, true),
+			Receiver: receiver,
+		}
+	}
+
+	//
+	// issue
+	issueCtx := newTrxCtx(issuer, btztypes.ZeroAddress(), &types.TrxPayloadTokenIssue{
+		Name:          "Test Token",
+		Symbol:        "TST",
+		Decimals:      6,
+		MaxSupply:     uint256.NewInt(1000),
+		InitialSupply: uint256.NewInt(600),
+		MintAuthority: issuer,
+	})
+	require.NoError(t, mocks.DoRunTrx(ctrler, issueCtx))
+	tokenAddr := types.TokenAddress(issuer, issueCtx.Tx.Nonce)
+	require.EqualValues(t, tokenAddr, issueCtx.RetData)
+
+	token := ctrler.FindToken(tokenAddr, true)
+	require.NotNil(t, token)
+	require.Equal(t, "TST", token.Symbol)
+	require.Equal(t, uint256.NewInt(600), token.TotalSupply)
+	require.Equal(t, uint256.NewInt(600), ctrler.TokenBalanceOf(tokenAddr, issuer, true))
+
+	// too long symbol
+	xerr = mocks.DoRunTrx(ctrler, newTrxCtx(issuer, btztypes.ZeroAddress(), &types.TrxPayloadTokenIssue{
+		Symbol:        "TOO_LONG_SYMBOL_OF_TOKEN",
+		MaxSupply:     uint256.NewInt(0),
+		InitialSupply: uint256.NewInt(0),
+	}))
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxPayloadParams.Error())
+
+	//
+	// mint
+	require.NoError(t, mocks.DoRunTrx(ctrler, newTrxCtx(issuer, alice,
+		&types.TrxPayloadTokenMint{TokenAmount: types.TokenAmount{Token: tokenAddr, Amount: uint256.NewInt(300)}})))
+	require.Equal(t, uint256.NewInt(900), ctrler.FindToken(tokenAddr, true).TotalSupply)
+	require.Equal(t, uint256.NewInt(300), ctrler.TokenBalanceOf(tokenAddr, alice, true))
+
+	// only the mint authority can mint.
+	xerr = mocks.DoRunTrx(ctrler, newTrxCtx(alice, alice,
+		&types.TrxPayloadTokenMint{TokenAmount: types.TokenAmount{Token: tokenAddr, Amount: uint256.NewInt(1)}}))
+	require.ErrorContains(t, xerr, xerrors.ErrNoRight.Error())
+
+	// it can not exceed the max supply.
+	xerr = mocks.DoRunTrx(ctrler, newTrxCtx(issuer, alice,
+		&types.TrxPayloadTokenMint{TokenAmount: types.TokenAmount{Token: tokenAddr, Amount: uint256.NewInt(101)}}))
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidAmount.Error())
+
+	//
+	// transfer
+	require.NoError(t, mocks.DoRunTrx(ctrler, newTrxCtx(alice, bob,
+		&types.TrxPayloadTokenTransfer{TokenAmount: types.TokenAmount{Token: tokenAddr, Amount: uint256.NewInt(100)}})))
+	require.Equal(t, uint256.NewInt(200), ctrler.TokenBalanceOf(tokenAddr, alice, true))
+	require.Equal(t, uint256.NewInt(100), ctrler.TokenBalanceOf(tokenAddr, bob, true))
+
+	xerr = mocks.DoRunTrx(ctrler, newTrxCtx(bob, alice,
+		&types.TrxPayloadTokenTransfer{TokenAmount: types.TokenAmount{Token: tokenAddr, Amount: uint256.NewInt(101)}}))
+	require.ErrorContains(t, xerr, xerrors.ErrInsufficientFund.Error())
+
+	xerr = mocks.DoRunTrx(ctrler, newTrxCtx(bob, alice,
+		&types.TrxPayloadTokenTransfer{TokenAmount: types.TokenAmount{Token: btztypes.RandAddress(), Amount: uint256.NewInt(1)}}))
+	require.ErrorContains(t, xerr, xerrors.ErrNotFoundToken.Error())
+
+	//
+	// burn
+	require.NoError(t, mocks.DoRunTrx(ctrler, newTrxCtx(bob, btztypes.ZeroAddress(),
+		&types.TrxPayloadTokenBurn{TokenAmount: types.TokenAmount{Token: tokenAddr, Amount: uint256.NewInt(100)}})))
+	require.Equal(t, uint256.NewInt(800), ctrler.FindToken(tokenAddr, true).TotalSupply)
+	require.True(t, ctrler.TokenBalanceOf(tokenAddr, bob, true).IsZero())
+
+	require.NoError(t, mocks.DoEndBlockAndCommit(ctrler))
+
+	// the committed state
+	require.Equal(t, uint256.NewInt(800), ctrler.FindToken(tokenAddr, false).TotalSupply)
+	require.Equal(t, uint256.NewInt(600), ctrler.TokenBalanceOf(tokenAddr, issuer, false))
+	require.Equal(t, uint256.NewInt(200), ctrler.TokenBalanceOf(tokenAddr, alice, false))
+
+	//
+	// invariants
+	reg := crisis.NewInvariantRegistry()
+	ctrler.RegisterInvariants(reg)
+
+	// a balance not counted in the total supply
+	require.NoError(t, ctrler.addTokenBalance(tokenAddr, bob, uint256.NewInt(1), true))
+	_, height, xerr := ctrler.Commit()
+	require.NoError(t, xerr)
+
+	broken := reg.AssertBroken(height)
+	require.Len(t, broken, 1)
+	require.Equal(t, "account", broken[0].Module)
+	require.Equal(t, "token-supply", broken[0].Route)
+	require.Contains(t, broken[0].Msg, tokenAddr.String())
+
+	// the state at the previous height is still valid.
+	require.Len(t, reg.AssertBroken(height-1), 0)
+}
@@ -78,3 +78,31 @@ func Test_TxFeeProcessing(t *testing.T) {
 		require.NoError(t, mocks.DoCommit(ctrler))
 	}
 }
+
+func Test_TxFeeToTreasury(t *testing.T) {
+	rootDir := filepath.Join(os.TempDir(), "txfee-treasury-test")
+	config := btzcfg.DefaultConfig()
+	config.SetRoot(rootDir)
+	require.NoError(t, os.RemoveAll(config.RootDir))
+	defer os.RemoveAll(config.RootDir)
+
+	params := types.NewGovParams(1)
+	params.SetValue(func(v *types.GovParamsProto) {
+		v.TxFeeRewardRate = 80
+		v.TreasuryFeeRate = 15
+	})
+	govMock := govmock.NewGovHandlerMock(params)
+	ctrler, xerr := NewAcctCtrler(config, tmlog.NewNopLogger())
+	require.NoError(t, xerr)
+
+	_ = mocks.InitBlockCtxWith("", 1, govMock, ctrler, nil, nil, nil)
+	require.NoError(t, mocks.DoBeginBlock(ctrler))
+	proposer := btztypes.RandAddress()
+	mocks.CurrBlockCtx().SetProposerAddress(proposer)
+	mocks.CurrBlockCtx().AddFee(uint256.NewInt(1000))
+	require.NoError(t, mocks.DoEndBlockAndCommit(ctrler))
+
+	require.Equal(t, uint256.NewInt(800), ctrler.FindAccount(proposer, true).GetBalance())
+	require.Equal(t, uint256.NewInt(150), ctrler.FindAccount(btztypes.TreasuryAddress(), true).GetBalance())
+	require.Equal(t, uint256.NewInt(50), ctrler.FindAccount(govMock.DeadAddress(), true).GetBalance())
+}
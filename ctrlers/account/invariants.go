@@ -0,0 +1,94 @@
+package account
+
+import (
+	"fmt"
+	"sort"
+
+	btztypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+)
+
+// SumBalanceAt returns the sum of the balances of all accounts committed at `height`.
+func (ctrler *AcctCtrler) SumBalanceAt(height int64) (*uint256.Int, xerrors.XError) {
+	atledger, xerr := ctrler.acctState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	sum := uint256.NewInt(0)
+	xerr = atledger.Seek(v1.KeyPrefixAccount, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		acct, _ := item.(*btztypes.Account)
+		_ = sum.Add(sum, acct.Balance)
+		return nil
+	})
+	if xerr != nil {
+		return nil, xerr
+	}
+	return sum, nil
+}
+
+func (ctrler *AcctCtrler) RegisterInvariants(reg btztypes.IInvariantRegistry) {
+	reg.RegisterRoute("account", "token-supply", ctrler.tokenSupplyInvariant)
+}
+
+// tokenSupplyInvariant checks that the total supply of each native token is the sum of its balances.
+func (ctrler *AcctCtrler) tokenSupplyInvariant(height int64) (string, bool) {
+	atledger, xerr := ctrler.acctState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return fmt.Sprintf("account: token-supply invariant: %v", xerr), true
+	}
+
+	sums := make(map[string]*uint256.Int)
+	xerr = atledger.Seek(v1.KeyPrefixTokenBalance, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		bal, _ := item.(*btztypes.TokenBalance)
+		token := types.Address(key[len(v1.KeyPrefixTokenBalance) : len(v1.KeyPrefixTokenBalance)+types.AddrSize]).String()
+		sum, ok := sums[token]
+		if !ok {
+			sum = uint256.NewInt(0)
+			sums[token] = sum
+		}
+		_ = sum.Add(sum, &bal.Int)
+		return nil
+	})
+	if xerr != nil {
+		return fmt.Sprintf("account: token-supply invariant: %v", xerr), true
+	}
+
+	var broken []string
+	xerr = atledger.Seek(v1.KeyPrefixToken, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		token, _ := item.(*btztypes.Token)
+		sum, ok := sums[token.Address.String()]
+		if !ok {
+			sum = uint256.NewInt(0)
+		}
+		delete(sums, token.Address.String())
+
+		if !sum.Eq(token.TotalSupply) {
+			broken = append(broken, fmt.Sprintf("token(%v) has total supply %v, but the sum of balances is %v",
+				token.Address, token.TotalSupply.Dec(), sum.Dec()))
+		}
+		return nil
+	})
+	if xerr != nil {
+		return fmt.Sprintf("account: token-supply invariant: %v", xerr), true
+	}
+	var unknowns []string
+	for addr, sum := range sums {
+		if !sum.IsZero() {
+			unknowns = append(unknowns, fmt.Sprintf("the balances %v are held of the unknown token(%v)", sum.Dec(), addr))
+		}
+	}
+	sort.Strings(unknowns) // for deterministic message
+	broken = append(broken, unknowns...)
+
+	msg := fmt.Sprintf("account: token-supply invariant: %d broken token(s)", len(broken))
+	for _, d := range broken {
+		msg += "\n\t" + d
+	}
+	return msg, len(broken) > 0
+}
+
+var _ btztypes.IInvariantHandler = (*AcctCtrler)(nil)
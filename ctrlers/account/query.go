@@ -16,6 +16,16 @@ func (ctrler *AcctCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertypes.
 		return nil, xerrors.ErrQuery.Wrap(xerr)
 	}
 
+	switch req.Path {
+	case "token":
+		return queryToken(immuLedger, req.Data)
+	case "token_balance":
+		if len(req.Data) != types.AddrSize*2 {
+			return nil, xerrors.ErrQuery.Wrap(xerrors.ErrInvalidQueryParams)
+		}
+		return queryTokenBalance(immuLedger, req.Data[:types.AddrSize], req.Data[types.AddrSize:])
+	}
+
 	item, xerr := immuLedger.Get(v1.LedgerKeyAccount(req.Data))
 	if xerr != nil {
 		item = ctrlertypes.NewAccount(req.Data)
@@ -49,3 +59,63 @@ func (ctrler *AcctCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertypes.
 		return raw, nil
 	}
 }
+
+func queryToken(atledger v1.IImitable, addr types.Address) ([]byte, xerrors.XError) {
+	item, xerr := atledger.Get(v1.LedgerKeyToken(addr))
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerrors.ErrNotFoundToken)
+	}
+
+	// the supplies are marshaled to decimal strings like `Account::Balance`.
+	token, _ := item.(*ctrlertypes.Token)
+	_token := &struct {
+		Address       types.Address `json:"address"`
+		Issuer        types.Address `json:"issuer"`
+		Name          string        `json:"name,omitempty"`
+		Symbol        string        `json:"symbol"`
+		Decimals      int32         `json:"decimals"`
+		MaxSupply     string        `json:"maxSupply"`
+		TotalSupply   string        `json:"totalSupply"`
+		MintAuthority types.Address `json:"mintAuthority"`
+	}{
+		Address:       token.Address,
+		Issuer:        token.Issuer,
+		Name:          token.Name,
+		Symbol:        token.Symbol,
+		Decimals:      token.Decimals,
+		MaxSupply:     token.MaxSupply.Dec(),
+		TotalSupply:   token.TotalSupply.Dec(),
+		MintAuthority: token.MintAuthority,
+	}
+	if raw, err := jsonx.Marshal(_token); err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	} else {
+		return raw, nil
+	}
+}
+
+func queryTokenBalance(atledger v1.IImitable, token, holder types.Address) ([]byte, xerrors.XError) {
+	if _, xerr := atledger.Get(v1.LedgerKeyToken(token)); xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerrors.ErrNotFoundToken)
+	}
+
+	balance := "0"
+	if item, xerr := atledger.Get(v1.LedgerKeyTokenBalance(token, holder)); xerr == nil {
+		balance = item.(*ctrlertypes.TokenBalance).Dec()
+	}
+
+	_balance := &struct {
+		Token   types.Address `json:"token"`
+		Address types.Address `json:"address"`
+		Balance string        `json:"balance"`
+	}{
+		Token:   token,
+		Address: holder,
+		Balance: balance,
+	}
+	if raw, err := jsonx.Marshal(_balance); err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	} else {
+		return raw, nil
+	}
+}
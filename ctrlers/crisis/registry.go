@@ -0,0 +1,87 @@
+package crisis
+
+import (
+	"fmt"
+	"sync"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+)
+
+type invariantRoute struct {
+	module string
+	route  string
+	invar  ctrlertypes.Invariant
+}
+
+func (r *invariantRoute) FullRoute() string {
+	return fmt.Sprintf("%s/%s", r.module, r.route)
+}
+
+// InvariantRegistry keeps the invariants contributed by the controllers.
+// The invariants are asserted in the order they are registered.
+type InvariantRegistry struct {
+	routes []*invariantRoute
+	mtx    sync.RWMutex
+}
+
+func NewInvariantRegistry() *InvariantRegistry {
+	return &InvariantRegistry{}
+}
+
+func (reg *InvariantRegistry) RegisterRoute(module, route string, invar ctrlertypes.Invariant) {
+	reg.mtx.Lock()
+	defer reg.mtx.Unlock()
+
+	for _, r := range reg.routes {
+		if r.module == module && r.route == route {
+			panic(fmt.Errorf("the invariant route(%s/%s) is already registered", module, route))
+		}
+	}
+	reg.routes = append(reg.routes, &invariantRoute{
+		module: module,
+		route:  route,
+		invar:  invar,
+	})
+}
+
+func (reg *InvariantRegistry) Routes() []string {
+	reg.mtx.RLock()
+	defer reg.mtx.RUnlock()
+
+	ret := make([]string, len(reg.routes))
+	for i, r := range reg.routes {
+		ret[i] = r.FullRoute()
+	}
+	return ret
+}
+
+// AssertAll runs all invariants against the state at `height` and returns the result of each invariant.
+func (reg *InvariantRegistry) AssertAll(height int64) []*ctrlertypes.InvariantResult {
+	reg.mtx.RLock()
+	defer reg.mtx.RUnlock()
+
+	ret := make([]*ctrlertypes.InvariantResult, len(reg.routes))
+	for i, r := range reg.routes {
+		msg, broken := r.invar(height)
+		ret[i] = &ctrlertypes.InvariantResult{
+			Module: r.module,
+			Route:  r.route,
+			Broken: broken,
+			Msg:    msg,
+		}
+	}
+	return ret
+}
+
+// AssertBroken runs all invariants against the state at `height` and returns only the broken ones.
+func (reg *InvariantRegistry) AssertBroken(height int64) []*ctrlertypes.InvariantResult {
+	var ret []*ctrlertypes.InvariantResult
+	for _, r := range reg.AssertAll(height) {
+		if r.Broken {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+var _ ctrlertypes.IInvariantRegistry = (*InvariantRegistry)(nil)
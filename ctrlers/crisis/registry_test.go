@@ -0,0 +1,41 @@
+package crisis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InvariantRegistry(t *testing.T) {
+	reg := NewInvariantRegistry()
+	reg.RegisterRoute("moduleA", "route0", func(height int64) (string, bool) {
+		return "moduleA/route0", false
+	})
+	reg.RegisterRoute("moduleB", "route0", func(height int64) (string, bool) {
+		return "moduleB/route0", height%2 == 0
+	})
+	reg.RegisterRoute("moduleA", "route1", func(height int64) (string, bool) {
+		return "moduleA/route1", height%3 == 0
+	})
+	require.Panics(t, func() {
+		reg.RegisterRoute("moduleA", "route0", func(height int64) (string, bool) {
+			return "", false
+		})
+	})
+	require.Equal(t, []string{"moduleA/route0", "moduleB/route0", "moduleA/route1"}, reg.Routes())
+
+	rets := reg.AssertAll(1)
+	require.Len(t, rets, 3)
+	for i, r := range rets {
+		require.Equal(t, reg.Routes()[i], r.Module+"/"+r.Route)
+		require.Equal(t, r.Module+"/"+r.Route, r.Msg)
+		require.False(t, r.Broken)
+	}
+
+	require.Len(t, reg.AssertBroken(1), 0)
+	require.Len(t, reg.AssertBroken(2), 1)
+	require.Len(t, reg.AssertBroken(3), 1)
+	require.Len(t, reg.AssertBroken(6), 2)
+	require.Equal(t, "route0", reg.AssertBroken(2)[0].Route)
+	require.Equal(t, "moduleA", reg.AssertBroken(3)[0].Module)
+}
@@ -0,0 +1,57 @@
+package crisis
+
+import (
+	"fmt"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+)
+
+type ISupplyReader interface {
+	TotalSupplyAt(height int64) (*uint256.Int, xerrors.XError)
+	SumRewardAt(height int64) (*uint256.Int, xerrors.XError)
+}
+
+type IBalanceReader interface {
+	SumBalanceAt(height int64) (*uint256.Int, xerrors.XError)
+}
+
+type IPowerReader interface {
+	SumPowerAt(height int64) (int64, int64, xerrors.XError)
+}
+
+// TotalSupplyInvariant returns the invariant checking that the total supply is equal to
+// the sum of all balances, bonded powers, frozen powers and rewards not withdrawn yet.
+func TotalSupplyInvariant(supply ISupplyReader, acct IBalanceReader, vpow IPowerReader) ctrlertypes.Invariant {
+	return func(height int64) (string, bool) {
+		totalSupply, xerr := supply.TotalSupplyAt(height)
+		if xerr != nil {
+			return fmt.Sprintf("supply: total-supply invariant: %v", xerr), true
+		}
+		rewards, xerr := supply.SumRewardAt(height)
+		if xerr != nil {
+			return fmt.Sprintf("supply: total-supply invariant: %v", xerr), true
+		}
+		balances, xerr := acct.SumBalanceAt(height)
+		if xerr != nil {
+			return fmt.Sprintf("supply: total-supply invariant: %v", xerr), true
+		}
+		bonded, frozen, xerr := vpow.SumPowerAt(height)
+		if xerr != nil {
+			return fmt.Sprintf("supply: total-supply invariant: %v", xerr), true
+		}
+
+		bondedAmt := types.PowerToAmount(bonded)
+		frozenAmt := types.PowerToAmount(frozen)
+
+		sum := new(uint256.Int).Add(balances, bondedAmt)
+		_ = sum.Add(sum, frozenAmt)
+		_ = sum.Add(sum, rewards)
+
+		broken := !sum.Eq(totalSupply)
+		return fmt.Sprintf("supply: total-supply invariant: total supply(%v), sum(%v) = balances(%v) + bonded(%v) + frozen(%v) + rewards(%v)",
+			totalSupply.Dec(), sum.Dec(), balances.Dec(), bondedAmt.Dec(), frozenAmt.Dec(), rewards.Dec()), broken
+	}
+}
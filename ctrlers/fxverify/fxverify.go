@@ -0,0 +1,217 @@
+// Package fxverify checks that the weight and issuance computations based on `libs/fxnum`
+// produce exactly the same results as the reference outputs recorded for each arithmetic backend.
+// The results of `robaho/fixed`(default) and `shopspring/decimal`('-tags decimal') builds differ,
+// so the nodes built with different backends cannot be in the same network.
+package fxverify
+
+import (
+	"embed"
+	"fmt"
+	"path"
+
+	"github.com/beatoz/beatoz-go/ctrlers/supply"
+	"github.com/beatoz/beatoz-go/ctrlers/vpower"
+	"github.com/beatoz/beatoz-go/libs/fxnum"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/holiman/uint256"
+)
+
+const (
+	BackendFixed   = "fixed"
+	BackendDecimal = "decimal"
+
+	CorpusFile = "corpus.json"
+)
+
+var Backends = []string{BackendFixed, BackendDecimal}
+
+//go:embed testdata/*.json
+var testdata embed.FS
+
+type PowerChunk struct {
+	Power  int64 `json:"power"`
+	Height int64 `json:"height"`
+}
+
+// WeightCase is the input of `Wa`, the weight of all power chunks, and `Wi`, the weight of each beneficiary.
+// `Benefs` has the indices of `Chunks` owned by each beneficiary.
+type WeightCase struct {
+	Name          string        `json:"name"`
+	Height        int64         `json:"height"`
+	RipeningCycle int64         `json:"ripeningCycle"`
+	Tau           int32         `json:"tau"`
+	BaseSupply    string        `json:"baseSupply"`
+	Chunks        []*PowerChunk `json:"chunks"`
+	Benefs        [][]int       `json:"benefs"`
+}
+
+// SdCase is the input of `Sd`, the additional issuance.
+type SdCase struct {
+	Name         string `json:"name"`
+	ScaledHeight string `json:"scaledHeight"`
+	LastSupply   string `json:"lastSupply"`
+	MaxSupply    string `json:"maxSupply"`
+	Lambda       int32  `json:"lambda"`
+	Wa           string `json:"wa"`
+}
+
+type Corpus struct {
+	Weights []*WeightCase `json:"weights"`
+	Sds     []*SdCase     `json:"sds"`
+}
+
+type Output struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Golden is the reference outputs of a backend for the corpus.
+type Golden struct {
+	Backend string    `json:"backend"`
+	Outputs []*Output `json:"outputs"`
+}
+
+func (g *Golden) Find(key string) *Output {
+	for _, o := range g.Outputs {
+		if o.Key == key {
+			return o
+		}
+	}
+	return nil
+}
+
+type Mismatch struct {
+	Key      string
+	Expected string
+	Actual   string
+}
+
+func GoldenFile(backend string) string {
+	return fmt.Sprintf("golden_%s.json", backend)
+}
+
+// DefaultCorpus returns the corpus embedded in the binary.
+func DefaultCorpus() (*Corpus, error) {
+	bz, err := testdata.ReadFile(path.Join("testdata", CorpusFile))
+	if err != nil {
+		return nil, err
+	}
+	return DecodeCorpus(bz)
+}
+
+// DefaultGolden returns the reference outputs of `backend` embedded in the binary.
+func DefaultGolden(backend string) (*Golden, error) {
+	bz, err := testdata.ReadFile(path.Join("testdata", GoldenFile(backend)))
+	if err != nil {
+		return nil, err
+	}
+	return DecodeGolden(bz)
+}
+
+func DecodeCorpus(bz []byte) (*Corpus, error) {
+	corpus := &Corpus{}
+	if err := jsonx.Unmarshal(bz, corpus); err != nil {
+		return nil, err
+	}
+	return corpus, nil
+}
+
+func DecodeGolden(bz []byte) (*Golden, error) {
+	golden := &Golden{}
+	if err := jsonx.Unmarshal(bz, golden); err != nil {
+		return nil, err
+	}
+	return golden, nil
+}
+
+// Compute runs the computations over `corpus` with the backend of this build.
+func Compute(corpus *Corpus) (*Golden, error) {
+	ret := &Golden{Backend: fxnum.Backend}
+
+	for _, c := range corpus.Weights {
+		outs, err := computeWeight(c)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Name, err)
+		}
+		ret.Outputs = append(ret.Outputs, outs...)
+	}
+	for _, c := range corpus.Sds {
+		out, err := computeSd(c)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Name, err)
+		}
+		ret.Outputs = append(ret.Outputs, out)
+	}
+	return ret, nil
+}
+
+// computeWeight computes `Wa` and `Wi` in the same way as `VPowerCtrler.ComputeWeight`.
+func computeWeight(c *WeightCase) ([]*Output, error) {
+	baseSupply, err := uint256.FromDecimal(c.BaseSupply)
+	if err != nil {
+		return nil, err
+	}
+	pcs := make([]*vpower.PowerChunkProto, len(c.Chunks))
+	for i, pc := range c.Chunks {
+		pcs[i] = &vpower.PowerChunkProto{Power: pc.Power, Height: pc.Height}
+	}
+
+	wa := vpower.FxNumWeightOfPowerChunks(pcs, c.Height, c.RipeningCycle, c.Tau, baseSupply)
+	rets := []*Output{{Key: c.Name + "/wa", Value: wa.String()}}
+
+	allScaled := vpower.FxNumScaledPowerChunks(pcs, c.Height, c.RipeningCycle, c.Tau)
+	sumWi := fxnum.ZERO
+	for i, idxs := range c.Benefs {
+		var wi fxnum.FxNum
+		if i == len(c.Benefs)-1 {
+			// the last beneficiary takes the remainder.
+			wi = wa.Sub(sumWi)
+		} else {
+			var benefPcs []*vpower.PowerChunkProto
+			for _, idx := range idxs {
+				if idx < 0 || idx >= len(pcs) {
+					return nil, fmt.Errorf("wrong chunk index: %d", idx)
+				}
+				benefPcs = append(benefPcs, pcs[idx])
+			}
+			benefScaled := vpower.FxNumScaledPowerChunks(benefPcs, c.Height, c.RipeningCycle, c.Tau)
+			wi = vpower.FxNumBenefWeight(wa, benefScaled, allScaled)
+		}
+		sumWi = sumWi.Add(wi)
+		rets = append(rets, &Output{Key: fmt.Sprintf("%s/wi/%d", c.Name, i), Value: wi.String()})
+	}
+	return rets, nil
+}
+
+func computeSd(c *SdCase) (*Output, error) {
+	lastSupply, err := uint256.FromDecimal(c.LastSupply)
+	if err != nil {
+		return nil, err
+	}
+	maxSupply, err := uint256.FromDecimal(c.MaxSupply)
+	if err != nil {
+		return nil, err
+	}
+	sd := supply.Sd(fxnum.FromString(c.ScaledHeight), lastSupply, maxSupply, c.Lambda, fxnum.FromString(c.Wa))
+	return &Output{Key: c.Name + "/sd", Value: sd.String()}, nil
+}
+
+// Verify compares `actual` with `expected` bit for bit.
+// The outputs missing in either side are also reported as mismatches.
+func Verify(expected, actual *Golden) []*Mismatch {
+	var ret []*Mismatch
+	for _, a := range actual.Outputs {
+		e := expected.Find(a.Key)
+		if e == nil {
+			ret = append(ret, &Mismatch{Key: a.Key, Actual: a.Value})
+		} else if e.Value != a.Value {
+			ret = append(ret, &Mismatch{Key: a.Key, Expected: e.Value, Actual: a.Value})
+		}
+	}
+	for _, e := range expected.Outputs {
+		if actual.Find(e.Key) == nil {
+			ret = append(ret, &Mismatch{Key: e.Key, Expected: e.Value})
+		}
+	}
+	return ret
+}
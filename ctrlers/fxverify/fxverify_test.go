@@ -0,0 +1,139 @@
+package fxverify
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/libs/fxnum"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// The corpus should not be changed once the reference outputs are recorded.
+	// After `-update-corpus`, the reference outputs of both backends must be recorded again:
+	//   go test ./ctrlers/fxverify -update
+	//   go test -tags decimal ./ctrlers/fxverify -update
+	updateCorpus = flag.Bool("update-corpus", false, "regenerate testdata/corpus.json")
+	updateGolden = flag.Bool("update", false, "record the reference outputs of the current backend")
+)
+
+func Test_Golden(t *testing.T) {
+	if *updateCorpus {
+		writeJSON(t, CorpusFile, genCorpus(rand.New(rand.NewSource(20250101))))
+	}
+
+	corpus, err := DefaultCorpus()
+	if *updateCorpus {
+		bz, _ := os.ReadFile(filepath.Join("testdata", CorpusFile))
+		corpus, err = DecodeCorpus(bz)
+	}
+	require.NoError(t, err)
+
+	actual, err := Compute(corpus)
+	require.NoError(t, err)
+	require.Equal(t, fxnum.Backend, actual.Backend)
+
+	// the computations are deterministic in a build.
+	actual2, err := Compute(corpus)
+	require.NoError(t, err)
+	require.Len(t, Verify(actual, actual2), 0)
+
+	if *updateGolden {
+		writeJSON(t, GoldenFile(fxnum.Backend), actual)
+		return
+	}
+
+	expected, err := DefaultGolden(fxnum.Backend)
+	require.NoError(t, err)
+	require.Equal(t, fxnum.Backend, expected.Backend)
+	for _, m := range Verify(expected, actual) {
+		t.Errorf("%s: expected %q, actual %q", m.Key, m.Expected, m.Actual)
+	}
+
+	// the reference outputs of all backends cover the same corpus.
+	for _, backend := range Backends {
+		golden, err := DefaultGolden(backend)
+		require.NoError(t, err)
+		require.Len(t, golden.Outputs, len(actual.Outputs), backend)
+		for _, o := range actual.Outputs {
+			require.NotNil(t, golden.Find(o.Key), "%s: %s", backend, o.Key)
+		}
+	}
+}
+
+func Test_Verify(t *testing.T) {
+	expected := &Golden{Outputs: []*Output{{"a", "1"}, {"b", "2"}, {"c", "3"}}}
+	actual := &Golden{Outputs: []*Output{{"a", "1"}, {"b", "2.0000001"}, {"d", "4"}}}
+
+	mismatches := Verify(expected, actual)
+	require.Len(t, mismatches, 3)
+	require.Equal(t, &Mismatch{Key: "b", Expected: "2", Actual: "2.0000001"}, mismatches[0])
+	require.Equal(t, &Mismatch{Key: "d", Actual: "4"}, mismatches[1])
+	require.Equal(t, &Mismatch{Key: "c", Expected: "3"}, mismatches[2])
+}
+
+func genCorpus(r *rand.Rand) *Corpus {
+	corpus := &Corpus{}
+
+	// the edge cases of the ripening period
+	for i, dur := range []int64{0, 1, 2, 15_768_000, 31_535_999, 31_536_000, 31_536_001} {
+		corpus.Weights = append(corpus.Weights, &WeightCase{
+			Name:          fmt.Sprintf("wgt-dur-%d", i),
+			Height:        40_000_000,
+			RipeningCycle: 31_536_000,
+			Tau:           200,
+			BaseSupply:    types.ToGrans(350_000_000).Dec(),
+			Chunks:        []*PowerChunk{{Power: 35_000_000, Height: 40_000_000 - dur}},
+			Benefs:        [][]int{{0}},
+		})
+	}
+
+	for i := 0; i < 100; i++ {
+		height := r.Int63n(100_000_000) + 1
+		c := &WeightCase{
+			Name:          fmt.Sprintf("wgt-%03d", i),
+			Height:        height,
+			RipeningCycle: []int64{10, 604_800, 31_536_000}[r.Intn(3)],
+			Tau:           int32(r.Intn(1001)),
+			BaseSupply:    types.ToGrans(r.Int63n(700_000_000) + 100_000_000).Dec(),
+		}
+		for j := r.Intn(30) + 1; j > 0; j-- {
+			c.Chunks = append(c.Chunks, &PowerChunk{
+				Power:  r.Int63n(1_000_000) + 100,
+				Height: height - r.Int63n(2*c.RipeningCycle),
+			})
+		}
+		nBenefs := r.Intn(len(c.Chunks)) + 1
+		c.Benefs = make([][]int, nBenefs)
+		for j := range c.Chunks {
+			b := r.Intn(nBenefs)
+			c.Benefs[b] = append(c.Benefs[b], j)
+		}
+		corpus.Weights = append(corpus.Weights, c)
+	}
+
+	for i := 0; i < 100; i++ {
+		maxSupply := r.Int63n(700_000_000) + 100_000_000
+		corpus.Sds = append(corpus.Sds, &SdCase{
+			Name:         fmt.Sprintf("sd-%03d", i),
+			ScaledHeight: []string{"1", "0.5", "2.25"}[r.Intn(3)],
+			LastSupply:   types.ToGrans(maxSupply - r.Int63n(maxSupply/2)).Dec(),
+			MaxSupply:    types.ToGrans(maxSupply).Dec(),
+			Lambda:       int32(r.Intn(1000) + 1),
+			Wa:           fmt.Sprintf("0.%07d", r.Intn(10_000_000)),
+		})
+	}
+	return corpus
+}
+
+func writeJSON(t *testing.T, name string, v interface{}) {
+	bz, err := jsonx.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join("testdata", name), append(bz, '\n'), 0o644))
+}
@@ -0,0 +1,78 @@
+package gov
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/beatoz/beatoz-sdk-go/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestQueryProposals(t *testing.T) {
+	bzOpt, err := jsonx.Marshal(govParams0)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		proposer := vpowMock.PickAddress(i)
+		tx := web3.NewTrxProposal(
+			proposer, types.ZeroAddress(), 1, govCtrler.MinTrxGas(), govCtrler.GasPrice(),
+			fmt.Sprintf("test query proposal %d", i), 30, govCtrler.MinVotingPeriodBlocks(),
+			30+govCtrler.MinVotingPeriodBlocks()+govCtrler.LazyApplyingBlocks(), proposal.PROPOSAL_GOVPARAMS, bzOpt)
+		require.NoError(t, signTrx(tx, proposer, config.ChainIdHex()))
+		require.NoError(t, runTrx(makeTrxCtx(tx, 1, true)))
+	}
+	_, height, xerr := govCtrler.Commit()
+	require.NoError(t, xerr)
+
+	props, xerr := govCtrler.ReadAllProposals(false)
+	require.NoError(t, xerr)
+	require.GreaterOrEqual(t, len(props), 2)
+
+	type item struct {
+		Status   string                `json:"status"`
+		Proposal *proposal.GovProposal `json:"proposal"`
+	}
+	query := func(qry *ctrlertypes.ListQuery) (*ctrlertypes.PageResult, []*item, xerrors.XError) {
+		bz, err := jsonx.Marshal(qry)
+		require.NoError(t, err)
+		bz, xerr := govCtrler.Query(abcitypes.RequestQuery{Path: "proposals", Data: bz, Height: height})
+		if xerr != nil {
+			return nil, nil, xerr
+		}
+		var items []*item
+		ret := &ctrlertypes.PageResult{Items: &items}
+		require.NoError(t, jsonx.Unmarshal(bz, ret))
+		return ret, items, nil
+	}
+
+	ret, items, xerr := query(&ctrlertypes.ListQuery{Status: "voting"})
+	require.NoError(t, xerr)
+	require.Equal(t, len(props), ret.Total)
+	require.Len(t, items, len(props))
+	for i, it := range items {
+		require.Equal(t, "voting", it.Status)
+		require.EqualValues(t, props[i].Header().TxHash, it.Proposal.Header().TxHash)
+	}
+
+	// the last page
+	ret, items, xerr = query(&ctrlertypes.ListQuery{Status: "voting", Page: 2, PerPage: len(props) - 1})
+	require.NoError(t, xerr)
+	require.Equal(t, len(props), ret.Total)
+	require.Len(t, items, 1)
+	require.EqualValues(t, props[len(props)-1].Header().TxHash, items[0].Proposal.Header().TxHash)
+
+	ret, items, xerr = query(&ctrlertypes.ListQuery{Status: "frozen"})
+	require.NoError(t, xerr)
+	for _, it := range items {
+		require.Equal(t, "frozen", it.Status)
+	}
+
+	_, _, xerr = query(&ctrlertypes.ListQuery{Status: "unknown"})
+	require.Error(t, xerr)
+}
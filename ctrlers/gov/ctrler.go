@@ -2,16 +2,18 @@ package gov
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
 	cfg "github.com/beatoz/beatoz-go/cmd/config"
 	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
 	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
 	"github.com/beatoz/beatoz-go/genesis"
 	v1 "github.com/beatoz/beatoz-go/ledger/v1"
-	"github.com/beatoz/beatoz-go/libs/jsonx"
 	"github.com/beatoz/beatoz-go/types"
 	abytes "github.com/beatoz/beatoz-go/types/bytes"
 	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	"sync"
 )
@@ -33,6 +35,12 @@ var defaultNewItemFor = func(key v1.LedgerKey) v1.ILedgerItem {
 	if bytes.HasPrefix(key, v1.KeyPrefixProposal) || bytes.HasPrefix(key, v1.KeyPrefixFrozenProp) {
 		return &proposal.GovProposal{}
 	}
+	if bytes.HasPrefix(key, v1.KeyPrefixTreasuryPayout) {
+		return &proposal.TreasuryPayout{}
+	}
+	if bytes.HasPrefix(key, v1.KeyPrefixAllowList) {
+		return &proposal.AllowList{}
+	}
 	panic("unknown key prefix")
 	return nil
 }
@@ -70,6 +78,9 @@ func (ctrler *GovCtrler) InitLedger(req interface{}) xerrors.XError {
 	}
 	ctrler.GovParams = *genAppState.GovParams
 	_ = ctrler.govState.Set(v1.LedgerKeyGovParams(), &ctrler.GovParams, true)
+	if genAppState.PermissionedDeploy {
+		_ = ctrler.govState.Set(v1.LedgerKeyAllowList(types.ZeroAddress()), proposal.NewAllowList(genAppState.AllowedDeployers...), true)
+	}
 	return nil
 }
 
@@ -85,8 +96,15 @@ func (ctrler *GovCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 		}
 
 		// check right
+		// A non-validator can propose only with an initial deposit.
+		// If no deposit is required, only the validators can propose.
 		if ctx.VPowerHandler.IsValidator(ctx.Tx.From) == false {
-			return xerrors.ErrNoRight
+			if ctrler.MinDeposit().IsZero() {
+				return xerrors.ErrNoRight
+			}
+			if ctx.Tx.Amount.Sign() == 0 {
+				return xerrors.ErrNoRight.Wrapf("a non-validator should propose with an initial deposit")
+			}
 		}
 
 		// check tx type
@@ -115,10 +133,21 @@ func (ctrler *GovCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 		// check governance proposal consistency
 		if txpayload.OptType == proposal.PROPOSAL_GOVPARAMS {
 			//check options
-			checkGovParams := &ctrlertypes.GovParams{}
 			for _, option := range txpayload.Options {
-				if err := jsonx.Unmarshal(option, checkGovParams); err != nil {
-					return xerrors.ErrInvalidTrxPayloadParams.Wrap(err)
+				if _, xerr := proposedGovParams(&ctrler.GovParams, option, txpayload.ApplyingHeight); xerr != nil {
+					return xerrors.ErrInvalidTrxPayloadParams.Wrap(xerr)
+				}
+			}
+		} else if txpayload.OptType == proposal.PROPOSAL_TREASURY_SPEND {
+			for _, option := range txpayload.Options {
+				if _, xerr := proposal.DecodeTreasurySpend(option); xerr != nil {
+					return xerr
+				}
+			}
+		} else if txpayload.OptType == proposal.PROPOSAL_ALLOW_LIST {
+			for _, option := range txpayload.Options {
+				if _, xerr := proposal.DecodeAllowListChange(option); xerr != nil {
+					return xerr
 				}
 			}
 		}
@@ -159,20 +188,77 @@ func (ctrler *GovCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 			return xerr
 		}
 		prop, _ := item.(*proposal.GovProposal)
-		if prop.IsVoter(ctx.Tx.From) == false {
+		if prop.IsVoter(ctx.Tx.From) == false &&
+			len(delegationsToVoters(prop, ctx.Tx.From, ctx.VPowerHandler)) == 0 {
 			return xerrors.ErrNoRight
 		}
 
 		// check choice validation
-		if txpayload.Choice < 0 || txpayload.Choice >= int32(len(prop.Options())) {
+		if txpayload.Choice != proposal.ABSTAIN_CHOICE && txpayload.Choice != proposal.VETO_CHOICE &&
+			(txpayload.Choice < 0 || txpayload.Choice >= int32(len(prop.Options()))) {
 			return xerrors.ErrInvalidTrxPayloadParams
 		}
 
 		// check end height
-		if ctx.Height() > prop.Header().EndVotingHeight ||
+		if prop.IsDepositPeriod() ||
+			ctx.Height() > prop.Header().EndVotingHeight ||
 			ctx.Height() < prop.Header().StartVotingHeight {
 			return xerrors.ErrNotVotingPeriod
 		}
+	case ctrlertypes.TRX_DEPOSIT:
+		if bytes.Compare(ctx.Tx.To, types.ZeroAddress()) != 0 {
+			return xerrors.ErrInvalidTrx.Wrap(errors.New("wrong address: the 'to' field in TRX_DEPOSIT should be zero address"))
+		}
+		txpayload, ok := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadDeposit)
+		if !ok {
+			return xerrors.ErrInvalidTrxPayloadType
+		}
+		if ctx.Tx.Amount.IsZero() {
+			return xerrors.ErrInvalidAmount.Wrapf("the deposit should be greater than 0")
+		}
+
+		item, xerr := ctrler.govState.Get(v1.LedgerKeyProposal(txpayload.TxHash), ctx.Exec)
+		if xerr != nil {
+			if xerr.Contains(xerrors.ErrNotFoundResult) {
+				return xerrors.ErrNotFoundProposal
+			}
+			return xerr
+		}
+		prop, _ := item.(*proposal.GovProposal)
+		if !prop.IsDepositPeriod() || ctx.Height() > prop.Header().DepositEndHeight {
+			return xerrors.ErrInvalidTrx.Wrapf("the proposal is not in the deposit period")
+		}
+	case ctrlertypes.TRX_CANCEL_PROPOSAL:
+		if bytes.Compare(ctx.Tx.To, types.ZeroAddress()) != 0 {
+			return xerrors.ErrInvalidTrx.Wrap(errors.New("wrong address: the 'to' field in TRX_CANCEL_PROPOSAL should be zero address"))
+		}
+		txpayload, ok := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadCancelProposal)
+		if !ok {
+			return xerrors.ErrInvalidTrxPayloadType
+		}
+		if !ctx.Tx.Amount.IsZero() {
+			return xerrors.ErrInvalidAmount.Wrapf("the amount of TRX_CANCEL_PROPOSAL should be 0")
+		}
+
+		item, xerr := ctrler.govState.Get(v1.LedgerKeyProposal(txpayload.TxHash), ctx.Exec)
+		if xerr != nil {
+			if xerr.Contains(xerrors.ErrNotFoundResult) {
+				return xerrors.ErrNotFoundProposal
+			}
+			return xerr
+		}
+		prop, _ := item.(*proposal.GovProposal)
+		if !bytes.Equal(prop.Proposer(), ctx.Tx.From) {
+			return xerrors.ErrNoRight
+		}
+		if ctx.Height() > prop.Header().EndVotingHeight {
+			return xerrors.ErrNotVotingPeriod.Wrapf("the voting of the proposal has ended")
+		}
+		if inVoting(prop, ctx.Height()) {
+			if xerr := ctx.Sender.CheckBalance(ctrler.CancelPenalty()); xerr != nil {
+				return xerr
+			}
+		}
 	default:
 		return xerrors.ErrUnknownTrxType
 	}
@@ -189,6 +275,10 @@ func (ctrler *GovCtrler) ExecuteTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 		return ctrler.execProposing(ctx)
 	case ctrlertypes.TRX_VOTING:
 		return ctrler.execVoting(ctx)
+	case ctrlertypes.TRX_DEPOSIT:
+		return ctrler.execDeposit(ctx)
+	case ctrlertypes.TRX_CANCEL_PROPOSAL:
+		return ctrler.execCancel(ctx)
 	default:
 		return xerrors.ErrUnknownTrxType
 	}
@@ -210,6 +300,9 @@ func (ctrler *GovCtrler) execProposing(ctx *ctrlertypes.TrxContext) xerrors.XErr
 	prop := proposal.NewGovProposal(txpayload.OptType, ctx.TxHash,
 		txpayload.StartVotingHeight, txpayload.VotingPeriodBlocks,
 		totalVotingPower, txpayload.ApplyingHeight)
+	// the result is decided with the rates at the time of proposing.
+	prop.SetTallyRates(ctrler.QuorumRate(), ctrler.PassThresholdRate(), ctrler.VetoThresholdRate())
+	prop.SetDepositRule(ctx.Tx.From, ctrler.MinDeposit(), ctx.Height()+ctrler.MaxDepositPeriodBlocks())
 
 	for _, v := range voters {
 		prop.AddVoter(v.Address, v.Power)
@@ -217,6 +310,13 @@ func (ctrler *GovCtrler) execProposing(ctx *ctrlertypes.TrxContext) xerrors.XErr
 	for _, opt := range txpayload.Options {
 		prop.AddOption(opt)
 	}
+	if !ctx.Tx.Amount.IsZero() {
+		// the initial deposit
+		if xerr := depositTo(ctx); xerr != nil {
+			return xerr
+		}
+		_ = prop.AddDeposit(ctx.Tx.From, ctx.Tx.Amount, ctx.Height())
+	}
 	if xerr := ctrler.govState.Set(v1.LedgerKeyProposal(prop.Header().TxHash), prop, ctx.Exec); xerr != nil {
 		return xerr
 	}
@@ -231,6 +331,12 @@ func (ctrler *GovCtrler) execVoting(ctx *ctrlertypes.TrxContext) xerrors.XError
 		return xerr
 	}
 	prop, _ := item.(*proposal.GovProposal)
+
+	// the delegator takes over its power from the validators,
+	// and its choice overrides the validators' choice.
+	for _, dg := range delegationsToVoters(prop, ctx.Tx.From, ctx.VPowerHandler) {
+		prop.AddDelegatorVoter(ctx.Tx.From, dg.to, dg.power)
+	}
 	if xerr = prop.DoVote(ctx.Tx.From, txpayload.Choice); xerr != nil {
 		return xerr
 	}
@@ -243,11 +349,148 @@ func (ctrler *GovCtrler) execVoting(ctx *ctrlertypes.TrxContext) xerrors.XError
 	return nil
 }
 
+func (ctrler *GovCtrler) execDeposit(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	txpayload, _ := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadDeposit)
+	item, xerr := ctrler.govState.Get(v1.LedgerKeyProposal(txpayload.TxHash), ctx.Exec)
+	if xerr != nil {
+		return xerr
+	}
+	prop, _ := item.(*proposal.GovProposal)
+
+	if xerr := depositTo(ctx); xerr != nil {
+		return xerr
+	}
+	if prop.AddDeposit(ctx.Tx.From, ctx.Tx.Amount, ctx.Height()) {
+		ctrler.logger.Debug("Proposal reaches the minimum deposit", "key", prop.Header().TxHash,
+			"startVotingHeight", prop.Header().StartVotingHeight)
+	}
+	return ctrler.govState.Set(v1.LedgerKeyProposal(prop.Header().TxHash), prop, ctx.Exec)
+}
+
+// execCancel removes the proposal and refunds its deposits.
+// If the proposal is being voted, the proposer pays GovParams.CancelPenalty, which is transferred to the dead address.
+func (ctrler *GovCtrler) execCancel(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	txpayload, _ := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadCancelProposal)
+
+	item, xerr := ctrler.govState.Get(v1.LedgerKeyProposal(txpayload.TxHash), ctx.Exec)
+	if xerr != nil {
+		return xerr
+	}
+	prop, _ := item.(*proposal.GovProposal)
+
+	penalty := uint256.NewInt(0)
+	if inVoting(prop, ctx.Height()) {
+		penalty = ctrler.CancelPenalty()
+	}
+	if !penalty.IsZero() {
+		if xerr := ctx.Sender.SubBalance(penalty); xerr != nil {
+			return xerr
+		}
+		if xerr := addBalance(ctx, ctrler.DeadAddress(), penalty); xerr != nil {
+			return xerr
+		}
+	}
+
+	for _, d := range prop.Deposits() {
+		if xerr := ctx.AcctHandler.SubBalance(types.DepositAddress(), d.Amount(), ctx.Exec); xerr != nil {
+			return xerr
+		}
+		if xerr := addBalance(ctx, d.Depositor, d.Amount()); xerr != nil {
+			return xerr
+		}
+	}
+
+	if xerr := ctrler.govState.Del(v1.LedgerKeyProposal(txpayload.TxHash), ctx.Exec); xerr != nil {
+		return xerr
+	}
+
+	ctx.Events = append(ctx.Events, abcitypes.Event{
+		Type: "proposal",
+		Attributes: []abcitypes.EventAttribute{
+			{Key: []byte("cancelled"), Value: []byte(hex.EncodeToString(txpayload.TxHash)), Index: true},
+			{Key: []byte("penalty"), Value: []byte(penalty.Dec()), Index: false},
+		},
+	})
+	return nil
+}
+
+// inVoting returns true if `prop` has finished its deposit period and is being voted at `height`.
+func inVoting(prop *proposal.GovProposal, height int64) bool {
+	return !prop.IsDepositPeriod() &&
+		height >= prop.Header().StartVotingHeight &&
+		height <= prop.Header().EndVotingHeight
+}
+
+// depositTo moves the tx amount from the sender to the deposit account.
+func depositTo(ctx *ctrlertypes.TrxContext) xerrors.XError {
+	if xerr := ctx.Sender.SubBalance(ctx.Tx.Amount); xerr != nil {
+		return xerr
+	}
+	return addBalance(ctx, types.DepositAddress(), ctx.Tx.Amount)
+}
+
+// addBalance credits `amt` to `addr` during the tx.
+// The accounts of the sender and the payer are updated in ctx.Sender and ctx.Payer, not in the ledger directly,
+// because they are written to the ledger after the tx and would overwrite the credit.
+func addBalance(ctx *ctrlertypes.TrxContext, addr types.Address, amt *uint256.Int) xerrors.XError {
+	switch {
+	case bytes.Equal(addr, ctx.Sender.Address):
+		return ctx.Sender.AddBalance(amt)
+	case ctx.Payer != nil && bytes.Equal(addr, ctx.Payer.Address):
+		return ctx.Payer.AddBalance(amt)
+	default:
+		return ctx.AcctHandler.AddBalance(addr, amt, ctx.Exec)
+	}
+}
+
+// settleDeposits refunds the deposits of `prop` to the depositors, or burns them if `burn` is true.
+func (ctrler *GovCtrler) settleDeposits(prop *proposal.GovProposal, burn bool, acctHandler ctrlertypes.IAccountHandler) {
+	if acctHandler == nil {
+		return
+	}
+	for _, d := range prop.Deposits() {
+		to := types.Address(d.Depositor)
+		if burn {
+			to = ctrler.DeadAddress()
+		}
+		if xerr := acctHandler.SubBalance(types.DepositAddress(), d.Amount(), true); xerr != nil {
+			ctrler.logger.Error("Settle deposit", "key", prop.Header().TxHash, "depositor", d.Depositor, "error", xerr)
+			continue
+		}
+		_ = acctHandler.AddBalance(to, d.Amount(), true)
+	}
+}
+
+type delegation struct {
+	to    types.Address
+	power int64
+}
+
+// delegationsToVoters returns the powers delegated from `addr` to the validator voters of `prop`.
+// The self-bonding power of `addr` is not included.
+func delegationsToVoters(prop *proposal.GovProposal, addr types.Address, stakeHandler ctrlertypes.IStakeHandler) []*delegation {
+	var ret []*delegation
+	for _, voter := range prop.Header().Voters {
+		if voter.IsDelegatorVoter() || bytes.Equal(voter.Address, addr) {
+			continue
+		}
+		if power := stakeHandler.VPowerOf(addr, voter.Address); power > 0 {
+			ret = append(ret, &delegation{to: voter.Address, power: power})
+		}
+	}
+	return ret
+}
+
 // freezeProposals is called from EndBlock
-func (ctrler *GovCtrler) freezeProposals(height int64) ([]v1.LedgerKey, []v1.LedgerKey, xerrors.XError) {
+// If `powerSrc` is not nil, the powers of voters are updated with it before the major option is decided.
+// The proposal is frozen with its tally result whether it has passed or not,
+// so that the result can be queried until the applying height.
+// The deposits are burned if the proposal is vetoed or does not reach the quorum, otherwise refunded.
+// The proposal not reaching the minimum deposit until its deposit end height is removed and its deposits are refunded.
+func (ctrler *GovCtrler) freezeProposals(height int64, powerSrc proposal.IPowerSource, acctHandler ctrlertypes.IAccountHandler) ([]*proposal.GovProposal, []v1.LedgerKey, xerrors.XError) {
 	var frozenProps []v1.LedgerKey
-	var removedProps []v1.LedgerKey
 	var newFrozens []*proposal.GovProposal
+	var expiredProps []v1.LedgerKey
 
 	defer func() {
 		for _, _prop := range newFrozens {
@@ -258,47 +501,69 @@ func (ctrler *GovCtrler) freezeProposals(height int64) ([]v1.LedgerKey, []v1.Led
 			// remove frozen proposal
 			_ = ctrler.govState.Del(k, true)
 		}
-		for _, k := range removedProps {
-			// remove proposal
+		for _, k := range expiredProps {
 			_ = ctrler.govState.Del(k, true)
 		}
 	}()
 
 	xerr := ctrler.govState.Seek(v1.KeyPrefixProposal, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
 		prop, _ := item.(*proposal.GovProposal)
-		if prop.Header().EndVotingHeight < height {
+		if prop.IsDepositPeriod() {
+			if prop.Header().DepositEndHeight < height {
+				ctrler.logger.Debug("Remove proposal", "key(txHash)", prop.Header().TxHash, "reason", "not enough deposit")
+				ctrler.settleDeposits(prop, false, acctHandler)
+				expiredProps = append(expiredProps, key)
+			}
+		} else if prop.Header().EndVotingHeight < height {
 
 			// DO NOT REMOVE `prop` from `proposalState`
 
-			majorOpt := prop.UpdateMajorOption()
-			if majorOpt != nil {
-				// freeze the proposal
-				newFrozens = append(newFrozens, prop)
-				frozenProps = append(frozenProps, key)
-			} else {
-				// do nothing. the proposal will be just removedProps.
-				ctrler.logger.Debug("Freeze proposal", "warning", "not found major option")
-				removedProps = append(removedProps, key)
+			if powerSrc != nil {
+				// exclude the powers unbonded during the voting period.
+				prop.UpdatePowers(powerSrc)
+			}
+			if majorOpt := prop.UpdateMajorOption(); majorOpt == nil {
+				ctrler.logger.Debug("Freeze proposal", "warning", "not found major option",
+					"result", proposal.TallyStatusName(prop.Result().GetStatus()))
 			}
+			result := prop.Result()
+			ctrler.settleDeposits(prop, result.IsVetoed() || result.GetStatus() == proposal.TALLY_NO_QUORUM, acctHandler)
+
+			// freeze the proposal
+			newFrozens = append(newFrozens, prop)
+			frozenProps = append(frozenProps, key)
 		}
 		return nil
 	}, true)
-	return frozenProps, removedProps, xerr
+	return newFrozens, expiredProps, xerr
 }
 
 // applyProposals is called from EndBlock
-func (ctrler *GovCtrler) applyProposals(height int64) ([]v1.LedgerKey, xerrors.XError) {
+// The proposals which have failed are removed without being applied.
+func (ctrler *GovCtrler) applyProposals(height int64) ([]v1.LedgerKey, []v1.LedgerKey, xerrors.XError) {
 	var applied []v1.LedgerKey
+	var removed []v1.LedgerKey
+	var payouts []*proposal.TreasuryPayout
+	var allowListChanges []*proposal.AllowListChange
 
 	defer func() {
 		if ctrler.newGovParams != nil {
 			_ = ctrler.govState.Set(v1.LedgerKeyGovParams(), ctrler.newGovParams, true)
 		}
+		for _, payout := range payouts {
+			_ = ctrler.govState.Set(v1.LedgerKeyTreasuryPayout(payout.TxHash()), payout, true)
+		}
+		for _, change := range allowListChanges {
+			ctrler.changeAllowList(change)
+		}
 
 		for _, k := range applied {
 			// remove
 			_ = ctrler.govState.Del(k, true)
 		}
+		for _, k := range removed {
+			_ = ctrler.govState.Del(k, true)
+		}
 	}()
 
 	xerr := ctrler.govState.Seek(v1.KeyPrefixFrozenProp, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
@@ -308,22 +573,40 @@ func (ctrler *GovCtrler) applyProposals(height int64) ([]v1.LedgerKey, xerrors.X
 			// DO NOT REMOVE `prop` from `frozenState` in Seek.
 
 			if prop.MajorOption() == nil {
-				// not reachable.
-				ctrler.logger.Error("Apply proposal", "error", "major option is nil")
+				// the proposal has failed.
+				ctrler.logger.Debug("Remove proposal", "key(txHash)", prop.Header().TxHash,
+					"result", proposal.TallyStatusName(prop.Result().GetStatus()))
+				removed = append(removed, key) // this key will be removed from frozenState
+				return nil
 			}
 
 			switch prop.Header().PropType {
 			case proposal.PROPOSAL_GOVPARAMS:
-				newGovParams := &ctrlertypes.GovParams{}
-
-				strOpt := string(prop.MajorOption().Option)
-				if err := jsonx.Unmarshal([]byte(strOpt), newGovParams); err != nil {
-					ctrler.logger.Error("Apply proposal", "error", err, "option", string(prop.MajorOption().Option))
-					return xerrors.From(err)
+				newGovParams, xerr := proposedGovParams(&ctrler.GovParams, prop.MajorOption().Option, height)
+				if xerr != nil {
+					// the parameters may become invalid by other proposals applied after this proposal was submitted.
+					ctrler.logger.Error("Apply proposal", "error", xerr, "option", string(prop.MajorOption().Option))
+					removed = append(removed, key) // this key will be removed from frozenState
+					return nil
 				}
-
-				ctrlertypes.MergeGovParams(&ctrler.GovParams, newGovParams)
 				ctrler.newGovParams = newGovParams
+			case proposal.PROPOSAL_TREASURY_SPEND:
+				ts, xerr := proposal.DecodeTreasurySpend(prop.MajorOption().Option)
+				if xerr != nil {
+					ctrler.logger.Error("Apply proposal", "error", xerr, "option", string(prop.MajorOption().Option))
+					removed = append(removed, key) // this key will be removed from frozenState
+					return nil
+				}
+				// the first instalment is paid at this height.
+				payouts = append(payouts, proposal.NewTreasuryPayout(prop.Header().TxHash, ts, height))
+			case proposal.PROPOSAL_ALLOW_LIST:
+				change, xerr := proposal.DecodeAllowListChange(prop.MajorOption().Option)
+				if xerr != nil {
+					ctrler.logger.Error("Apply proposal", "error", xerr, "option", string(prop.MajorOption().Option))
+					removed = append(removed, key) // this key will be removed from frozenState
+					return nil
+				}
+				allowListChanges = append(allowListChanges, change)
 			default:
 				ctrler.logger.Debug("Apply proposal", "key(txHash)", prop.Header().TxHash, "type", prop.Header().PropType)
 			}
@@ -334,7 +617,123 @@ func (ctrler *GovCtrler) applyProposals(height int64) ([]v1.LedgerKey, xerrors.X
 		return nil
 	}, true)
 
-	return applied, xerr
+	return applied, removed, xerr
+}
+
+// proposedGovParams returns the parameters made by merging `option` into `current`.
+// The fields present in `option` override the current ones even if they are 0.
+// It returns an error if `option` is not GovParams JSON or the merged parameters are invalid.
+// The new parameters are used from the next block of `applyHeight`,
+// so the EVM forks in them can not be changed at or below `applyHeight`.
+func proposedGovParams(current *ctrlertypes.GovParams, option []byte, applyHeight int64) (*ctrlertypes.GovParams, xerrors.XError) {
+	newGovParams, xerr := ctrlertypes.MergeGovParamsJSON(current, option)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if xerr := newGovParams.Validate(); xerr != nil {
+		return nil, xerr
+	}
+	if xerr := ctrlertypes.ValidateEVMForksChange(current, newGovParams, applyHeight); xerr != nil {
+		return nil, xerr
+	}
+	return newGovParams, nil
+}
+
+// changeAllowList applies `change` to the allow-list of `change.Target`.
+// It is called from applyProposals after seeking the proposals.
+func (ctrler *GovCtrler) changeAllowList(change *proposal.AllowListChange) {
+	key := v1.LedgerKeyAllowList(change.Target)
+	if change.Unrestrict {
+		_ = ctrler.govState.Del(key, true)
+		return
+	}
+
+	list := proposal.NewAllowList()
+	if item, xerr := ctrler.govState.Get(key, true); xerr == nil {
+		list, _ = item.(*proposal.AllowList)
+	}
+	list.Add(change.Add...)
+	list.Remove(change.Remove...)
+	_ = ctrler.govState.Set(key, list, true)
+}
+
+// IsAllowedDeployer returns true if `addr` can deploy a contract.
+// Everyone can deploy unless the allow-list of the deployers exists (permissioned deployer mode).
+func (ctrler *GovCtrler) IsAllowedDeployer(addr types.Address, exec bool) bool {
+	return ctrler.isAllowed(types.ZeroAddress(), addr, exec)
+}
+
+// IsAllowedCaller returns true if `caller` can call the contract `contract` by a tx.
+// Everyone can call the contract unless the allow-list of its callers exists.
+func (ctrler *GovCtrler) IsAllowedCaller(contract, caller types.Address, exec bool) bool {
+	return ctrler.isAllowed(contract, caller, exec)
+}
+
+func (ctrler *GovCtrler) isAllowed(target, addr types.Address, exec bool) bool {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	item, xerr := ctrler.govState.Get(v1.LedgerKeyAllowList(target), exec)
+	if xerr != nil {
+		// `target` is not restricted if it has no allow-list.
+		return xerr.Contains(xerrors.ErrNotFoundResult)
+	}
+	list, _ := item.(*proposal.AllowList)
+	return list.Contains(addr)
+}
+
+type treasuryPayment struct {
+	payout *proposal.TreasuryPayout
+	amt    *uint256.Int
+}
+
+// payTreasury is called from EndBlock after applyProposals.
+// It transfers the instalments scheduled at or before `height` from the treasury to the recipients.
+// If the treasury does not have enough balance, the instalment is postponed to the next block.
+func (ctrler *GovCtrler) payTreasury(height int64, acctHandler ctrlertypes.IAccountHandler) ([]*treasuryPayment, xerrors.XError) {
+	if acctHandler == nil {
+		return nil, nil
+	}
+
+	var payments []*treasuryPayment
+	var done []v1.LedgerKey
+
+	xerr := ctrler.govState.Seek(v1.KeyPrefixTreasuryPayout, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		payout, _ := item.(*proposal.TreasuryPayout)
+		if payout.NextHeight() > height {
+			return nil
+		}
+
+		amt := payout.NextInstalment()
+		if xerr := acctHandler.Transfer(types.TreasuryAddress(), payout.Recipient(), amt, true); xerr != nil {
+			ctrler.logger.Error("Pay treasury", "key(txHash)", payout.TxHash(), "recipient", payout.Recipient(),
+				"amount", amt.Dec(), "error", xerr)
+			return nil
+		}
+		if payout.Paid(amt) {
+			done = append(done, key)
+		}
+		payments = append(payments, &treasuryPayment{payout: payout, amt: amt})
+		return nil
+	}, true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// DO NOT UPDATE `govState` in Seek.
+	for _, k := range done {
+		if xerr := ctrler.govState.Del(k, true); xerr != nil {
+			return nil, xerr
+		}
+	}
+	for _, p := range payments {
+		if p.payout.Instalments() > 0 {
+			if xerr := ctrler.govState.Set(v1.LedgerKeyTreasuryPayout(p.payout.TxHash()), p.payout, true); xerr != nil {
+				return nil, xerr
+			}
+		}
+	}
+	return payments, nil
 }
 
 func (ctrler *GovCtrler) Close() xerrors.XError {
@@ -389,3 +788,4 @@ var _ ctrlertypes.ILedgerHandler = (*GovCtrler)(nil)
 var _ ctrlertypes.ITrxHandler = (*GovCtrler)(nil)
 var _ ctrlertypes.IBlockHandler = (*GovCtrler)(nil)
 var _ ctrlertypes.IGovParams = (*GovCtrler)(nil)
+var _ ctrlertypes.IGovHandler = (*GovCtrler)(nil)
@@ -0,0 +1,129 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	"github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/genesis"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	btztypes "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func Test_AllowList(t *testing.T) {
+	f := newGovFixture(t, "gov-allow-list-test", 3, nil)
+	deployer0, deployer1 := acctMock.GetWallet(40).Address(), acctMock.GetWallet(41).Address()
+	contract := btztypes.RandAddress()
+
+	// permissioned deployer mode at genesis
+	require.NoError(t, f.ctrler.InitLedger(&genesis.GenesisAppState{
+		GovParams:          types.DefaultGovParams(),
+		PermissionedDeploy: true,
+		AllowedDeployers:   []btztypes.Address{deployer0},
+	}))
+	ver := f.commit()
+	height := int64(1)
+
+	require.True(t, f.ctrler.IsAllowedDeployer(deployer0, true))
+	require.False(t, f.ctrler.IsAllowedDeployer(deployer1, true))
+	require.True(t, f.ctrler.IsAllowedCaller(contract, deployer1, true))
+
+	propose := func(option string, height int64) (bytes.HexBytes, xerrors.XError) {
+		return f.propose(f.vpow.PickAddress(0), height+1, height, proposal.PROPOSAL_ALLOW_LIST, []byte(option))
+	}
+	// passProposals votes for the proposals and returns their applying height and the last version of the ledger.
+	passProposals := func(txhashes ...bytes.HexBytes) (int64, int64) {
+		for _, txhash := range txhashes {
+			f.voteAll(txhash, 0)
+		}
+		f.commit()
+
+		prop, xerr := f.ctrler.ReadProposal(txhashes[len(txhashes)-1], true)
+		require.NoError(t, xerr)
+		f.endBlock(prop.Header().EndVotingHeight + 1)
+		f.endBlock(prop.Header().ApplyHeight)
+		return prop.Header().ApplyHeight, f.ctrler.govState.Version()
+	}
+	queryAllowList := func(target btztypes.Address, ver int64) (bool, []btztypes.Address) {
+		var resp struct {
+			Target     btztypes.Address   `json:"target"`
+			Restricted bool               `json:"restricted"`
+			Accounts   []btztypes.Address `json:"accounts"`
+		}
+		bz, xerr := f.ctrler.Query(abcitypes.RequestQuery{Path: "allow_list", Data: target, Height: ver})
+		require.NoError(t, xerr)
+		require.NoError(t, jsonx.Unmarshal(bz, &resp))
+		require.Equal(t, target, resp.Target)
+		return resp.Restricted, resp.Accounts
+	}
+
+	restricted, accounts := queryAllowList(btztypes.ZeroAddress(), ver)
+	require.True(t, restricted)
+	require.Equal(t, []btztypes.Address{deployer0}, accounts)
+
+	//
+	// wrong options
+	for _, opt := range []string{
+		`{"add":["` + deployer1.String() + `"]}`,
+		`{"target":"` + contract.String() + `","add":["` + deployer1.String() + `"],"unrestrict":true}`,
+	} {
+		_, xerr := propose(opt, height)
+		require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxPayloadParams.Error(), opt)
+	}
+
+	//
+	// replace the deployer and restrict the callers of `contract`.
+	txhash0, xerr := propose(`{"target":"`+btztypes.ZeroAddress().String()+`","add":["`+deployer1.String()+`"],"remove":["`+deployer0.String()+`"]}`, height)
+	require.NoError(t, xerr)
+	txhash1, xerr := propose(`{"target":"`+contract.String()+`","add":["`+deployer0.String()+`"]}`, height)
+	require.NoError(t, xerr)
+	height, ver = passProposals(txhash0, txhash1)
+
+	require.False(t, f.ctrler.IsAllowedDeployer(deployer0, true))
+	require.True(t, f.ctrler.IsAllowedDeployer(deployer1, true))
+	require.True(t, f.ctrler.IsAllowedCaller(contract, deployer0, true))
+	require.False(t, f.ctrler.IsAllowedCaller(contract, deployer1, true))
+	require.True(t, f.ctrler.IsAllowedCaller(btztypes.RandAddress(), deployer1, true))
+
+	restricted, accounts = queryAllowList(contract, ver)
+	require.True(t, restricted)
+	require.Equal(t, []btztypes.Address{deployer0}, accounts)
+
+	//
+	// turn off the permissioned deployer mode.
+	txhash2, xerr := propose(`{"target":"`+btztypes.ZeroAddress().String()+`","unrestrict":true}`, height)
+	require.NoError(t, xerr)
+	_, ver = passProposals(txhash2)
+
+	require.True(t, f.ctrler.IsAllowedDeployer(deployer0, true))
+	restricted, accounts = queryAllowList(btztypes.ZeroAddress(), ver)
+	require.False(t, restricted)
+	require.Empty(t, accounts)
+}
+
+func Test_AllowList_InvalidOption(t *testing.T) {
+	f := newGovFixture(t, "gov-allow-list-invalid-test", 3, nil)
+	deployer := acctMock.GetWallet(40).Address()
+
+	txhash, xerr := f.propose(f.vpow.PickAddress(0), 2, 1, proposal.PROPOSAL_ALLOW_LIST,
+		[]byte(`{"target":"`+btztypes.ZeroAddress().String()+`","add":["`+deployer.String()+`"]}`))
+	require.NoError(t, xerr)
+	prop, xerr := f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	f.voteAll(txhash, 0)
+	f.commit()
+	f.endBlock(prop.Header().EndVotingHeight + 1)
+
+	// the option that can not be decoded is dropped without stopping the block.
+	f.setFrozenOption(txhash, []byte(`{"target":`))
+	f.endBlock(prop.Header().ApplyHeight)
+	require.True(t, f.ctrler.IsAllowedDeployer(btztypes.RandAddress(), true))
+
+	_, xerr = f.ctrler.govState.Get(v1.LedgerKeyFrozenProp(txhash), true)
+	require.Equal(t, xerrors.ErrNotFoundResult, xerr)
+}
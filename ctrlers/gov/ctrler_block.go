@@ -2,6 +2,7 @@ package gov
 
 import (
 	"encoding/hex"
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
 	"github.com/beatoz/beatoz-go/ctrlers/types"
 	"github.com/beatoz/beatoz-go/ledger/v1"
 	types3 "github.com/beatoz/beatoz-go/types"
@@ -44,21 +45,28 @@ func (ctrler *GovCtrler) EndBlock(ctx *types.BlockContext) ([]types2.Event, xerr
 
 	var evts []types2.Event
 
-	frozen, removed, xerr := ctrler.freezeProposals(ctx.Height())
+	frozen, expired, xerr := ctrler.freezeProposals(ctx.Height(), ctx.VPowerHandler, ctx.AcctHandler)
 	if xerr != nil {
 		return nil, xerr
 	}
 
-	applied, xerr := ctrler.applyProposals(ctx.Height())
+	applied, removed, xerr := ctrler.applyProposals(ctx.Height())
 	if xerr != nil {
 		return nil, xerr
 	}
+	removed = append(expired, removed...)
 
-	for _, k := range frozen {
+	payments, xerr := ctrler.payTreasury(ctx.Height(), ctx.AcctHandler)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	for _, prop := range frozen {
 		evts = append(evts, types2.Event{
 			Type: "proposal",
 			Attributes: []types2.EventAttribute{
-				{Key: []byte("frozen"), Value: []byte(hex.EncodeToString(v1.UnwrapKeyPrefix(k))), Index: true},
+				{Key: []byte("frozen"), Value: []byte(hex.EncodeToString(prop.Header().TxHash)), Index: true},
+				{Key: []byte("result"), Value: []byte(proposal.TallyStatusName(prop.Result().GetStatus())), Index: false},
 			},
 		})
 	}
@@ -78,6 +86,18 @@ func (ctrler *GovCtrler) EndBlock(ctx *types.BlockContext) ([]types2.Event, xerr
 			},
 		})
 	}
+	for _, p := range payments {
+		evts = append(evts, types2.Event{
+			Type: "treasury.payout",
+			Attributes: []types2.EventAttribute{
+				{Key: []byte("proposal"), Value: []byte(hex.EncodeToString(p.payout.TxHash())), Index: true},
+				{Key: []byte("recipient"), Value: []byte(p.payout.Recipient().String()), Index: true},
+				{Key: []byte("amount"), Value: []byte(p.amt.Dec()), Index: false},
+				{Key: []byte("remaining"), Value: []byte(p.payout.Amount().Dec()), Index: false},
+				{Key: []byte("instalments"), Value: []byte(strconv.FormatInt(p.payout.Instalments(), 10)), Index: false},
+			},
+		})
+	}
 
 	return evts, nil
 }
@@ -0,0 +1,123 @@
+package gov
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	"github.com/beatoz/beatoz-go/ctrlers/types"
+	btztypes "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func Test_CancelProposal(t *testing.T) {
+	f := newGovFixture(t, "gov-proposal-cancel-test", 3, func(v *types.GovParamsProto) {
+		v.XMinDeposit = uint256.NewInt(1000).Bytes()
+		v.XCancelPenalty = uint256.NewInt(50).Bytes()
+	})
+	proposer, depositor := acctMock.GetWallet(50).Address(), acctMock.GetWallet(51).Address()
+
+	propose := func(from btztypes.Address, amt uint64, height int64) bytes.HexBytes {
+		tx := f.proposalTx(from, height+10, proposal.PROPOSAL_COMMON, []byte("yes"), []byte("no"))
+		tx.Amount = uint256.NewInt(amt)
+		txctx, xerr := f.runTx(tx, height)
+		require.NoError(t, xerr)
+		return txctx.TxHash
+	}
+	cancel := func(from btztypes.Address, txhash bytes.HexBytes, height int64) ([]abcitypes.Event, xerrors.XError) {
+		tx := types.NewTrx(1, from, btztypes.ZeroAddress(), 1, defMinGas, defGasPrice, uint256.NewInt(0),
+			&types.TrxPayloadCancelProposal{TxHash: txhash})
+		txctx, xerr := f.runTx(tx, height)
+		if xerr != nil {
+			return nil, xerr
+		}
+		return txctx.Events, nil
+	}
+
+	proposerBalance, depositorBalance := balanceOf(proposer), balanceOf(depositor)
+	depositBalance, deadBalance := balanceOf(btztypes.DepositAddress()), balanceOf(f.ctrler.DeadAddress())
+
+	//
+	// cancel the proposal in the deposit period.
+	txhash := propose(proposer, 400, 1)
+	deposit := types.NewTrx(1, depositor, btztypes.ZeroAddress(), 1, defMinGas, defGasPrice, uint256.NewInt(100),
+		&types.TrxPayloadDeposit{TxHash: txhash})
+	_, xerr := f.runTx(deposit, 2)
+	require.NoError(t, xerr)
+	require.Equal(t, depositBalance+500, balanceOf(btztypes.DepositAddress()))
+
+	// only the proposer can cancel it.
+	_, xerr = cancel(depositor, txhash, 3)
+	require.Equal(t, xerrors.ErrNoRight, xerr)
+
+	evts, xerr := cancel(proposer, txhash, 3)
+	require.NoError(t, xerr)
+	require.Len(t, evts, 1)
+	require.Equal(t, "proposal", evts[0].Type)
+	require.Equal(t, "cancelled", string(evts[0].Attributes[0].Key))
+	require.Equal(t, hex.EncodeToString(txhash), string(evts[0].Attributes[0].Value))
+	require.Equal(t, "0", string(evts[0].Attributes[1].Value))
+
+	// the deposits are refunded without penalty.
+	require.Equal(t, proposerBalance, balanceOf(proposer))
+	require.Equal(t, depositorBalance, balanceOf(depositor))
+	require.Equal(t, depositBalance, balanceOf(btztypes.DepositAddress()))
+	_, xerr = f.ctrler.ReadProposal(txhash, true)
+	require.Equal(t, xerrors.ErrNotFoundProposal, xerr)
+
+	_, xerr = cancel(proposer, txhash, 4)
+	require.Equal(t, xerrors.ErrNotFoundProposal, xerr)
+
+	//
+	// cancel the proposal in the voting period with the penalty.
+	txhash = propose(proposer, 1000, 10)
+	prop, xerr := f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	require.False(t, prop.IsDepositPeriod())
+
+	// not ended voting only
+	_, xerr = cancel(proposer, txhash, prop.Header().EndVotingHeight+1)
+	require.ErrorContains(t, xerr, xerrors.ErrNotVotingPeriod.Error())
+
+	evts, xerr = cancel(proposer, txhash, prop.Header().StartVotingHeight)
+	require.NoError(t, xerr)
+	require.Equal(t, "50", string(evts[0].Attributes[1].Value))
+	require.Equal(t, proposerBalance-50, balanceOf(proposer))
+	require.Equal(t, deadBalance+50, balanceOf(f.ctrler.DeadAddress()))
+	require.Equal(t, depositBalance, balanceOf(btztypes.DepositAddress()))
+	_, xerr = f.ctrler.ReadProposal(txhash, true)
+	require.Equal(t, xerrors.ErrNotFoundProposal, xerr)
+}
+
+func Test_CancelProposal_PayerIsDepositor(t *testing.T) {
+	f := newGovFixture(t, "gov-proposal-cancel-payer-test", 3, func(v *types.GovParamsProto) {
+		v.XMinDeposit = uint256.NewInt(1000).Bytes()
+	})
+	proposer, depositor := acctMock.GetWallet(52).Address(), acctMock.GetWallet(53).Address()
+	proposerBalance, depositorBalance := balanceOf(proposer), balanceOf(depositor)
+
+	tx := f.proposalTx(proposer, 10, proposal.PROPOSAL_COMMON, []byte("yes"), []byte("no"))
+	tx.Amount = uint256.NewInt(400)
+	txctx, xerr := f.runTx(tx, 1)
+	require.NoError(t, xerr)
+	txhash := txctx.TxHash
+
+	deposit := types.NewTrx(1, depositor, btztypes.ZeroAddress(), 1, defMinGas, defGasPrice, uint256.NewInt(100),
+		&types.TrxPayloadDeposit{TxHash: txhash})
+	_, xerr = f.runTx(deposit, 2)
+	require.NoError(t, xerr)
+	require.Equal(t, depositorBalance-100, balanceOf(depositor))
+
+	// the depositor pays the fee of the cancel tx and gets its deposit back in the same tx.
+	cancel := types.NewTrx(1, proposer, btztypes.ZeroAddress(), 1, defMinGas, defGasPrice, uint256.NewInt(0),
+		&types.TrxPayloadCancelProposal{TxHash: txhash})
+	_, xerr = f.runTxPaidBy(cancel, depositor, 3)
+	require.NoError(t, xerr)
+
+	require.Equal(t, proposerBalance, balanceOf(proposer))
+	require.Equal(t, depositorBalance, balanceOf(depositor))
+}
@@ -0,0 +1,86 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	btztypes "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DelegatorVoting(t *testing.T) {
+	f := newGovFixture(t, "gov-delegator-voting-test", 3, nil)
+
+	val0, val1, val2 := f.vpow.PickAddress(0), f.vpow.PickAddress(1), f.vpow.PickAddress(2)
+	dgtor0, dgtor1, other := acctMock.GetWallet(3).Address(), acctMock.GetWallet(4).Address(), acctMock.GetWallet(5).Address()
+	f.vpow.Delegate(dgtor0, val0, 300)
+	f.vpow.Delegate(dgtor1, val0, 200)
+	f.vpow.Delegate(dgtor1, val1, 100)
+
+	//
+	// proposal
+	startHeight := int64(10)
+	txhash, xerr := f.propose(val0, startHeight, 1, proposal.PROPOSAL_COMMON, []byte("yes"), []byte("no"))
+	require.NoError(t, xerr)
+
+	vote := func(addr btztypes.Address, choice int32) xerrors.XError {
+		return f.vote(addr, txhash, choice, startHeight)
+	}
+	checkVotes := func(votes0, votes1 int64) *proposal.GovProposal {
+		prop, xerr := f.ctrler.ReadProposal(txhash, true)
+		require.NoError(t, xerr)
+		require.Equal(t, votes0, prop.Option(0).Votes)
+		require.Equal(t, votes1, prop.Option(1).Votes)
+		require.Equal(t, prop.Header().SumVotingPowers(), prop.Header().TotalVotingPower)
+		return prop
+	}
+
+	// only the validators are the voters at first.
+	prop := checkVotes(0, 0)
+	require.Len(t, prop.Header().Voters, 3)
+	require.Equal(t, int64(3600), prop.Header().TotalVotingPower)
+
+	// neither a validator nor a delegator
+	require.Equal(t, xerrors.ErrNoRight, vote(other, 0))
+
+	// the delegators inherit the validator's choice.
+	require.NoError(t, vote(val0, 0))
+	checkVotes(1500, 0)
+
+	// the delegator's power is subtracted from the validator's tally.
+	require.NoError(t, vote(dgtor0, 1))
+	checkVotes(1200, 300)
+	require.NoError(t, vote(dgtor1, 1))
+	prop = checkVotes(1000, 600)
+	require.Equal(t, int64(1000), prop.FindVoter(val1).Power)
+
+	// the validator votes with the power except for the delegator's power.
+	require.NoError(t, vote(val1, 0))
+	checkVotes(2000, 600)
+
+	// the delegator changes its choice.
+	require.NoError(t, vote(dgtor0, 0))
+	checkVotes(2300, 300)
+	require.NoError(t, vote(val2, 0))
+	checkVotes(3300, 300)
+	require.Len(t, prop.Header().Voters, 6)
+
+	f.commit()
+
+	//
+	// unbonding during the voting period
+	f.vpow.Delegate(dgtor1, val0, -200)
+	f.vpow.Delegate(val2, val2, -400)
+
+	f.endBlock(prop.Header().EndVotingHeight + 1)
+
+	item, xerr := f.ctrler.govState.Get(v1.LedgerKeyFrozenProp(txhash), false)
+	require.NoError(t, xerr)
+	frozenProp, _ := item.(*proposal.GovProposal)
+	require.Equal(t, int64(3000), frozenProp.Header().TotalVotingPower)
+	require.Equal(t, frozenProp.Header().SumVotingPowers(), frozenProp.Header().TotalVotingPower)
+	require.Equal(t, []byte("yes"), frozenProp.MajorOption().Option)
+	require.Equal(t, int64(2900), frozenProp.MajorOption().Votes)
+}
@@ -0,0 +1,122 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	"github.com/beatoz/beatoz-go/ctrlers/types"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	btztypes "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/beatoz/beatoz-sdk-go/web3"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProposalDeposit(t *testing.T) {
+	f := newGovFixture(t, "gov-proposal-deposit-test", 3, func(v *types.GovParamsProto) {
+		v.XMinDeposit = uint256.NewInt(1000).Bytes()
+		v.MaxDepositPeriodBlocks = 20
+	})
+	proposer, depositor := acctMock.GetWallet(30).Address(), acctMock.GetWallet(31).Address()
+
+	propose := func(from btztypes.Address, amt uint64, height int64) bytes.HexBytes {
+		tx := f.proposalTx(from, height+9, proposal.PROPOSAL_COMMON, []byte("yes"), []byte("no"))
+		tx.Amount = uint256.NewInt(amt)
+		txctx, xerr := f.runTx(tx, height)
+		require.NoError(t, xerr)
+		return txctx.TxHash
+	}
+	deposit := func(from btztypes.Address, txhash bytes.HexBytes, amt uint64, height int64) xerrors.XError {
+		tx := web3.NewTrxTransfer(from, btztypes.ZeroAddress(), 1, defMinGas, defGasPrice, uint256.NewInt(amt))
+		tx.Type = types.TRX_DEPOSIT
+		tx.Payload = &types.TrxPayloadDeposit{TxHash: txhash}
+		_, xerr := f.runTx(tx, height)
+		return xerr
+	}
+
+	proposerBalance, depositorBalance := balanceOf(proposer), balanceOf(depositor)
+	depositBalance, deadBalance := balanceOf(btztypes.DepositAddress()), balanceOf(f.ctrler.DeadAddress())
+
+	//
+	// a non-validator can propose with the deposit.
+	txhash := propose(proposer, 400, 1)
+	require.Equal(t, proposerBalance-400, balanceOf(proposer))
+	require.Equal(t, depositBalance+400, balanceOf(btztypes.DepositAddress()))
+
+	prop, xerr := f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	require.True(t, prop.IsDepositPeriod())
+	require.Equal(t, proposer, prop.Proposer())
+	require.Equal(t, int64(21), prop.Header().DepositEndHeight)
+	startHeight, endHeight, applyHeight := prop.Header().StartVotingHeight, prop.Header().EndVotingHeight, prop.Header().ApplyHeight
+
+	// it can not be voted in the deposit period.
+	require.Equal(t, xerrors.ErrNotVotingPeriod, f.vote(f.vpow.PickAddress(0), txhash, 0, startHeight))
+	require.ErrorContains(t, deposit(depositor, txhash, 0, startHeight), xerrors.ErrInvalidAmount.Error())
+
+	// reaching the minimum deposit after the voting start height, the voting period is postponed.
+	require.NoError(t, deposit(depositor, txhash, 600, startHeight+2))
+	require.Equal(t, depositorBalance-600, balanceOf(depositor))
+	require.Equal(t, depositBalance+1000, balanceOf(btztypes.DepositAddress()))
+	prop, xerr = f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	require.False(t, prop.IsDepositPeriod())
+	require.Equal(t, uint256.NewInt(1000), prop.TotalDeposit())
+	require.Equal(t, startHeight+3, prop.Header().StartVotingHeight)
+	require.Equal(t, endHeight+3, prop.Header().EndVotingHeight)
+	require.Equal(t, applyHeight+3, prop.Header().ApplyHeight)
+
+	// no more deposit is needed.
+	require.Error(t, deposit(depositor, txhash, 100, startHeight+3))
+
+	f.voteAll(txhash, 0)
+	f.commit()
+
+	// the deposits of the passed proposal are refunded.
+	require.Equal(t, []string{"frozen"}, f.endBlockKeys(endHeight+4))
+	require.Equal(t, proposerBalance, balanceOf(proposer))
+	require.Equal(t, depositorBalance, balanceOf(depositor))
+	require.Equal(t, depositBalance, balanceOf(btztypes.DepositAddress()))
+
+	//
+	// the proposal not reaching the minimum deposit is removed and its deposit is refunded.
+	height := endHeight + 5
+	txhash = propose(proposer, 100, height)
+	require.Equal(t, proposerBalance-100, balanceOf(proposer))
+	require.Empty(t, f.endBlockKeys(height+20))
+	require.Equal(t, []string{"removed"}, f.endBlockKeys(height+21))
+	require.Equal(t, proposerBalance, balanceOf(proposer))
+	_, xerr = f.ctrler.ReadProposal(txhash, true)
+	require.Equal(t, xerrors.ErrNotFoundProposal, xerr)
+
+	//
+	// the deposits of the proposal not reaching the quorum are burned.
+	height += 22
+	txhash = propose(depositor, 1000, height)
+	prop, xerr = f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	require.False(t, prop.IsDepositPeriod())
+	// the first proposal is also applied at this height.
+	require.Equal(t, []string{"frozen", "applied"}, f.endBlockKeys(prop.Header().EndVotingHeight+1))
+	item, xerr := f.ctrler.govState.Get(v1.LedgerKeyFrozenProp(txhash), false)
+	require.NoError(t, xerr)
+	require.Equal(t, proposal.TALLY_NO_QUORUM, item.(*proposal.GovProposal).Result().Status)
+	require.Equal(t, depositorBalance-1000, balanceOf(depositor))
+	require.Equal(t, deadBalance+1000, balanceOf(f.ctrler.DeadAddress()))
+	require.Equal(t, depositBalance, balanceOf(btztypes.DepositAddress()))
+
+	//
+	// a non-validator can not propose without the initial deposit.
+	_, xerr = f.propose(proposer, height+10, height+1, proposal.PROPOSAL_COMMON, []byte("yes"))
+	require.ErrorContains(t, xerr, xerrors.ErrNoRight.Error())
+
+	//
+	// if no deposit is required, only the validators can propose.
+	f.ctrler.GovParams.SetValue(func(v *types.GovParamsProto) {
+		v.XMinDeposit = nil
+	})
+	_, xerr = f.propose(proposer, height+10, height+1, proposal.PROPOSAL_COMMON, []byte("yes"))
+	require.Equal(t, xerrors.ErrNoRight, xerr)
+}
@@ -0,0 +1,118 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	"github.com/beatoz/beatoz-go/ctrlers/types"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func Test_ProposedGovParams_ZeroRates(t *testing.T) {
+	current := types.DefaultGovParams()
+	current.SetValue(func(v *types.GovParamsProto) {
+		v.TreasuryFeeRate = 100 - v.TxFeeRewardRate
+		v.TreasuryInflationRate = 10
+	})
+	require.NoError(t, current.Validate())
+
+	for _, c := range []struct {
+		option string
+		get    func(*types.GovParams) int32
+	}{
+		{`{"quorumRate":0}`, (*types.GovParams).QuorumRate},
+		{`{"vetoThresholdRate":0}`, (*types.GovParams).VetoThresholdRate},
+		{`{"treasuryFeeRate":0}`, (*types.GovParams).TreasuryFeeRate},
+		{`{"treasuryInflationRate":0}`, (*types.GovParams).TreasuryInflationRate},
+	} {
+		require.NotZero(t, c.get(current), c.option)
+		proposed, xerr := proposedGovParams(current, []byte(c.option), 1)
+		require.NoError(t, xerr, c.option)
+		require.Zero(t, c.get(proposed), c.option)
+	}
+}
+
+func Test_GovParamsProposalValidation(t *testing.T) {
+	f := newGovFixture(t, "gov-govparams-validation-test", 3, nil)
+	require.NoError(t, f.ctrler.govState.Set(v1.LedgerKeyGovParams(), &f.ctrler.GovParams, true))
+
+	propose := func(height int64, options ...string) (bytes.HexBytes, xerrors.XError) {
+		var opts [][]byte
+		for _, opt := range options {
+			opts = append(opts, []byte(opt))
+		}
+		return f.propose(f.vpow.PickAddress(0), height+1, height, proposal.PROPOSAL_GOVPARAMS, opts...)
+	}
+
+	//
+	// the invalid options are rejected at the proposal time.
+	for _, opt := range []string{
+		`{"maxValidatorCnt":-1}`,
+		`{"txFeeRewardRate":101}`,
+		`{"blockGasLimit":1000}`,              // less than minTrxGas
+		`{"minVotingPeriodBlocks":999999999}`, // greater than maxVotingPeriodBlocks
+		`{"txFeeRewardRate":90, "treasuryFeeRate":20}`,
+		`{"evmCancunHeight":100000000}`, // already activated
+		`{"evmPragueHeight":100000000}`, // not supported
+		`{"evmPragueHeight":-1}`,
+		`{"quorumrate":0}`, // misspelled
+		`not json`,
+	} {
+		_, xerr := propose(1, `{"maxValidatorCnt":30}`, opt)
+		require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxPayloadParams.Error(), opt)
+	}
+
+	//
+	// query the diff of each option.
+	txhash, xerr := propose(1, `{"maxValidatorCnt":30}`, `{"maxVotingPeriodBlocks":700000, "treasuryFeeRate":5}`)
+	require.NoError(t, xerr)
+	height := f.commit()
+
+	type optionDiff struct {
+		Option  int                    `json:"option"`
+		Error   string                 `json:"error"`
+		Changes []*types.GovParamsDiff `json:"changes"`
+	}
+	var resp struct {
+		TxHash  bytes.HexBytes `json:"txHash"`
+		Options []*optionDiff  `json:"options"`
+	}
+	bz, xerr := f.ctrler.Query(abcitypes.RequestQuery{Path: "proposal_diff", Data: txhash, Height: height})
+	require.NoError(t, xerr)
+	require.NoError(t, jsonx.Unmarshal(bz, &resp))
+	require.Equal(t, txhash, resp.TxHash)
+	require.Len(t, resp.Options, 2)
+	require.Empty(t, resp.Options[0].Error)
+	require.Len(t, resp.Options[0].Changes, 1)
+	require.Equal(t, "maxValidatorCnt", resp.Options[0].Changes[0].Field)
+	require.EqualValues(t, 21, resp.Options[0].Changes[0].Current)
+	require.EqualValues(t, 30, resp.Options[0].Changes[0].Proposed)
+	require.Len(t, resp.Options[1].Changes, 2)
+	require.Equal(t, "maxVotingPeriodBlocks", resp.Options[1].Changes[0].Field)
+	require.Equal(t, "treasuryFeeRate", resp.Options[1].Changes[1].Field)
+
+	_, xerr = f.ctrler.Query(abcitypes.RequestQuery{Path: "proposal_diff", Data: bytes.RandBytes(32), Height: height})
+	require.Error(t, xerr)
+
+	//
+	// the option which becomes invalid until the applying height is not applied.
+	prop, xerr := f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	f.voteAll(txhash, 1)
+	f.commit()
+
+	require.Equal(t, []string{"frozen"}, f.endBlockKeys(prop.Header().EndVotingHeight+1))
+
+	// `treasuryFeeRate` 5 is not allowed anymore with this `txFeeRewardRate`.
+	f.ctrler.GovParams.SetValue(func(v *types.GovParamsProto) {
+		v.TxFeeRewardRate = 100
+	})
+	require.Equal(t, []string{"removed"}, f.endBlockKeys(prop.Header().ApplyHeight))
+	require.Nil(t, f.ctrler.newGovParams)
+	require.Equal(t, int32(0), f.ctrler.TreasuryFeeRate())
+}
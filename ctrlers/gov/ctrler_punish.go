@@ -7,7 +7,8 @@ import (
 	"github.com/beatoz/beatoz-go/types/xerrors"
 )
 
-// doSlash slashes the voting power of the byzantine validator(voter).
+// doSlash slashes the voting power of the byzantine validator(voter)
+// and the powers which the delegator voters have taken over from it.
 // If the voter has already voted, it will be canceled.
 // This function is called from BeatozApp::BeginBlock.
 func (ctrler *GovCtrler) doSlash(targetAddr types.Address) (int64, xerrors.XError) {
@@ -22,8 +23,7 @@ func (ctrler *GovCtrler) doSlash(targetAddr types.Address) (int64, xerrors.XErro
 	_ = ctrler.govState.Seek(v1.KeyPrefixProposal, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
 		prop, _ := item.(*proposal.GovProposal)
 
-		if prop.FindVoter(targetAddr) != nil {
-			slash, _ := prop.DoSlash(targetAddr, ctrler.SlashRate())
+		if slash, xerr := prop.DoSlash(targetAddr, ctrler.SlashRate()); xerr == nil {
 			slashPower += slash
 			updatedProp = append(updatedProp, prop)
 		}
@@ -0,0 +1,60 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	btztypes "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/beatoz/beatoz-sdk-go/web3"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TallyResult(t *testing.T) {
+	f := newGovFixture(t, "gov-tally-result-test", 4, nil)
+
+	startHeight := int64(10)
+	txhash, xerr := f.propose(f.vpow.PickAddress(0), startHeight, 1, proposal.PROPOSAL_COMMON, []byte("yes"), []byte("no"))
+	require.NoError(t, xerr)
+
+	prop, xerr := f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	require.Equal(t, f.ctrler.QuorumRate(), prop.Header().QuorumRate)
+	require.Equal(t, f.ctrler.PassThresholdRate(), prop.Header().PassThresholdRate)
+	require.Equal(t, f.ctrler.VetoThresholdRate(), prop.Header().VetoThresholdRate)
+
+	// an out of range choice is rejected, but abstain and veto are allowed.
+	for i, choice := range []int32{0, proposal.VETO_CHOICE, proposal.VETO_CHOICE, proposal.ABSTAIN_CHOICE} {
+		addr := f.vpow.PickAddress(i)
+		_, xerr := f.runTx(web3.NewTrxVoting(addr, btztypes.ZeroAddress(), 1, defMinGas, defGasPrice, txhash, -4), startHeight)
+		require.Equal(t, xerrors.ErrInvalidTrxPayloadParams, xerr)
+		require.NoError(t, f.vote(addr, txhash, choice, startHeight))
+	}
+	f.commit()
+
+	//
+	// the vetoed proposal is frozen with its result.
+	prop, xerr = f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	evts := f.endBlock(prop.Header().EndVotingHeight + 1)
+	require.Len(t, evts, 1)
+	require.Equal(t, "result", string(evts[0].Attributes[1].Key))
+	require.Equal(t, "vetoed", string(evts[0].Attributes[1].Value))
+
+	item, xerr := f.ctrler.govState.Get(v1.LedgerKeyFrozenProp(txhash), false)
+	require.NoError(t, xerr)
+	frozenProp, _ := item.(*proposal.GovProposal)
+	require.Nil(t, frozenProp.MajorOption())
+	require.Equal(t, proposal.TALLY_VETOED, frozenProp.Result().Status)
+	require.Equal(t, int64(4000), frozenProp.Result().VotedPower)
+	require.Equal(t, int64(2000), frozenProp.VetoVotes())
+	require.Equal(t, int64(1000), frozenProp.AbstainVotes())
+
+	//
+	// the failed proposal is removed without being applied.
+	require.Equal(t, []string{"removed"}, f.endBlockKeys(frozenProp.Header().ApplyHeight))
+
+	_, xerr = f.ctrler.govState.Get(v1.LedgerKeyFrozenProp(txhash), false)
+	require.Equal(t, xerrors.ErrNotFoundResult, xerr)
+}
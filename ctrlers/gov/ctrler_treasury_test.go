@@ -0,0 +1,104 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	btztypes "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TreasurySpend(t *testing.T) {
+	f := newGovFixture(t, "gov-treasury-spend-test", 3, nil)
+	recipient := acctMock.GetWallet(40).Address()
+
+	propose := func(option string, height int64) (bytes.HexBytes, xerrors.XError) {
+		return f.propose(f.vpow.PickAddress(0), height+1, height, proposal.PROPOSAL_TREASURY_SPEND, []byte(option))
+	}
+	endBlock := func(height int64) []string {
+		var types []string
+		for _, evt := range f.endBlock(height) {
+			types = append(types, evt.Type)
+		}
+		return types
+	}
+
+	//
+	// wrong options
+	for _, opt := range []string{
+		`{"recipient":"0x0000000000000000000000000000000000000000","amount":"1000"}`,
+		`{"recipient":"` + recipient.String() + `","amount":"0"}`,
+		`{"recipient":"` + recipient.String() + `","amount":"1000","instalments":3}`,
+		`{"recipient":"` + recipient.String() + `","amount":"2","instalments":3,"intervalBlocks":10}`,
+	} {
+		_, xerr := propose(opt, 1)
+		require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxPayloadParams.Error(), opt)
+	}
+
+	//
+	// 1000 is paid in 3 instalments every 10 blocks.
+	txhash, xerr := propose(`{"recipient":"`+recipient.String()+`","amount":"1000","instalments":3,"intervalBlocks":10}`, 1)
+	require.NoError(t, xerr)
+	prop, xerr := f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	f.voteAll(txhash, 0)
+	f.commit()
+	require.Equal(t, []string{"proposal"}, endBlock(prop.Header().EndVotingHeight+1))
+
+	require.NoError(t, acctMock.AddBalance(btztypes.TreasuryAddress(), uint256.NewInt(500), true))
+	treasuryBalance, recipientBalance := balanceOf(btztypes.TreasuryAddress()), balanceOf(recipient)
+
+	applyHeight := prop.Header().ApplyHeight
+	require.Equal(t, []string{"proposal", "treasury.payout"}, endBlock(applyHeight))
+	require.Equal(t, recipientBalance+333, balanceOf(recipient))
+	require.Equal(t, treasuryBalance-333, balanceOf(btztypes.TreasuryAddress()))
+
+	// not yet.
+	require.Empty(t, endBlock(applyHeight+9))
+
+	// the treasury does not have enough balance. the instalment is postponed.
+	require.Empty(t, endBlock(applyHeight+10))
+	require.Equal(t, recipientBalance+333, balanceOf(recipient))
+
+	require.NoError(t, acctMock.AddBalance(btztypes.TreasuryAddress(), uint256.NewInt(500), true))
+	require.Equal(t, []string{"treasury.payout"}, endBlock(applyHeight+11))
+	require.Equal(t, recipientBalance+666, balanceOf(recipient))
+
+	// the last instalment pays the remainder.
+	require.Empty(t, endBlock(applyHeight+19))
+	require.Equal(t, []string{"treasury.payout"}, endBlock(applyHeight+20))
+	require.Equal(t, recipientBalance+1000, balanceOf(recipient))
+	require.Equal(t, treasuryBalance+500-1000, balanceOf(btztypes.TreasuryAddress()))
+
+	// all instalments are paid.
+	require.Empty(t, endBlock(applyHeight+30))
+}
+
+func Test_TreasurySpend_InvalidOption(t *testing.T) {
+	f := newGovFixture(t, "gov-treasury-spend-invalid-test", 3, nil)
+	recipient := acctMock.GetWallet(41).Address()
+
+	txhash, xerr := f.propose(f.vpow.PickAddress(0), 2, 1, proposal.PROPOSAL_TREASURY_SPEND,
+		[]byte(`{"recipient":"`+recipient.String()+`","amount":"1000"}`))
+	require.NoError(t, xerr)
+	prop, xerr := f.ctrler.ReadProposal(txhash, true)
+	require.NoError(t, xerr)
+	f.voteAll(txhash, 0)
+	f.commit()
+	f.endBlock(prop.Header().EndVotingHeight + 1)
+
+	// the option that can not be decoded is dropped without stopping the block.
+	f.setFrozenOption(txhash, []byte(`{"recipient":`))
+	recipientBalance := balanceOf(recipient)
+	for _, evt := range f.endBlock(prop.Header().ApplyHeight) {
+		require.NotEqual(t, "treasury.payout", evt.Type)
+	}
+	require.Equal(t, recipientBalance, balanceOf(recipient))
+
+	_, xerr = f.ctrler.govState.Get(v1.LedgerKeyFrozenProp(txhash), true)
+	require.Equal(t, xerrors.ErrNotFoundResult, xerr)
+}
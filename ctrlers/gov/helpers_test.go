@@ -1,11 +1,24 @@
 package gov
 
 import (
+	"os"
+	"path/filepath"
+	"testing"
 	"time"
 
+	cfg "github.com/beatoz/beatoz-go/cmd/config"
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
 	"github.com/beatoz/beatoz-go/ctrlers/mocks"
+	mockvpower "github.com/beatoz/beatoz-go/ctrlers/mocks/vpower"
 	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	btztypes "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
 	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/beatoz/beatoz-sdk-go/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
 )
 
 func makeTrxCtx(tx *ctrlertypes.Trx, height int64, exec bool) *ctrlertypes.TrxContext {
@@ -31,3 +44,160 @@ func runTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
 	}
 	return nil
 }
+
+// govFixture has the GovCtrler and the validators of its own
+// to avoid interference with `govCtrler` and `vpowMock` used by the other tests.
+// The accounts are shared in `acctMock`.
+type govFixture struct {
+	t      *testing.T
+	cfg    *cfg.Config
+	ctrler *GovCtrler
+	vpow   *mockvpower.VPowerHandlerMock
+}
+
+// newGovFixture creates the fixture whose root directory is `name` in the temp directory.
+// Its GovCtrler has the default parameters changed by `setParams` (if not nil),
+// and the first `valCnt` wallets of `acctMock` are the validators with the power 1000 each.
+func newGovFixture(t *testing.T, name string, valCnt int, setParams func(*ctrlertypes.GovParamsProto)) *govFixture {
+	rootPath := filepath.Join(os.TempDir(), name)
+	localCfg := cfg.DefaultConfig("0x1234")
+	localCfg.SetRoot(rootPath)
+
+	require.NoError(t, os.RemoveAll(rootPath))
+	t.Cleanup(func() { _ = os.RemoveAll(rootPath) })
+
+	ctrler, xerr := NewGovCtrler(localCfg, tmlog.NewNopLogger())
+	require.NoError(t, xerr)
+	ctrler.GovParams = *(ctrlertypes.DefaultGovParams())
+	if setParams != nil {
+		ctrler.GovParams.SetValue(setParams)
+	}
+
+	var valWals []*web3.Wallet
+	for i := 0; i < valCnt; i++ {
+		valWals = append(valWals, acctMock.GetWallet(i))
+	}
+	return &govFixture{
+		t:      t,
+		cfg:    localCfg,
+		ctrler: ctrler,
+		vpow:   mockvpower.NewVPowerHandlerMockWithPower(valWals, valCnt, 1000),
+	}
+}
+
+// runTx signs `tx` by its sender, and validates and executes it at `height`.
+func (f *govFixture) runTx(tx *ctrlertypes.Trx, height int64) (*ctrlertypes.TrxContext, xerrors.XError) {
+	_ = signTrx(tx, tx.From, f.cfg.ChainIdHex())
+	txctx, xerr := mocks.MakeTrxCtxWithTrx(tx, f.cfg.ChainIdHex(), height, time.Now(), true,
+		f.ctrler, acctMock, nil, nil, f.vpow)
+	require.NoError(f.t, xerr)
+	if xerr := f.ctrler.ValidateTrx(txctx); xerr != nil {
+		return nil, xerr
+	}
+	if xerr := f.ctrler.ExecuteTrx(txctx); xerr != nil {
+		return nil, xerr
+	}
+	return txctx, nil
+}
+
+// runTxPaidBy runs `tx` like runTx, but `payer` pays its fee.
+// As the account ledger does, the payer's account in the context is a copy
+// that is written back to `acctMock` after the tx.
+func (f *govFixture) runTxPaidBy(tx *ctrlertypes.Trx, payer btztypes.Address, height int64) (*ctrlertypes.TrxContext, xerrors.XError) {
+	_ = signTrx(tx, tx.From, f.cfg.ChainIdHex())
+	_, _, err := acctMock.FindWallet(payer).SignPayerTrxRLP(tx, f.cfg.ChainIdHex())
+	require.NoError(f.t, err)
+	txctx, xerr := mocks.MakeTrxCtxWithTrx(tx, f.cfg.ChainIdHex(), height, time.Now(), true,
+		f.ctrler, acctMock, nil, nil, f.vpow)
+	require.NoError(f.t, xerr)
+	require.Equal(f.t, payer, txctx.Payer.Address)
+	txctx.Payer = txctx.Payer.Clone()
+
+	if xerr := f.ctrler.ValidateTrx(txctx); xerr != nil {
+		return nil, xerr
+	}
+	if xerr := f.ctrler.ExecuteTrx(txctx); xerr != nil {
+		return nil, xerr
+	}
+	acctMock.FindAccount(payer, true).SetBalance(txctx.Payer.Balance)
+	return txctx, nil
+}
+
+// proposalTx returns the proposal tx of `from` whose voting starts at `startHeight` for the minimum voting period.
+func (f *govFixture) proposalTx(from btztypes.Address, startHeight int64, optType int32, options ...[]byte) *ctrlertypes.Trx {
+	return web3.NewTrxProposal(
+		from, btztypes.ZeroAddress(), 1, defMinGas, defGasPrice,
+		f.t.Name(),
+		startHeight,
+		f.ctrler.MinVotingPeriodBlocks(),
+		startHeight+f.ctrler.MinVotingPeriodBlocks()+f.ctrler.LazyApplyingBlocks(),
+		optType, options...)
+}
+
+// propose runs the proposal tx of `from` at `height` and returns its hash.
+func (f *govFixture) propose(from btztypes.Address, startHeight, height int64, optType int32, options ...[]byte) (bytes.HexBytes, xerrors.XError) {
+	txctx, xerr := f.runTx(f.proposalTx(from, startHeight, optType, options...), height)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return txctx.TxHash, nil
+}
+
+func (f *govFixture) vote(addr btztypes.Address, txhash bytes.HexBytes, choice int32, height int64) xerrors.XError {
+	tx := web3.NewTrxVoting(addr, btztypes.ZeroAddress(), 1, defMinGas, defGasPrice, txhash, choice)
+	_, xerr := f.runTx(tx, height)
+	return xerr
+}
+
+// voteAll makes all validators vote for `choice` at the start height of the proposal.
+func (f *govFixture) voteAll(txhash bytes.HexBytes, choice int32) {
+	prop, xerr := f.ctrler.ReadProposal(txhash, true)
+	require.NoError(f.t, xerr)
+	for i := 0; i < f.vpow.ValCnt; i++ {
+		require.NoError(f.t, f.vote(f.vpow.PickAddress(i), txhash, choice, prop.Header().StartVotingHeight))
+	}
+}
+
+// setFrozenOption replaces the major option of the frozen proposal `txhash` with `option`,
+// which has not been validated at the submission.
+func (f *govFixture) setFrozenOption(txhash bytes.HexBytes, option []byte) {
+	item, xerr := f.ctrler.govState.Get(v1.LedgerKeyFrozenProp(txhash), true)
+	require.NoError(f.t, xerr)
+	prop, _ := item.(*proposal.GovProposal)
+	prop.MajorOption().Option = option
+	require.NoError(f.t, f.ctrler.govState.Set(v1.LedgerKeyFrozenProp(txhash), prop, true))
+	f.commit()
+}
+
+// commit commits the ledger and returns its version.
+func (f *govFixture) commit() int64 {
+	_, ver, xerr := f.ctrler.Commit()
+	require.NoError(f.t, xerr)
+	return ver
+}
+
+// endBlock runs EndBlock at `height`, commits the ledger and returns the events.
+func (f *govFixture) endBlock(height int64) []abcitypes.Event {
+	bctx := mocks.InitBlockCtxWith(f.cfg.ChainIdHex(), height, f.ctrler, acctMock, nil, nil, f.vpow)
+	evts, xerr := f.ctrler.EndBlock(bctx)
+	require.NoError(f.t, xerr)
+	f.commit()
+	return evts
+}
+
+// endBlockKeys returns the key of the first attribute of each event of endBlock.
+func (f *govFixture) endBlockKeys(height int64) []string {
+	var keys []string
+	for _, evt := range f.endBlock(height) {
+		keys = append(keys, string(evt.Attributes[0].Key))
+	}
+	return keys
+}
+
+func balanceOf(addr btztypes.Address) uint64 {
+	acct := acctMock.FindAccount(addr, true)
+	if acct == nil {
+		return 0
+	}
+	return acct.Balance.Uint64()
+}
@@ -0,0 +1,120 @@
+package proposal
+
+import (
+	"bytes"
+	"sort"
+
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"google.golang.org/protobuf/proto"
+)
+
+// AllowListChange is the option of PROPOSAL_ALLOW_LIST.
+// It changes the allow-list of `Target`,
+// which is the list of the deployers if `Target` is the zero address,
+// or the list of the callers of the contract `Target` otherwise.
+// The list is created if it does not exist, so `Target` becomes restricted to the accounts in the list.
+// If `Unrestrict` is true, the list is deleted and `Target` is open to everyone again.
+type AllowListChange struct {
+	Target     types.Address   `json:"target"`
+	Add        []types.Address `json:"add,omitempty"`
+	Remove     []types.Address `json:"remove,omitempty"`
+	Unrestrict bool            `json:"unrestrict,omitempty"`
+}
+
+// DecodeAllowListChange parses and validates the option of PROPOSAL_ALLOW_LIST.
+func DecodeAllowListChange(opt []byte) (*AllowListChange, xerrors.XError) {
+	change := &AllowListChange{}
+	if err := jsonx.Unmarshal(opt, change); err != nil {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrap(err)
+	}
+	if len(change.Target) != types.AddrSize {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong target: %v", change.Target)
+	}
+	if change.Unrestrict && (len(change.Add) > 0 || len(change.Remove) > 0) {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong option: unrestrict can not be used with add or remove")
+	}
+
+	seen := make(map[string]struct{})
+	for _, addr := range append(append([]types.Address{}, change.Add...), change.Remove...) {
+		if len(addr) != types.AddrSize || types.IsZeroAddress(addr) {
+			return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong account: %v", addr)
+		}
+		if _, ok := seen[addr.String()]; ok {
+			return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("duplicated account: %v", addr)
+		}
+		seen[addr.String()] = struct{}{}
+	}
+	return change, nil
+}
+
+// AllowList is the list of the accounts allowed to deploy contracts or to call a contract.
+// The accounts are kept in ascending order.
+type AllowList struct {
+	v AllowListProto
+}
+
+func NewAllowList(accounts ...types.Address) *AllowList {
+	ret := &AllowList{}
+	ret.Add(accounts...)
+	return ret
+}
+
+func (list *AllowList) Encode() ([]byte, xerrors.XError) {
+	if bz, err := proto.Marshal(&list.v); err != nil {
+		return bz, xerrors.From(err)
+	} else {
+		return bz, nil
+	}
+}
+
+func (list *AllowList) Decode(k, v []byte) xerrors.XError {
+	if err := proto.Unmarshal(v, &list.v); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+var _ v1.ILedgerItem = (*AllowList)(nil)
+
+func (list *AllowList) search(addr types.Address) (int, bool) {
+	idx := sort.Search(len(list.v.Accounts), func(i int) bool {
+		return bytes.Compare(list.v.Accounts[i], addr) >= 0
+	})
+	return idx, idx < len(list.v.Accounts) && bytes.Equal(list.v.Accounts[idx], addr)
+}
+
+func (list *AllowList) Contains(addr types.Address) bool {
+	_, found := list.search(addr)
+	return found
+}
+
+func (list *AllowList) Add(accounts ...types.Address) {
+	for _, addr := range accounts {
+		idx, found := list.search(addr)
+		if found {
+			continue
+		}
+		list.v.Accounts = append(list.v.Accounts, nil)
+		copy(list.v.Accounts[idx+1:], list.v.Accounts[idx:])
+		list.v.Accounts[idx] = append([]byte(nil), addr...)
+	}
+}
+
+func (list *AllowList) Remove(accounts ...types.Address) {
+	for _, addr := range accounts {
+		if idx, found := list.search(addr); found {
+			list.v.Accounts = append(list.v.Accounts[:idx], list.v.Accounts[idx+1:]...)
+		}
+	}
+}
+
+func (list *AllowList) Accounts() []types.Address {
+	ret := make([]types.Address, len(list.v.Accounts))
+	for i, acct := range list.v.Accounts {
+		ret[i] = acct
+	}
+	return ret
+}
@@ -0,0 +1,111 @@
+package proposal
+
+import (
+	"bytes"
+
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/holiman/uint256"
+)
+
+func (x *DepositProto) Amount() *uint256.Int {
+	return new(uint256.Int).SetBytes(x.XAmount)
+}
+
+// SetDepositRule sets the proposer and the deposit required for the proposal to be voted.
+// The proposal should reach `minDeposit` until `depositEndHeight`.
+func (prop *GovProposal) SetDepositRule(proposer types.Address, minDeposit *uint256.Int, depositEndHeight int64) {
+	prop.mtx.Lock()
+	defer prop.mtx.Unlock()
+
+	prop.v.Header.Proposer = proposer
+	prop.v.Header.XMinDeposit = nil
+	if minDeposit != nil && !minDeposit.IsZero() {
+		prop.v.Header.XMinDeposit = minDeposit.Bytes()
+	}
+	prop.v.Header.DepositEndHeight = depositEndHeight
+}
+
+func (prop *GovProposal) Proposer() types.Address {
+	prop.mtx.RLock()
+	defer prop.mtx.RUnlock()
+
+	return prop.v.Header.Proposer
+}
+
+func (prop *GovProposal) MinDeposit() *uint256.Int {
+	prop.mtx.RLock()
+	defer prop.mtx.RUnlock()
+
+	return new(uint256.Int).SetBytes(prop.v.Header.XMinDeposit)
+}
+
+func (prop *GovProposal) Deposits() []*DepositProto {
+	prop.mtx.RLock()
+	defer prop.mtx.RUnlock()
+
+	return prop.v.Deposits
+}
+
+func (prop *GovProposal) TotalDeposit() *uint256.Int {
+	prop.mtx.RLock()
+	defer prop.mtx.RUnlock()
+
+	return prop.totalDeposit()
+}
+
+func (prop *GovProposal) totalDeposit() *uint256.Int {
+	sum := uint256.NewInt(0)
+	for _, d := range prop.v.Deposits {
+		_ = sum.Add(sum, d.Amount())
+	}
+	return sum
+}
+
+// IsDepositPeriod returns true if the proposal has not reached the minimum deposit yet.
+// The proposal can not be voted in the deposit period.
+func (prop *GovProposal) IsDepositPeriod() bool {
+	prop.mtx.RLock()
+	defer prop.mtx.RUnlock()
+
+	return prop.isDepositPeriod()
+}
+
+func (prop *GovProposal) isDepositPeriod() bool {
+	return prop.totalDeposit().Lt(new(uint256.Int).SetBytes(prop.v.Header.XMinDeposit))
+}
+
+// AddDeposit adds `amt` deposited by `depositor` at `height`.
+// If the proposal reaches the minimum deposit after its voting start height,
+// the voting period and the applying height are postponed so that the voting starts at the next block.
+// It returns true if the proposal has left the deposit period by this deposit.
+func (prop *GovProposal) AddDeposit(depositor types.Address, amt *uint256.Int, height int64) bool {
+	prop.mtx.Lock()
+	defer prop.mtx.Unlock()
+
+	wasDepositPeriod := prop.isDepositPeriod()
+
+	var deposit *DepositProto
+	for _, d := range prop.v.Deposits {
+		if bytes.Equal(d.Depositor, depositor) {
+			deposit = d
+			break
+		}
+	}
+	if deposit == nil {
+		deposit = &DepositProto{Depositor: depositor}
+		prop.v.Deposits = append(prop.v.Deposits, deposit)
+	}
+	deposit.XAmount = new(uint256.Int).Add(deposit.Amount(), amt).Bytes()
+
+	if !wasDepositPeriod || prop.isDepositPeriod() {
+		return false
+	}
+
+	hdr := prop.v.Header
+	if delay := height + 1 - hdr.StartVotingHeight; delay > 0 {
+		hdr.StartVotingHeight += delay
+		hdr.EndVotingHeight += delay
+		hdr.ApplyHeight += delay
+	}
+	return true
+}
@@ -26,6 +26,7 @@ type VoterProto struct {
 	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Power         int64                  `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
 	Choice        int32                  `protobuf:"varint,3,opt,name=choice,proto3" json:"choice,omitempty"`
+	Delegatee     []byte                 `protobuf:"bytes,4,opt,name=delegatee,proto3" json:"delegatee,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,6 +82,13 @@ func (x *VoterProto) GetChoice() int32 {
 	return 0
 }
 
+func (x *VoterProto) GetDelegatee() []byte {
+	if x != nil {
+		return x.Delegatee
+	}
+	return nil
+}
+
 type GovProposalHeaderProto struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	PropType          int32                  `protobuf:"varint,1,opt,name=prop_type,json=propType,proto3" json:"prop_type,omitempty"`
@@ -91,6 +99,12 @@ type GovProposalHeaderProto struct {
 	TotalVotingPower  int64                  `protobuf:"varint,6,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
 	MajorityPower     int64                  `protobuf:"varint,7,opt,name=majority_power,json=majorityPower,proto3" json:"majority_power,omitempty"`
 	Voters            []*VoterProto          `protobuf:"bytes,8,rep,name=voters,proto3" json:"voters,omitempty"`
+	QuorumRate        int32                  `protobuf:"varint,9,opt,name=quorum_rate,json=quorumRate,proto3" json:"quorum_rate,omitempty"`
+	PassThresholdRate int32                  `protobuf:"varint,10,opt,name=pass_threshold_rate,json=passThresholdRate,proto3" json:"pass_threshold_rate,omitempty"`
+	VetoThresholdRate int32                  `protobuf:"varint,11,opt,name=veto_threshold_rate,json=vetoThresholdRate,proto3" json:"veto_threshold_rate,omitempty"`
+	Proposer          []byte                 `protobuf:"bytes,12,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	DepositEndHeight  int64                  `protobuf:"varint,13,opt,name=deposit_end_height,json=depositEndHeight,proto3" json:"deposit_end_height,omitempty"`
+	XMinDeposit       []byte                 `protobuf:"bytes,14,opt,name=_min_deposit,json=MinDeposit,proto3" json:"_min_deposit,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -181,6 +195,48 @@ func (x *GovProposalHeaderProto) GetVoters() []*VoterProto {
 	return nil
 }
 
+func (x *GovProposalHeaderProto) GetQuorumRate() int32 {
+	if x != nil {
+		return x.QuorumRate
+	}
+	return 0
+}
+
+func (x *GovProposalHeaderProto) GetPassThresholdRate() int32 {
+	if x != nil {
+		return x.PassThresholdRate
+	}
+	return 0
+}
+
+func (x *GovProposalHeaderProto) GetVetoThresholdRate() int32 {
+	if x != nil {
+		return x.VetoThresholdRate
+	}
+	return 0
+}
+
+func (x *GovProposalHeaderProto) GetProposer() []byte {
+	if x != nil {
+		return x.Proposer
+	}
+	return nil
+}
+
+func (x *GovProposalHeaderProto) GetDepositEndHeight() int64 {
+	if x != nil {
+		return x.DepositEndHeight
+	}
+	return 0
+}
+
+func (x *GovProposalHeaderProto) GetXMinDeposit() []byte {
+	if x != nil {
+		return x.XMinDeposit
+	}
+	return nil
+}
+
 type VoteOptionProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Option        []byte                 `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`
@@ -233,18 +289,142 @@ func (x *VoteOptionProto) GetVotes() int64 {
 	return 0
 }
 
+type DepositProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Depositor     []byte                 `protobuf:"bytes,1,opt,name=depositor,proto3" json:"depositor,omitempty"`
+	XAmount       []byte                 `protobuf:"bytes,2,opt,name=_amount,json=Amount,proto3" json:"_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositProto) Reset() {
+	*x = DepositProto{}
+	mi := &file_gov_proposal_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositProto) ProtoMessage() {}
+
+func (x *DepositProto) ProtoReflect() protoreflect.Message {
+	mi := &file_gov_proposal_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositProto.ProtoReflect.Descriptor instead.
+func (*DepositProto) Descriptor() ([]byte, []int) {
+	return file_gov_proposal_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DepositProto) GetDepositor() []byte {
+	if x != nil {
+		return x.Depositor
+	}
+	return nil
+}
+
+func (x *DepositProto) GetXAmount() []byte {
+	if x != nil {
+		return x.XAmount
+	}
+	return nil
+}
+
+type TallyResultProto struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         int32                  `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	VotedPower     int64                  `protobuf:"varint,2,opt,name=voted_power,json=votedPower,proto3" json:"voted_power,omitempty"`
+	QuorumPower    int64                  `protobuf:"varint,3,opt,name=quorum_power,json=quorumPower,proto3" json:"quorum_power,omitempty"`
+	ThresholdPower int64                  `protobuf:"varint,4,opt,name=threshold_power,json=thresholdPower,proto3" json:"threshold_power,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TallyResultProto) Reset() {
+	*x = TallyResultProto{}
+	mi := &file_gov_proposal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TallyResultProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TallyResultProto) ProtoMessage() {}
+
+func (x *TallyResultProto) ProtoReflect() protoreflect.Message {
+	mi := &file_gov_proposal_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TallyResultProto.ProtoReflect.Descriptor instead.
+func (*TallyResultProto) Descriptor() ([]byte, []int) {
+	return file_gov_proposal_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TallyResultProto) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *TallyResultProto) GetVotedPower() int64 {
+	if x != nil {
+		return x.VotedPower
+	}
+	return 0
+}
+
+func (x *TallyResultProto) GetQuorumPower() int64 {
+	if x != nil {
+		return x.QuorumPower
+	}
+	return 0
+}
+
+func (x *TallyResultProto) GetThresholdPower() int64 {
+	if x != nil {
+		return x.ThresholdPower
+	}
+	return 0
+}
+
 type GovProposalProto struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
 	Header        *GovProposalHeaderProto `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Options       []*VoteOptionProto      `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
 	MajorOption   *VoteOptionProto        `protobuf:"bytes,3,opt,name=major_option,json=majorOption,proto3" json:"major_option,omitempty"`
+	AbstainVotes  int64                   `protobuf:"varint,4,opt,name=abstain_votes,json=abstainVotes,proto3" json:"abstain_votes,omitempty"`
+	VetoVotes     int64                   `protobuf:"varint,5,opt,name=veto_votes,json=vetoVotes,proto3" json:"veto_votes,omitempty"`
+	Result        *TallyResultProto       `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	Deposits      []*DepositProto         `protobuf:"bytes,7,rep,name=deposits,proto3" json:"deposits,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GovProposalProto) Reset() {
 	*x = GovProposalProto{}
-	mi := &file_gov_proposal_proto_msgTypes[3]
+	mi := &file_gov_proposal_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -256,7 +436,7 @@ func (x *GovProposalProto) String() string {
 func (*GovProposalProto) ProtoMessage() {}
 
 func (x *GovProposalProto) ProtoReflect() protoreflect.Message {
-	mi := &file_gov_proposal_proto_msgTypes[3]
+	mi := &file_gov_proposal_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,7 +449,7 @@ func (x *GovProposalProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GovProposalProto.ProtoReflect.Descriptor instead.
 func (*GovProposalProto) Descriptor() ([]byte, []int) {
-	return file_gov_proposal_proto_rawDescGZIP(), []int{3}
+	return file_gov_proposal_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GovProposalProto) GetHeader() *GovProposalHeaderProto {
@@ -293,16 +473,173 @@ func (x *GovProposalProto) GetMajorOption() *VoteOptionProto {
 	return nil
 }
 
+func (x *GovProposalProto) GetAbstainVotes() int64 {
+	if x != nil {
+		return x.AbstainVotes
+	}
+	return 0
+}
+
+func (x *GovProposalProto) GetVetoVotes() int64 {
+	if x != nil {
+		return x.VetoVotes
+	}
+	return 0
+}
+
+func (x *GovProposalProto) GetResult() *TallyResultProto {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GovProposalProto) GetDeposits() []*DepositProto {
+	if x != nil {
+		return x.Deposits
+	}
+	return nil
+}
+
+type TreasuryPayoutProto struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TxHash         []byte                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Recipient      []byte                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	XAmount        []byte                 `protobuf:"bytes,3,opt,name=_amount,json=Amount,proto3" json:"_amount,omitempty"`
+	Instalments    int64                  `protobuf:"varint,4,opt,name=instalments,proto3" json:"instalments,omitempty"`
+	IntervalBlocks int64                  `protobuf:"varint,5,opt,name=interval_blocks,json=intervalBlocks,proto3" json:"interval_blocks,omitempty"`
+	NextHeight     int64                  `protobuf:"varint,6,opt,name=next_height,json=nextHeight,proto3" json:"next_height,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TreasuryPayoutProto) Reset() {
+	*x = TreasuryPayoutProto{}
+	mi := &file_gov_proposal_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreasuryPayoutProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreasuryPayoutProto) ProtoMessage() {}
+
+func (x *TreasuryPayoutProto) ProtoReflect() protoreflect.Message {
+	mi := &file_gov_proposal_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreasuryPayoutProto.ProtoReflect.Descriptor instead.
+func (*TreasuryPayoutProto) Descriptor() ([]byte, []int) {
+	return file_gov_proposal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TreasuryPayoutProto) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+func (x *TreasuryPayoutProto) GetRecipient() []byte {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *TreasuryPayoutProto) GetXAmount() []byte {
+	if x != nil {
+		return x.XAmount
+	}
+	return nil
+}
+
+func (x *TreasuryPayoutProto) GetInstalments() int64 {
+	if x != nil {
+		return x.Instalments
+	}
+	return 0
+}
+
+func (x *TreasuryPayoutProto) GetIntervalBlocks() int64 {
+	if x != nil {
+		return x.IntervalBlocks
+	}
+	return 0
+}
+
+func (x *TreasuryPayoutProto) GetNextHeight() int64 {
+	if x != nil {
+		return x.NextHeight
+	}
+	return 0
+}
+
+type AllowListProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      [][]byte               `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllowListProto) Reset() {
+	*x = AllowListProto{}
+	mi := &file_gov_proposal_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllowListProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllowListProto) ProtoMessage() {}
+
+func (x *AllowListProto) ProtoReflect() protoreflect.Message {
+	mi := &file_gov_proposal_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllowListProto.ProtoReflect.Descriptor instead.
+func (*AllowListProto) Descriptor() ([]byte, []int) {
+	return file_gov_proposal_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AllowListProto) GetAccounts() [][]byte {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
 var File_gov_proposal_proto protoreflect.FileDescriptor
 
 const file_gov_proposal_proto_rawDesc = "" +
 	"\n" +
-	"\x12gov_proposal.proto\x12\x05types\"T\n" +
+	"\x12gov_proposal.proto\x12\x05types\"r\n" +
 	"\n" +
 	"VoterProto\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x14\n" +
 	"\x05power\x18\x02 \x01(\x03R\x05power\x12\x16\n" +
-	"\x06choice\x18\x03 \x01(\x05R\x06choice\"\xcd\x02\n" +
+	"\x06choice\x18\x03 \x01(\x05R\x06choice\x12\x1c\n" +
+	"\tdelegatee\x18\x04 \x01(\fR\tdelegatee\"\xba\x04\n" +
 	"\x16GovProposalHeaderProto\x12\x1b\n" +
 	"\tprop_type\x18\x01 \x01(\x05R\bpropType\x12\x17\n" +
 	"\atx_hash\x18\x02 \x01(\fR\x06txHash\x12.\n" +
@@ -311,14 +648,47 @@ const file_gov_proposal_proto_rawDesc = "" +
 	"\fapply_height\x18\x05 \x01(\x03R\vapplyHeight\x12,\n" +
 	"\x12total_voting_power\x18\x06 \x01(\x03R\x10totalVotingPower\x12%\n" +
 	"\x0emajority_power\x18\a \x01(\x03R\rmajorityPower\x12)\n" +
-	"\x06voters\x18\b \x03(\v2\x11.types.VoterProtoR\x06voters\"?\n" +
+	"\x06voters\x18\b \x03(\v2\x11.types.VoterProtoR\x06voters\x12\x1f\n" +
+	"\vquorum_rate\x18\t \x01(\x05R\n" +
+	"quorumRate\x12.\n" +
+	"\x13pass_threshold_rate\x18\n" +
+	" \x01(\x05R\x11passThresholdRate\x12.\n" +
+	"\x13veto_threshold_rate\x18\v \x01(\x05R\x11vetoThresholdRate\x12\x1a\n" +
+	"\bproposer\x18\f \x01(\fR\bproposer\x12,\n" +
+	"\x12deposit_end_height\x18\r \x01(\x03R\x10depositEndHeight\x12 \n" +
+	"\f_min_deposit\x18\x0e \x01(\fR\n" +
+	"MinDeposit\"?\n" +
 	"\x0fvoteOptionProto\x12\x16\n" +
 	"\x06option\x18\x01 \x01(\fR\x06option\x12\x14\n" +
-	"\x05votes\x18\x02 \x01(\x03R\x05votes\"\xb6\x01\n" +
+	"\x05votes\x18\x02 \x01(\x03R\x05votes\"E\n" +
+	"\fdepositProto\x12\x1c\n" +
+	"\tdepositor\x18\x01 \x01(\fR\tdepositor\x12\x17\n" +
+	"\a_amount\x18\x02 \x01(\fR\x06Amount\"\x97\x01\n" +
+	"\x10tallyResultProto\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\x05R\x06status\x12\x1f\n" +
+	"\vvoted_power\x18\x02 \x01(\x03R\n" +
+	"votedPower\x12!\n" +
+	"\fquorum_power\x18\x03 \x01(\x03R\vquorumPower\x12'\n" +
+	"\x0fthreshold_power\x18\x04 \x01(\x03R\x0ethresholdPower\"\xdc\x02\n" +
 	"\x10GovProposalProto\x125\n" +
 	"\x06header\x18\x01 \x01(\v2\x1d.types.GovProposalHeaderProtoR\x06header\x120\n" +
 	"\aoptions\x18\x02 \x03(\v2\x16.types.voteOptionProtoR\aoptions\x129\n" +
-	"\fmajor_option\x18\x03 \x01(\v2\x16.types.voteOptionProtoR\vmajorOptionB2Z0github.com/beatoz/beatoz-go/ctrlers/gov/proposalb\x06proto3"
+	"\fmajor_option\x18\x03 \x01(\v2\x16.types.voteOptionProtoR\vmajorOption\x12#\n" +
+	"\rabstain_votes\x18\x04 \x01(\x03R\fabstainVotes\x12\x1d\n" +
+	"\n" +
+	"veto_votes\x18\x05 \x01(\x03R\tvetoVotes\x12/\n" +
+	"\x06result\x18\x06 \x01(\v2\x17.types.tallyResultProtoR\x06result\x12/\n" +
+	"\bdeposits\x18\a \x03(\v2\x13.types.depositProtoR\bdeposits\"\xd1\x01\n" +
+	"\x13treasuryPayoutProto\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\fR\x06txHash\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\fR\trecipient\x12\x17\n" +
+	"\a_amount\x18\x03 \x01(\fR\x06Amount\x12 \n" +
+	"\vinstalments\x18\x04 \x01(\x03R\vinstalments\x12'\n" +
+	"\x0finterval_blocks\x18\x05 \x01(\x03R\x0eintervalBlocks\x12\x1f\n" +
+	"\vnext_height\x18\x06 \x01(\x03R\n" +
+	"nextHeight\",\n" +
+	"\x0eallowListProto\x12\x1a\n" +
+	"\baccounts\x18\x01 \x03(\fR\baccountsB2Z0github.com/beatoz/beatoz-go/ctrlers/gov/proposalb\x06proto3"
 
 var (
 	file_gov_proposal_proto_rawDescOnce sync.Once
@@ -332,23 +702,29 @@ func file_gov_proposal_proto_rawDescGZIP() []byte {
 	return file_gov_proposal_proto_rawDescData
 }
 
-var file_gov_proposal_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_gov_proposal_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_gov_proposal_proto_goTypes = []any{
 	(*VoterProto)(nil),             // 0: types.VoterProto
 	(*GovProposalHeaderProto)(nil), // 1: types.GovProposalHeaderProto
 	(*VoteOptionProto)(nil),        // 2: types.voteOptionProto
-	(*GovProposalProto)(nil),       // 3: types.GovProposalProto
+	(*DepositProto)(nil),           // 3: types.depositProto
+	(*TallyResultProto)(nil),       // 4: types.tallyResultProto
+	(*GovProposalProto)(nil),       // 5: types.GovProposalProto
+	(*TreasuryPayoutProto)(nil),    // 6: types.treasuryPayoutProto
+	(*AllowListProto)(nil),         // 7: types.allowListProto
 }
 var file_gov_proposal_proto_depIdxs = []int32{
 	0, // 0: types.GovProposalHeaderProto.voters:type_name -> types.VoterProto
 	1, // 1: types.GovProposalProto.header:type_name -> types.GovProposalHeaderProto
 	2, // 2: types.GovProposalProto.options:type_name -> types.voteOptionProto
 	2, // 3: types.GovProposalProto.major_option:type_name -> types.voteOptionProto
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	4, // 4: types.GovProposalProto.result:type_name -> types.tallyResultProto
+	3, // 5: types.GovProposalProto.deposits:type_name -> types.depositProto
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_gov_proposal_proto_init() }
@@ -362,7 +738,7 @@ func file_gov_proposal_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gov_proposal_proto_rawDesc), len(file_gov_proposal_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
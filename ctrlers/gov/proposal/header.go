@@ -6,14 +6,18 @@ import (
 )
 
 const (
-	PROPOSAL_ONCHAIN   int32 = 0x0100
-	PROPOSAL_OFFCHAIN        = 0x0200
-	PROPOSAL_GOVPARAMS       = PROPOSAL_ONCHAIN | 0x01
-	PROPOSAL_COMMON          = PROPOSAL_OFFCHAIN | 0x00
+	PROPOSAL_ONCHAIN        int32 = 0x0100
+	PROPOSAL_OFFCHAIN             = 0x0200
+	PROPOSAL_GOVPARAMS            = PROPOSAL_ONCHAIN | 0x01
+	PROPOSAL_TREASURY_SPEND       = PROPOSAL_ONCHAIN | 0x02
+	PROPOSAL_ALLOW_LIST           = PROPOSAL_ONCHAIN | 0x03
+	PROPOSAL_COMMON               = PROPOSAL_OFFCHAIN | 0x00
 )
 
 const (
-	NOT_CHOICE int32 = -1
+	NOT_CHOICE     int32 = -1
+	ABSTAIN_CHOICE int32 = -2
+	VETO_CHOICE    int32 = -3
 )
 
 func (x *GovProposalHeaderProto) SumVotingPowers() int64 {
@@ -33,6 +37,11 @@ func (x *GovProposalHeaderProto) IsVoter(addr types.Address) bool {
 	return false
 }
 
+// IsDelegatorVoter returns true if the voter has taken over the voting power from its validator.
+func (x *VoterProto) IsDelegatorVoter() bool {
+	return len(x.Delegatee) > 0
+}
+
 func (x *GovProposalHeaderProto) addVoter(addr types.Address, power int64) {
 	v := x.findVoter(addr)
 	if v != nil {
@@ -46,19 +55,52 @@ func (x *GovProposalHeaderProto) addVoter(addr types.Address, power int64) {
 	}
 	x.Voters = append(x.Voters, v)
 }
+
+// findVoter returns the validator voter whose address is `addr`.
 func (x *GovProposalHeaderProto) findVoter(addr types.Address) *VoterProto {
 	for _, v := range x.Voters {
-		if bytes.Equal(v.Address, addr) {
+		if !v.IsDelegatorVoter() && bytes.Equal(v.Address, addr) {
+			return v
+		}
+	}
+	return nil
+}
+
+func (x *GovProposalHeaderProto) findDelegatorVoter(addr, delegatee types.Address) *VoterProto {
+	for _, v := range x.Voters {
+		if v.IsDelegatorVoter() && bytes.Equal(v.Address, addr) && bytes.Equal(v.Delegatee, delegatee) {
 			return v
 		}
 	}
 	return nil
 }
 
-func (x *GovProposalHeaderProto) removeVoter(addr types.Address) *VoterProto {
+// delegatorVotersOf returns the delegator voters who have taken over the voting power from `delegatee`.
+func (x *GovProposalHeaderProto) delegatorVotersOf(delegatee types.Address) []*VoterProto {
+	var ret []*VoterProto
+	for _, v := range x.Voters {
+		if v.IsDelegatorVoter() && bytes.Equal(v.Delegatee, delegatee) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// votersOf returns the validator voter and the delegator voters whose address is `addr`.
+func (x *GovProposalHeaderProto) votersOf(addr types.Address) []*VoterProto {
+	var ret []*VoterProto
+	for _, v := range x.Voters {
+		if bytes.Equal(v.Address, addr) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func (x *GovProposalHeaderProto) removeVoter(voter *VoterProto) *VoterProto {
 	for i := len(x.Voters) - 1; i >= 0; i-- {
 		v := x.Voters[i]
-		if bytes.Equal(v.Address, addr) {
+		if v == voter {
 			x.Voters = append(x.Voters[:i], x.Voters[i+1:]...)
 			return v
 		}
@@ -1,7 +1,6 @@
 package proposal
 
 import (
-	"sort"
 	"sync"
 
 	v1 "github.com/beatoz/beatoz-go/ledger/v1"
@@ -9,6 +8,7 @@ import (
 	"github.com/beatoz/beatoz-go/types"
 	"github.com/beatoz/beatoz-go/types/bytes"
 	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -47,6 +47,18 @@ func (prop *GovProposal) AddOption(opt []byte) {
 	})
 }
 
+// SetTallyRates sets the rates used to decide the result of the proposal.
+// The rates are given in percent and the quorum power is updated with `quorum`.
+func (prop *GovProposal) SetTallyRates(quorum, passThreshold, vetoThreshold int32) {
+	prop.mtx.Lock()
+	defer prop.mtx.Unlock()
+
+	prop.v.Header.QuorumRate = quorum
+	prop.v.Header.PassThresholdRate = passThreshold
+	prop.v.Header.VetoThresholdRate = vetoThreshold
+	prop.v.Header.MajorityPower = prop.v.Header.quorumPower()
+}
+
 func (prop *GovProposal) AddVoter(addr types.Address, power int64) {
 	prop.mtx.Lock()
 	defer prop.mtx.Unlock()
@@ -102,61 +114,140 @@ func (prop *GovProposal) MajorOption() *VoteOptionProto {
 	return prop.v.MajorOption
 }
 
+func (prop *GovProposal) AbstainVotes() int64 {
+	prop.mtx.RLock()
+	defer prop.mtx.RUnlock()
+	return prop.v.AbstainVotes
+}
+
+func (prop *GovProposal) VetoVotes() int64 {
+	prop.mtx.RLock()
+	defer prop.mtx.RUnlock()
+	return prop.v.VetoVotes
+}
+
+// Result returns the result decided by UpdateMajorOption.
+// It is nil until the proposal is tallied.
+func (prop *GovProposal) Result() *TallyResultProto {
+	prop.mtx.RLock()
+	defer prop.mtx.RUnlock()
+	return prop.v.Result
+}
+
+// DoVote makes all the voters of `addr` select `choice`.
+// If `addr` has taken over the voting powers from several validators, all of them are voted with `choice`.
 func (prop *GovProposal) DoVote(addr types.Address, choice int32) xerrors.XError {
 	prop.mtx.Lock()
 	defer prop.mtx.Unlock()
 
-	// cancel previous vote
-	voter := prop.v.Header.findVoter(addr)
-	if voter == nil {
+	voters := prop.v.Header.votersOf(addr)
+	if len(voters) == 0 {
 		return xerrors.ErrNotFoundVoter
 	}
 
-	if voter.Choice == choice {
-		// same option is already selected.
-		return nil
+	for _, voter := range voters {
+		if voter.Choice == choice {
+			// same option is already selected.
+			continue
+		}
+		// cancel previous vote
+		prop.cancelVote(voter)
+		prop.doVote(voter, choice)
+	}
+
+	return nil
+}
+
+// AddDelegatorVoter makes the delegator `addr` a voter with `power` delegated to the validator `delegatee`.
+// The power is taken over from the validator's voting power, so the validator's choice no longer applies to it.
+// It is capped to the remaining voting power of the validator and the taken power is returned.
+// If the delegator voter already exists or `delegatee` is not a validator voter, it does nothing and returns 0.
+func (prop *GovProposal) AddDelegatorVoter(addr, delegatee types.Address, power int64) int64 {
+	prop.mtx.Lock()
+	defer prop.mtx.Unlock()
+
+	if prop.v.Header.findDelegatorVoter(addr, delegatee) != nil {
+		return 0
+	}
+	val := prop.v.Header.findVoter(delegatee)
+	if val == nil {
+		return 0
+	}
+	if power > val.Power {
+		power = val.Power
+	}
+	if power <= 0 {
+		return 0
 	}
 
-	prop.cancelVote(voter)
-	prop.doVote(voter, choice)
+	prop.addVotes(val.Choice, -power)
+	val.Power -= power
 
-	return nil
+	prop.v.Header.Voters = append(prop.v.Header.Voters, &VoterProto{
+		Address:   addr,
+		Power:     power,
+		Choice:    NOT_CHOICE,
+		Delegatee: delegatee,
+	})
+	return power
 }
 
 func (prop *GovProposal) cancelVote(voter *VoterProto) {
-	if voter.Choice >= 0 {
-		opt := prop.v.Options[voter.Choice]
-		opt.CancelVote(voter.Power)
-		voter.Choice = -1
+	if prop.addVotes(voter.Choice, -voter.Power) {
+		voter.Choice = NOT_CHOICE
 	}
 }
 
 func (prop *GovProposal) doVote(voter *VoterProto, choice int32) {
-	if choice >= 0 {
-		opt := prop.v.Options[choice]
-		if opt == nil {
-			return
-		}
-
-		opt.DoVote(voter.Power)
+	if prop.addVotes(choice, voter.Power) {
 		voter.Choice = choice
 	}
 }
 
+// addVotes adds `power` to the votes of `choice`.
+// It returns false if `choice` is not a valid choice.
+func (prop *GovProposal) addVotes(choice int32, power int64) bool {
+	switch {
+	case choice == ABSTAIN_CHOICE:
+		prop.v.AbstainVotes += power
+	case choice == VETO_CHOICE:
+		prop.v.VetoVotes += power
+	case choice >= 0 && choice < int32(len(prop.v.Options)):
+		prop.v.Options[choice].DoVote(power)
+	default:
+		return false
+	}
+	return true
+}
+
+// DoSlash slashes the voting power of the validator `addr`
+// and the powers which the delegator voters have taken over from it.
 func (prop *GovProposal) DoSlash(addr types.Address, rate int32) (int64, xerrors.XError) {
 	prop.mtx.Lock()
 	defer prop.mtx.Unlock()
 
-	voter := prop.v.Header.findVoter(addr)
-	if voter == nil {
+	voters := prop.v.Header.delegatorVotersOf(addr)
+	if voter := prop.v.Header.findVoter(addr); voter != nil {
+		voters = append(voters, voter)
+	}
+	if len(voters) == 0 {
 		return 0, xerrors.ErrNotFoundVoter
 	}
 
-	choice := voter.Choice
-	if choice >= 0 {
-		//  cancel it, if the voter already finishes selection.
-		prop.cancelVote(voter)
+	slash := int64(0)
+	for _, voter := range voters {
+		slash += prop.slashVoter(voter, rate)
 	}
+	prop.v.Header.TotalVotingPower -= slash
+	prop.v.Header.MajorityPower = prop.v.Header.quorumPower()
+
+	return slash, nil
+}
+
+func (prop *GovProposal) slashVoter(voter *VoterProto, rate int32) int64 {
+	choice := voter.Choice
+	//  cancel it, if the voter already finishes selection.
+	prop.cancelVote(voter)
 
 	slash := voter.Power * int64(rate) / 100
 	if slash <= 0 {
@@ -165,17 +256,55 @@ func (prop *GovProposal) DoSlash(addr types.Address, rate int32) (int64, xerrors
 	voter.Power -= slash
 
 	if voter.Power <= 0 {
-		_ = prop.v.Header.removeVoter(addr)
-	} else if choice >= 0 {
+		_ = prop.v.Header.removeVoter(voter)
+	} else if choice != NOT_CHOICE {
 		// do vote again with slashed power
 		prop.doVote(voter, choice)
 	}
-	prop.v.Header.TotalVotingPower -= slash
-	prop.v.Header.MajorityPower = (prop.v.Header.TotalVotingPower * 2) / 3
+	return slash
+}
 
-	return slash, nil
+// IPowerSource provides the current voting powers to UpdatePowers.
+type IPowerSource interface {
+	TotalPowerOf(types.Address) int64
+	VPowerOf(types.Address, types.Address) int64
+}
+
+// UpdatePowers decreases the power of each voter to the power currently bonded,
+// so that the power unbonded during the voting period is not counted.
+// The power of a validator voter is its total power except for the powers taken over by the delegator voters.
+// The power of a voter never increases over the power at the proposal or at the voting.
+func (prop *GovProposal) UpdatePowers(src IPowerSource) {
+	prop.mtx.Lock()
+	defer prop.mtx.Unlock()
+
+	for _, voter := range prop.v.Header.Voters {
+		var power int64
+		if voter.IsDelegatorVoter() {
+			power = src.VPowerOf(voter.Address, voter.Delegatee)
+		} else {
+			power = src.TotalPowerOf(voter.Address)
+			for _, dv := range prop.v.Header.delegatorVotersOf(voter.Address) {
+				power -= src.VPowerOf(dv.Address, dv.Delegatee)
+			}
+		}
+		if power < 0 {
+			power = 0
+		}
+		if power >= voter.Power {
+			continue
+		}
+
+		diff := voter.Power - power
+		prop.addVotes(voter.Choice, -diff)
+		voter.Power = power
+		prop.v.Header.TotalVotingPower -= diff
+	}
+	prop.v.Header.MajorityPower = prop.v.Header.quorumPower()
 }
 
+// UpdateMajorOption tallies the votes and records the result.
+// It returns the option which has passed, or nil if the proposal has failed.
 func (prop *GovProposal) UpdateMajorOption() *VoteOptionProto {
 	prop.mtx.Lock()
 	defer prop.mtx.Unlock()
@@ -184,10 +313,7 @@ func (prop *GovProposal) UpdateMajorOption() *VoteOptionProto {
 }
 
 func (prop *GovProposal) updateMajorOption() *VoteOptionProto {
-	sort.Sort(powerOrderVoteOptions(prop.v.Options))
-	if prop.v.Options[0].Votes >= prop.v.Header.MajorityPower {
-		prop.v.MajorOption = prop.v.Options[0]
-	}
+	prop.v.MajorOption, prop.v.Result = prop.tally()
 	return prop.v.MajorOption
 }
 
@@ -212,9 +338,10 @@ func (prop *GovProposal) MarshalJSON() ([]byte, error) {
 	voters := make([]*voterObj, len(prop.v.Header.Voters))
 	for i, voter := range prop.v.Header.Voters {
 		voters[i] = &voterObj{
-			Address: voter.Address,
-			Power:   voter.Power,
-			Choice:  voter.Choice,
+			Address:   voter.Address,
+			Power:     voter.Power,
+			Choice:    voter.Choice,
+			Delegatee: voter.Delegatee,
 		}
 	}
 
@@ -234,6 +361,24 @@ func (prop *GovProposal) MarshalJSON() ([]byte, error) {
 		}
 	}
 
+	var result *resultObj
+	if prop.v.Result != nil {
+		result = &resultObj{
+			Status:         TallyStatusName(prop.v.Result.Status),
+			VotedPower:     prop.v.Result.VotedPower,
+			QuorumPower:    prop.v.Result.QuorumPower,
+			ThresholdPower: prop.v.Result.ThresholdPower,
+		}
+	}
+
+	deposits := make([]*depositObj, len(prop.v.Deposits))
+	for i, d := range prop.v.Deposits {
+		deposits[i] = &depositObj{
+			Depositor: d.Depositor,
+			Amount:    d.Amount().Dec(),
+		}
+	}
+
 	propObj := proposalObj{
 		PropType:          prop.v.Header.PropType,
 		TxHash:            prop.v.Header.TxHash,
@@ -242,9 +387,19 @@ func (prop *GovProposal) MarshalJSON() ([]byte, error) {
 		ApplyHeight:       prop.v.Header.ApplyHeight,
 		TotalVotingPower:  prop.v.Header.TotalVotingPower,
 		MajorityPower:     prop.v.Header.MajorityPower,
+		QuorumRate:        prop.v.Header.QuorumRate,
+		PassThresholdRate: prop.v.Header.PassThresholdRate,
+		VetoThresholdRate: prop.v.Header.VetoThresholdRate,
+		Proposer:          prop.v.Header.Proposer,
+		DepositEndHeight:  prop.v.Header.DepositEndHeight,
+		MinDeposit:        new(uint256.Int).SetBytes(prop.v.Header.XMinDeposit).Dec(),
+		Deposits:          deposits,
 		Voters:            voters,
 		Options:           options,
 		MajorOption:       majorOption,
+		AbstainVotes:      prop.v.AbstainVotes,
+		VetoVotes:         prop.v.VetoVotes,
+		Result:            result,
 	}
 
 	return jsonx.Marshal(&propObj)
@@ -268,12 +423,38 @@ func (prop *GovProposal) UnmarshalJSON(bz []byte) error {
 	prop.v.Header.ApplyHeight = propObj.ApplyHeight
 	prop.v.Header.TotalVotingPower = propObj.TotalVotingPower
 	prop.v.Header.MajorityPower = propObj.MajorityPower
+	prop.v.Header.QuorumRate = propObj.QuorumRate
+	prop.v.Header.PassThresholdRate = propObj.PassThresholdRate
+	prop.v.Header.VetoThresholdRate = propObj.VetoThresholdRate
+	prop.v.Header.Proposer = propObj.Proposer
+	prop.v.Header.DepositEndHeight = propObj.DepositEndHeight
+	if propObj.MinDeposit != "" {
+		minDeposit, err := uint256.FromDecimal(propObj.MinDeposit)
+		if err != nil {
+			return err
+		}
+		if !minDeposit.IsZero() {
+			prop.v.Header.XMinDeposit = minDeposit.Bytes()
+		}
+	}
+	prop.v.Deposits = nil
+	for _, d := range propObj.Deposits {
+		amt, err := uint256.FromDecimal(d.Amount)
+		if err != nil {
+			return err
+		}
+		prop.v.Deposits = append(prop.v.Deposits, &DepositProto{
+			Depositor: d.Depositor,
+			XAmount:   amt.Bytes(),
+		})
+	}
 	prop.v.Header.Voters = make([]*VoterProto, len(propObj.Voters))
 	for i, voter := range propObj.Voters {
 		prop.v.Header.Voters[i] = &VoterProto{
-			Address: voter.Address,
-			Power:   voter.Power,
-			Choice:  voter.Choice,
+			Address:   voter.Address,
+			Power:     voter.Power,
+			Choice:    voter.Choice,
+			Delegatee: voter.Delegatee,
 		}
 	}
 
@@ -290,13 +471,24 @@ func (prop *GovProposal) UnmarshalJSON(bz []byte) error {
 			Votes:  propObj.MajorOption.Votes,
 		}
 	}
+	prop.v.AbstainVotes = propObj.AbstainVotes
+	prop.v.VetoVotes = propObj.VetoVotes
+	if propObj.Result != nil {
+		prop.v.Result = &TallyResultProto{
+			Status:         tallyStatusOf(propObj.Result.Status),
+			VotedPower:     propObj.Result.VotedPower,
+			QuorumPower:    propObj.Result.QuorumPower,
+			ThresholdPower: propObj.Result.ThresholdPower,
+		}
+	}
 	return nil
 }
 
 type voterObj struct {
-	Address bytes.HexBytes
-	Power   int64
-	Choice  int32
+	Address   bytes.HexBytes
+	Power     int64
+	Choice    int32
+	Delegatee bytes.HexBytes `json:",omitempty"`
 }
 
 type optionObj struct {
@@ -304,6 +496,18 @@ type optionObj struct {
 	Votes  int64
 }
 
+type depositObj struct {
+	Depositor bytes.HexBytes
+	Amount    string
+}
+
+type resultObj struct {
+	Status         string
+	VotedPower     int64
+	QuorumPower    int64
+	ThresholdPower int64
+}
+
 type proposalObj struct {
 	PropType          int32
 	TxHash            bytes.HexBytes
@@ -312,7 +516,17 @@ type proposalObj struct {
 	ApplyHeight       int64
 	TotalVotingPower  int64
 	MajorityPower     int64
+	QuorumRate        int32
+	PassThresholdRate int32
+	VetoThresholdRate int32
+	Proposer          bytes.HexBytes `json:",omitempty"`
+	DepositEndHeight  int64
+	MinDeposit        string
+	Deposits          []*depositObj `json:",omitempty"`
 	Voters            []*voterObj
 	Options           []*optionObj
 	MajorOption       *optionObj
+	AbstainVotes      int64
+	VetoVotes         int64
+	Result            *resultObj `json:",omitempty"`
 }
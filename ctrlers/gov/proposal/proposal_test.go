@@ -34,3 +34,174 @@ func Test_Proposal_Codec(t *testing.T) {
 
 	require.Equal(t, prop, prop2)
 }
+
+func Test_Proposal_DelegatorVoter(t *testing.T) {
+	prop := NewGovProposal(PROPOSAL_COMMON, bytes.RandBytes(32), 10, 100, 3000, 200)
+	val0, val1 := types.RandAddress(), types.RandAddress()
+	dgtor := types.RandAddress()
+	prop.AddVoter(val0, 2000)
+	prop.AddVoter(val1, 1000)
+	prop.AddOption([]byte("yes"))
+	prop.AddOption([]byte("no"))
+
+	require.NoError(t, prop.DoVote(val0, 0))
+	require.Equal(t, int64(2000), prop.Option(0).Votes)
+
+	// the power is capped to the validator's power.
+	require.Equal(t, int64(1000), prop.AddDelegatorVoter(dgtor, val1, 1500))
+	require.Equal(t, int64(500), prop.AddDelegatorVoter(dgtor, val0, 500))
+	require.Equal(t, int64(0), prop.AddDelegatorVoter(dgtor, val0, 500)) // already added
+	require.Equal(t, int64(1500), prop.Option(0).Votes)
+	require.Equal(t, int64(0), prop.FindVoter(val1).Power)
+
+	require.NoError(t, prop.DoVote(dgtor, 1))
+	require.Equal(t, int64(1500), prop.Option(0).Votes)
+	require.Equal(t, int64(1500), prop.Option(1).Votes)
+
+	// the powers taken over from `val0` are slashed together.
+	slashed, xerr := prop.DoSlash(val0, 10)
+	require.NoError(t, xerr)
+	require.Equal(t, int64(200), slashed)
+	require.Equal(t, int64(1350), prop.Option(0).Votes)
+	require.Equal(t, int64(1450), prop.Option(1).Votes)
+	require.Equal(t, int64(2800), prop.Header().TotalVotingPower)
+	require.Equal(t, prop.Header().SumVotingPowers(), prop.Header().TotalVotingPower)
+
+	_, xerr = prop.DoSlash(types.RandAddress(), 10)
+	require.Error(t, xerr)
+
+	jz, err := jsonx.Marshal(prop)
+	require.NoError(t, err)
+	prop2 := &GovProposal{}
+	require.NoError(t, jsonx.Unmarshal(jz, prop2))
+	require.Equal(t, prop, prop2)
+}
+
+func Test_Proposal_Tally(t *testing.T) {
+	newProp := func(quorum, pass, veto int32) (*GovProposal, []types.Address) {
+		prop := NewGovProposal(PROPOSAL_COMMON, bytes.RandBytes(32), 10, 100, 1000, 200)
+		prop.SetTallyRates(quorum, pass, veto)
+		var addrs []types.Address
+		for i := 0; i < 10; i++ {
+			addrs = append(addrs, types.RandAddress())
+			prop.AddVoter(addrs[i], 100)
+		}
+		prop.AddOption([]byte("yes"))
+		prop.AddOption([]byte("no"))
+		return prop, addrs
+	}
+	vote := func(prop *GovProposal, addrs []types.Address, choices ...int32) {
+		for i, c := range choices {
+			require.NoError(t, prop.DoVote(addrs[i], c))
+		}
+	}
+
+	// no quorum
+	prop, addrs := newProp(50, 67, 33)
+	require.Equal(t, int64(500), prop.Header().MajorityPower)
+	vote(prop, addrs, 0, 0, 0, 0)
+	require.Nil(t, prop.UpdateMajorOption())
+	require.Equal(t, TALLY_NO_QUORUM, prop.Result().Status)
+	require.Equal(t, int64(400), prop.Result().VotedPower)
+
+	// the abstain is counted for the quorum, but not for the threshold.
+	prop, addrs = newProp(50, 67, 33)
+	vote(prop, addrs, 0, 0, 0, 1, ABSTAIN_CHOICE, ABSTAIN_CHOICE)
+	require.Equal(t, int64(200), prop.AbstainVotes())
+	opt := prop.UpdateMajorOption()
+	require.NotNil(t, opt)
+	require.Equal(t, []byte("yes"), opt.Option)
+	require.Equal(t, TALLY_PASSED, prop.Result().Status)
+	require.Equal(t, int64(268), prop.Result().ThresholdPower)
+
+	// rejected
+	vote(prop, addrs, 0, 0, 1, 1)
+	require.Nil(t, prop.UpdateMajorOption())
+	require.Equal(t, TALLY_REJECTED, prop.Result().Status)
+
+	// vetoed
+	prop, addrs = newProp(50, 67, 33)
+	vote(prop, addrs, 0, 0, 0, 0, VETO_CHOICE, VETO_CHOICE)
+	require.Equal(t, int64(200), prop.VetoVotes())
+	require.Nil(t, prop.UpdateMajorOption())
+	require.Equal(t, TALLY_VETOED, prop.Result().Status)
+	require.True(t, prop.Result().IsVetoed())
+
+	// changing the choice moves the votes from veto.
+	vote(prop, addrs, 0, 0, 0, 0, 0)
+	require.Equal(t, int64(100), prop.VetoVotes())
+	require.NotNil(t, prop.UpdateMajorOption())
+	require.True(t, prop.Result().IsPassed())
+
+	// the slashed power is also removed from veto.
+	_, xerr := prop.DoSlash(addrs[5], 50)
+	require.NoError(t, xerr)
+	require.Equal(t, int64(50), prop.VetoVotes())
+	require.Equal(t, int64(950), prop.Header().TotalVotingPower)
+	require.Equal(t, int64(475), prop.Header().MajorityPower)
+
+	jz, err := jsonx.Marshal(prop)
+	require.NoError(t, err)
+	prop2 := &GovProposal{}
+	require.NoError(t, jsonx.Unmarshal(jz, prop2))
+	require.Equal(t, prop, prop2)
+
+	// without the tally rates, the option should get the 2/3 of the total power.
+	prop, addrs = newProp(0, 0, 0)
+	require.Equal(t, int64(666), prop.Header().MajorityPower)
+	vote(prop, addrs, 0, 0, 0, 0, 0, 0, VETO_CHOICE)
+	require.Nil(t, prop.UpdateMajorOption())
+	require.Equal(t, TALLY_REJECTED, prop.Result().Status)
+	vote(prop, addrs, 0, 0, 0, 0, 0, 0, 0)
+	require.NotNil(t, prop.UpdateMajorOption())
+	require.True(t, prop.Result().IsPassed())
+}
+
+func Test_AllowList(t *testing.T) {
+	addr0, addr1, addr2 := types.RandAddress(), types.RandAddress(), types.RandAddress()
+
+	list := NewAllowList(addr0, addr1, addr0)
+	require.Len(t, list.Accounts(), 2)
+	require.True(t, list.Contains(addr0))
+	require.True(t, list.Contains(addr1))
+	require.False(t, list.Contains(addr2))
+
+	list.Add(addr2)
+	list.Remove(addr0, types.RandAddress())
+	require.Len(t, list.Accounts(), 2)
+	require.False(t, list.Contains(addr0))
+	require.True(t, list.Contains(addr2))
+
+	// the accounts are sorted and encoded deterministically.
+	bz, xerr := list.Encode()
+	require.NoError(t, xerr)
+	list2 := NewAllowList(addr2, addr1)
+	bz2, xerr := list2.Encode()
+	require.NoError(t, xerr)
+	require.Equal(t, bz, bz2)
+
+	decoded := &AllowList{}
+	require.NoError(t, decoded.Decode(nil, bz))
+	require.Equal(t, list.Accounts(), decoded.Accounts())
+}
+
+func Test_DecodeAllowListChange(t *testing.T) {
+	addr0, addr1 := types.RandAddress(), types.RandAddress()
+
+	change, xerr := DecodeAllowListChange([]byte(`{"target":"` + types.ZeroAddress().String() + `","add":["` + addr0.String() + `"],"remove":["` + addr1.String() + `"]}`))
+	require.NoError(t, xerr)
+	require.True(t, types.IsZeroAddress(change.Target))
+	require.Equal(t, []types.Address{addr0}, change.Add)
+	require.Equal(t, []types.Address{addr1}, change.Remove)
+
+	for _, opt := range []string{
+		`{"add":["` + addr0.String() + `"]}`,
+		`{"target":"` + addr1.String() + `","add":["` + addr0.String() + `"],"unrestrict":true}`,
+		`{"target":"` + addr1.String() + `","add":["` + addr0.String() + `"],"remove":["` + addr0.String() + `"]}`,
+		`{"target":"` + addr1.String() + `","add":["` + types.ZeroAddress().String() + `"]}`,
+		`not json`,
+	} {
+		_, xerr := DecodeAllowListChange([]byte(opt))
+		require.Error(t, xerr, opt)
+	}
+}
@@ -0,0 +1,104 @@
+package proposal
+
+const (
+	TALLY_NONE int32 = iota
+	TALLY_PASSED
+	TALLY_REJECTED
+	TALLY_NO_QUORUM
+	TALLY_VETOED
+)
+
+var tallyStatusNames = map[int32]string{
+	TALLY_NONE:      "none",
+	TALLY_PASSED:    "passed",
+	TALLY_REJECTED:  "rejected",
+	TALLY_NO_QUORUM: "no_quorum",
+	TALLY_VETOED:    "vetoed",
+}
+
+func TallyStatusName(status int32) string {
+	return tallyStatusNames[status]
+}
+
+func tallyStatusOf(name string) int32 {
+	for k, v := range tallyStatusNames {
+		if v == name {
+			return k
+		}
+	}
+	return TALLY_NONE
+}
+
+// IsPassed returns true if the proposal has passed and its major option should be applied.
+func (x *TallyResultProto) IsPassed() bool {
+	return x.GetStatus() == TALLY_PASSED
+}
+
+// IsVetoed returns true if the proposal has failed by the veto votes.
+func (x *TallyResultProto) IsVetoed() bool {
+	return x.GetStatus() == TALLY_VETOED
+}
+
+// ratePower returns `rate`% of `power` without overflow.
+func ratePower(power int64, rate int32) int64 {
+	return power/100*int64(rate) + power%100*int64(rate)/100
+}
+
+// quorumPower returns the voting power required for the quorum.
+// A proposal made before the tally rates were introduced has no quorum rate,
+// and the 2/3 of the total voting power is required for an option as before.
+func (x *GovProposalHeaderProto) quorumPower() int64 {
+	if x.QuorumRate <= 0 {
+		return (x.TotalVotingPower * 2) / 3
+	}
+	return ratePower(x.TotalVotingPower, x.QuorumRate)
+}
+
+// tally decides the result of the proposal.
+// The quorum is checked with the all voted powers including abstain and veto.
+// If the veto votes reach the veto threshold of the voted powers, the proposal is vetoed.
+// The veto is disabled when the veto threshold rate is 0.
+// Otherwise, the option having the most votes passes
+// if it reaches the pass threshold of the voted powers except for abstain.
+func (prop *GovProposal) tally() (*VoteOptionProto, *TallyResultProto) {
+	hdr := prop.v.Header
+
+	var top *VoteOptionProto
+	voted := prop.v.AbstainVotes + prop.v.VetoVotes
+	for _, opt := range prop.v.Options {
+		voted += opt.Votes
+		if top == nil || opt.Votes > top.Votes {
+			top = opt
+		}
+	}
+
+	result := &TallyResultProto{
+		VotedPower:  voted,
+		QuorumPower: hdr.MajorityPower,
+	}
+
+	if hdr.QuorumRate <= 0 {
+		// legacy rule
+		result.ThresholdPower = hdr.MajorityPower
+		if top != nil && top.Votes >= hdr.MajorityPower {
+			result.Status = TALLY_PASSED
+			return top, result
+		}
+		result.Status = TALLY_REJECTED
+		return nil, result
+	}
+
+	result.ThresholdPower = ratePower(voted-prop.v.AbstainVotes, hdr.PassThresholdRate)
+	if voted < hdr.MajorityPower || voted <= 0 {
+		result.Status = TALLY_NO_QUORUM
+	} else if hdr.VetoThresholdRate > 0 && prop.v.VetoVotes > 0 &&
+		prop.v.VetoVotes >= ratePower(voted, hdr.VetoThresholdRate) {
+		result.Status = TALLY_VETOED
+	} else if top != nil && top.Votes > 0 && top.Votes >= result.ThresholdPower {
+		result.Status = TALLY_PASSED
+		return top, result
+	} else {
+		result.Status = TALLY_REJECTED
+	}
+	return nil, result
+}
@@ -0,0 +1,159 @@
+package proposal
+
+import (
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+	"google.golang.org/protobuf/proto"
+)
+
+// TreasurySpend is the option of PROPOSAL_TREASURY_SPEND.
+// `Amount` is transferred from the treasury to `Recipient` in `Instalments` parts,
+// one part every `IntervalBlocks` blocks from the applying height.
+type TreasurySpend struct {
+	Recipient      types.Address
+	Amount         *uint256.Int
+	Instalments    int64
+	IntervalBlocks int64
+}
+
+type treasurySpendObj struct {
+	Recipient      types.Address `json:"recipient"`
+	Amount         string        `json:"amount"`
+	Instalments    int64         `json:"instalments,omitempty"`
+	IntervalBlocks int64         `json:"intervalBlocks,omitempty"`
+}
+
+func (ts *TreasurySpend) MarshalJSON() ([]byte, error) {
+	amt := "0"
+	if ts.Amount != nil {
+		amt = ts.Amount.Dec()
+	}
+	return jsonx.Marshal(&treasurySpendObj{
+		Recipient:      ts.Recipient,
+		Amount:         amt,
+		Instalments:    ts.Instalments,
+		IntervalBlocks: ts.IntervalBlocks,
+	})
+}
+
+func (ts *TreasurySpend) UnmarshalJSON(bz []byte) error {
+	obj := &treasurySpendObj{}
+	if err := jsonx.Unmarshal(bz, obj); err != nil {
+		return err
+	}
+	amt, err := uint256.FromDecimal(obj.Amount)
+	if err != nil {
+		return err
+	}
+	ts.Recipient = obj.Recipient
+	ts.Amount = amt
+	ts.Instalments = obj.Instalments
+	ts.IntervalBlocks = obj.IntervalBlocks
+	return nil
+}
+
+// DecodeTreasurySpend parses and validates the option of PROPOSAL_TREASURY_SPEND.
+// The zero `Instalments` is regarded as 1.
+func DecodeTreasurySpend(opt []byte) (*TreasurySpend, xerrors.XError) {
+	ts := &TreasurySpend{}
+	if err := jsonx.Unmarshal(opt, ts); err != nil {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrap(err)
+	}
+	if len(ts.Recipient) != types.AddrSize || types.IsZeroAddress(ts.Recipient) {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong recipient: %v", ts.Recipient)
+	}
+	if ts.Amount == nil || ts.Amount.IsZero() {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong amount: must be greater than 0")
+	}
+	if ts.Instalments < 0 || ts.IntervalBlocks < 0 {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong instalments: instalments:%v, intervalBlocks:%v", ts.Instalments, ts.IntervalBlocks)
+	}
+	if ts.Instalments == 0 {
+		ts.Instalments = 1
+	}
+	if ts.Instalments > 1 && ts.IntervalBlocks == 0 {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong intervalBlocks: must be greater than 0 when instalments > 1")
+	}
+	if uint256.NewInt(uint64(ts.Instalments)).Gt(ts.Amount) {
+		return nil, xerrors.ErrInvalidTrxPayloadParams.Wrapf("wrong instalments: more than amount")
+	}
+	return ts, nil
+}
+
+// TreasuryPayout is the remaining payment of the applied PROPOSAL_TREASURY_SPEND.
+type TreasuryPayout struct {
+	v TreasuryPayoutProto
+}
+
+func NewTreasuryPayout(txhash []byte, ts *TreasurySpend, startHeight int64) *TreasuryPayout {
+	return &TreasuryPayout{
+		v: TreasuryPayoutProto{
+			TxHash:         txhash,
+			Recipient:      ts.Recipient,
+			XAmount:        ts.Amount.Bytes(),
+			Instalments:    ts.Instalments,
+			IntervalBlocks: ts.IntervalBlocks,
+			NextHeight:     startHeight,
+		},
+	}
+}
+
+func (payout *TreasuryPayout) Encode() ([]byte, xerrors.XError) {
+	if bz, err := proto.Marshal(&payout.v); err != nil {
+		return bz, xerrors.From(err)
+	} else {
+		return bz, nil
+	}
+}
+
+func (payout *TreasuryPayout) Decode(k, v []byte) xerrors.XError {
+	if err := proto.Unmarshal(v, &payout.v); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
+
+var _ v1.ILedgerItem = (*TreasuryPayout)(nil)
+
+func (payout *TreasuryPayout) TxHash() []byte {
+	return payout.v.TxHash
+}
+
+func (payout *TreasuryPayout) Recipient() types.Address {
+	return payout.v.Recipient
+}
+
+// Amount returns the amount which has not been paid yet.
+func (payout *TreasuryPayout) Amount() *uint256.Int {
+	return new(uint256.Int).SetBytes(payout.v.XAmount)
+}
+
+// Instalments returns the number of the remaining instalments.
+func (payout *TreasuryPayout) Instalments() int64 {
+	return payout.v.Instalments
+}
+
+func (payout *TreasuryPayout) NextHeight() int64 {
+	return payout.v.NextHeight
+}
+
+// NextInstalment returns the amount to be paid at NextHeight.
+// The last instalment pays all the remaining amount.
+func (payout *TreasuryPayout) NextInstalment() *uint256.Int {
+	if payout.v.Instalments <= 1 {
+		return payout.Amount()
+	}
+	return new(uint256.Int).Div(payout.Amount(), uint256.NewInt(uint64(payout.v.Instalments)))
+}
+
+// Paid deducts `amt` from the remaining amount and schedules the next instalment.
+// It returns true if all instalments have been paid.
+func (payout *TreasuryPayout) Paid(amt *uint256.Int) bool {
+	payout.v.XAmount = new(uint256.Int).Sub(payout.Amount(), amt).Bytes()
+	payout.v.Instalments--
+	payout.v.NextHeight += payout.v.IntervalBlocks
+	return payout.v.Instalments <= 0
+}
@@ -5,6 +5,8 @@ import (
 	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
 	v1 "github.com/beatoz/beatoz-go/ledger/v1"
 	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types"
+	abytes "github.com/beatoz/beatoz-go/types/bytes"
 	"github.com/beatoz/beatoz-go/types/xerrors"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 )
@@ -29,7 +31,7 @@ func (ctrler *GovCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertypes.O
 			if xerr := atledger.Seek(v1.KeyPrefixProposal, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
 				prop, _ := item.(*proposal.GovProposal)
 				readProposals = append(readProposals, &_response{
-					Status:   "voting",
+					Status:   activeStatus(prop),
 					Proposal: prop,
 				})
 				return nil
@@ -68,6 +70,9 @@ func (ctrler *GovCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertypes.O
 				}
 			}
 			prop, _ := item.(*proposal.GovProposal)
+			if resp.Status == "voting" {
+				resp.Status = activeStatus(prop)
+			}
 			resp.Proposal = prop
 
 			v, err := jsonx.Marshal(resp)
@@ -77,6 +82,12 @@ func (ctrler *GovCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertypes.O
 
 			return v, nil
 		}
+	case "proposals":
+		return queryProposals(atledger, req.Data)
+	case "proposal_diff":
+		return queryProposalDiff(atledger, req.Data)
+	case "allow_list":
+		return queryAllowList(atledger, req.Data)
 	case "gov_params":
 		govParams, xerr := atledger.Get(v1.LedgerKeyGovParams())
 		if xerr != nil {
@@ -91,3 +102,173 @@ func (ctrler *GovCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertypes.O
 
 	return nil, nil
 }
+
+// queryProposals returns the proposals in the page.
+// The proposals being voted or waiting for the deposit are listed before the frozen proposals.
+func queryProposals(atledger v1.IImitable, data []byte) ([]byte, xerrors.XError) {
+	type _response struct {
+		Status   string                `json:"status"`
+		Proposal *proposal.GovProposal `json:"proposal"`
+	}
+
+	qry := &ctrlertypes.ListQuery{}
+	if len(data) > 0 {
+		if err := jsonx.Unmarshal(data, qry); err != nil {
+			return nil, xerrors.ErrInvalidQueryParams.Wrap(err)
+		}
+	}
+
+	var prefixes [][]byte
+	var statuses []string
+	switch qry.Status {
+	case "":
+		prefixes = [][]byte{v1.KeyPrefixProposal, v1.KeyPrefixFrozenProp}
+		statuses = []string{"voting", "frozen"}
+	case "voting", "deposit":
+		prefixes = [][]byte{v1.KeyPrefixProposal}
+		statuses = []string{"voting"}
+	case "frozen":
+		prefixes = [][]byte{v1.KeyPrefixFrozenProp}
+		statuses = []string{"frozen"}
+	default:
+		return nil, xerrors.ErrInvalidQueryParams.Wrapf("invalid proposal status: %v", qry.Status)
+	}
+
+	var readProposals []*_response
+	for i, prefix := range prefixes {
+		status := statuses[i]
+		if xerr := atledger.Seek(prefix, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+			prop, _ := item.(*proposal.GovProposal)
+			status := status
+			if status == "voting" {
+				status = activeStatus(prop)
+			}
+			if qry.Status != "" && qry.Status != status {
+				return nil
+			}
+			readProposals = append(readProposals, &_response{
+				Status:   status,
+				Proposal: prop,
+			})
+			return nil
+		}); xerr != nil {
+			return nil, xerrors.ErrQuery.Wrap(xerr)
+		}
+	}
+
+	page, perPage := ctrlertypes.NormalizePage(qry.Page, qry.PerPage)
+	start, end := ctrlertypes.PageRange(len(readProposals), page, perPage)
+	v, err := jsonx.Marshal(&ctrlertypes.PageResult{
+		Total:   len(readProposals),
+		Page:    page,
+		PerPage: perPage,
+		Items:   readProposals[start:end],
+	})
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return v, nil
+}
+
+// queryProposalDiff returns the changes of the governance parameters proposed by each option of
+// the PROPOSAL_GOVPARAMS proposal made by the tx `txhash`.
+// The changes are compared with the parameters at the queried height.
+// The error of the option is also returned if the option is not valid for the parameters.
+func queryProposalDiff(atledger v1.IImitable, txhash []byte) ([]byte, xerrors.XError) {
+	type _optionDiff struct {
+		Option  int                          `json:"option"`
+		Error   string                       `json:"error,omitempty"`
+		Changes []*ctrlertypes.GovParamsDiff `json:"changes"`
+	}
+	type _response struct {
+		TxHash  abytes.HexBytes `json:"txHash"`
+		Options []*_optionDiff  `json:"options"`
+	}
+
+	if len(txhash) == 0 {
+		return nil, xerrors.ErrInvalidQueryParams.Wrapf("txhash is required")
+	}
+	item, xerr := atledger.Get(v1.LedgerKeyProposal(txhash))
+	if xerr != nil && xerr.Code() == xerrors.ErrCodeNotFoundResult {
+		item, xerr = atledger.Get(v1.LedgerKeyFrozenProp(txhash))
+	}
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	prop, _ := item.(*proposal.GovProposal)
+	if prop.Header().PropType != proposal.PROPOSAL_GOVPARAMS {
+		return nil, xerrors.ErrInvalidQueryParams.Wrapf("not a governance parameters proposal")
+	}
+
+	item, xerr = atledger.Get(v1.LedgerKeyGovParams())
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	current, _ := item.(*ctrlertypes.GovParams)
+
+	resp := &_response{TxHash: txhash}
+	for i, opt := range prop.Options() {
+		optDiff := &_optionDiff{Option: i}
+		if proposed, xerr := ctrlertypes.MergeGovParamsJSON(current, opt.Option); xerr != nil {
+			optDiff.Error = xerr.Error()
+		} else {
+			if xerr := proposed.Validate(); xerr != nil {
+				optDiff.Error = xerr.Error()
+			} else if xerr := ctrlertypes.ValidateEVMForksChange(current, proposed, prop.Header().ApplyHeight); xerr != nil {
+				optDiff.Error = xerr.Error()
+			}
+			if optDiff.Changes, xerr = ctrlertypes.DiffGovParams(current, proposed); xerr != nil {
+				return nil, xerrors.ErrQuery.Wrap(xerr)
+			}
+		}
+		resp.Options = append(resp.Options, optDiff)
+	}
+
+	v, err := jsonx.Marshal(resp)
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return v, nil
+}
+
+// queryAllowList returns the allow-list of `target`.
+// `target` is the zero address (or empty) for the deployers, or the contract address for its callers.
+// If `restricted` is false, `target` has no allow-list and is open to everyone.
+func queryAllowList(atledger v1.IImitable, target []byte) ([]byte, xerrors.XError) {
+	type _response struct {
+		Target     types.Address   `json:"target"`
+		Restricted bool            `json:"restricted"`
+		Accounts   []types.Address `json:"accounts"`
+	}
+
+	if len(target) == 0 {
+		target = types.ZeroAddress()
+	}
+	if len(target) != types.AddrSize {
+		return nil, xerrors.ErrInvalidQueryParams.Wrapf("wrong target address: %x", target)
+	}
+
+	resp := &_response{Target: target}
+	item, xerr := atledger.Get(v1.LedgerKeyAllowList(target))
+	if xerr != nil && xerr.Code() != xerrors.ErrCodeNotFoundResult {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	} else if xerr == nil {
+		list, _ := item.(*proposal.AllowList)
+		resp.Restricted = true
+		resp.Accounts = list.Accounts()
+	}
+
+	v, err := jsonx.Marshal(resp)
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return v, nil
+}
+
+// activeStatus returns the status of the proposal not frozen yet.
+func activeStatus(prop *proposal.GovProposal) string {
+	if prop.IsDepositPeriod() {
+		return "deposit"
+	}
+	return "voting"
+}
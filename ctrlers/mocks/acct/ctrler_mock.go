@@ -140,6 +140,14 @@ func (mock *AcctHandlerMock) SetBalance(addr types.Address, amt *uint256.Int, ex
 	return nil
 }
 
+func (mock *AcctHandlerMock) FindToken(addr types.Address, exec bool) *ctrlertypes.Token {
+	return nil
+}
+
+func (mock *AcctHandlerMock) TokenBalanceOf(token, holder types.Address, exec bool) *uint256.Int {
+	return uint256.NewInt(0)
+}
+
 func (mock *AcctHandlerMock) SimuAcctCtrlerAt(i int64) (ctrlertypes.IAccountHandler, xerrors.XError) {
 	return &AcctHandlerMock{}, nil
 }
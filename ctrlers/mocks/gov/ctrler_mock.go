@@ -2,6 +2,7 @@ package gov
 
 import (
 	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
 	"github.com/beatoz/beatoz-go/types/xerrors"
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 )
@@ -40,4 +41,12 @@ func (mock *GovHandlerMock) ExecuteTrx(context *ctrlertypes.TrxContext) xerrors.
 	panic("implement me")
 }
 
+func (mock *GovHandlerMock) IsAllowedDeployer(addr types.Address, exec bool) bool {
+	return true
+}
+
+func (mock *GovHandlerMock) IsAllowedCaller(contract, caller types.Address, exec bool) bool {
+	return true
+}
+
 var _ ctrlertypes.IGovHandler = (*GovHandlerMock)(nil)
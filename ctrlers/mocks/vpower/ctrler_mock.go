@@ -140,6 +140,44 @@ func (mock *VPowerHandlerMock) DelegatedPowerOf(addr types.Address) int64 {
 	panic("implement me")
 }
 
+func (mock *VPowerHandlerMock) VPowerOf(from, to types.Address) int64 {
+	if vpow, ok := mock.mapVPowers[from.String()+to.String()]; ok {
+		return vpow.SumPower
+	}
+	return 0
+}
+
+// Delegate adds `power` delegated from `from` to the delegatee `to`.
+// If `power` is negative, it is unbonded.
+func (mock *VPowerHandlerMock) Delegate(from, to types.Address, power int64) {
+	var dgtee *vpower.Delegatee
+	for _, v := range mock.Delegatees {
+		if bytes.Equal(v.Address(), to) {
+			dgtee = v
+			break
+		}
+	}
+	if dgtee == nil {
+		panic("not found delegatee")
+	}
+
+	key := from.String() + to.String()
+	vpow, ok := mock.mapVPowers[key]
+	if !ok {
+		vpow = vpower.NewVPower(from, to)
+		mock.mapVPowers[key] = vpow
+		dgtee.AddDelegator(from)
+	}
+	if power >= 0 {
+		vpow.AddPowerWithTxHash(power, 1, bytes.RandBytes(32))
+		dgtee.AddPower(from, power)
+	} else {
+		vpow.SumPower += power
+		dgtee.DelPower(from, -power)
+	}
+	mock.totalPower += power
+}
+
 func (mock *VPowerHandlerMock) PickAddress(i int) types.Address {
 	return mock.Delegatees[i].Address()
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	cfg "github.com/beatoz/beatoz-go/cmd/config"
+	"github.com/beatoz/beatoz-go/ctrlers/crisis"
 	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
 	v1 "github.com/beatoz/beatoz-go/ledger/v1"
 	"github.com/beatoz/beatoz-go/types/xerrors"
@@ -17,11 +18,16 @@ type SupplyCtrler struct {
 
 	lastTotalSupply *Supply
 
+	// the readers of the amounts summed up by the total-supply invariant.
+	balanceReader crisis.IBalanceReader
+	powerReader   crisis.IPowerReader
+
 	reqCh  chan *reqMint
 	respCh chan *respMint
 
-	logger tmlog.Logger
-	mtx    sync.RWMutex
+	metrics *Metrics
+	logger  tmlog.Logger
+	mtx     sync.RWMutex
 }
 
 func defaultNewItem(key v1.LedgerKey) v1.ILedgerItem {
@@ -59,17 +65,27 @@ func NewSupplyCtrler(config *cfg.Config, logger tmlog.Logger) (*SupplyCtrler, xe
 		lastTotalSupply: total,
 		reqCh:           reqCh,
 		respCh:          respCh,
+		metrics:         NopMetrics(),
 		logger:          lg,
 		mtx:             sync.RWMutex{},
 	}, nil
 }
 
+func (ctrler *SupplyCtrler) SetMetrics(metrics *Metrics) {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	ctrler.metrics = metrics
+	ctrler.metrics.TotalSupply.Set(amountToFloat(ctrler.lastTotalSupply.GetTotalSupply()))
+}
+
 func (ctrler *SupplyCtrler) InitLedger(req interface{}) xerrors.XError {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
 
 	// it will be saved at Commit
 	ctrler.lastTotalSupply.AdjustAdd(1, req.(*uint256.Int))
+	ctrler.metrics.TotalSupply.Set(amountToFloat(ctrler.lastTotalSupply.GetTotalSupply()))
 	return nil
 }
 
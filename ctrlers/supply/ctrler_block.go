@@ -46,6 +46,7 @@ func (ctrler *SupplyCtrler) EndBlock(bctx *ctrlertypes.BlockContext) ([]abcitype
 			Type: "supply.mint",
 			Attributes: []abcitypes.EventAttribute{
 				{Key: []byte("mint"), Value: []byte(resp.sumMintedAmt.Dec()), Index: false},
+				{Key: []byte("treasury"), Value: []byte(resp.treasuryAmt.Dec()), Index: false},
 				{Key: []byte("total.supply"), Value: []byte(ctrler.lastTotalSupply.totalSupply.Dec()), Index: false},
 			},
 		})
@@ -18,5 +18,6 @@ func (ctrler *SupplyCtrler) burn(height int64, amt *uint256.Int) xerrors.XError
 		return xerrors.ErrInvalidAmount
 	}
 	ctrler.lastTotalSupply.AdjustSub(height, amt)
+	ctrler.metrics.TotalSupply.Set(amountToFloat(ctrler.lastTotalSupply.GetTotalSupply()))
 	return nil
 }
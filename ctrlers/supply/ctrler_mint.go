@@ -25,6 +25,7 @@ type respMint struct {
 	xerr         xerrors.XError
 	sumMintedAmt *uint256.Int
 	rewards      []*mintedReward
+	treasuryAmt  *uint256.Int
 }
 
 func (ctrler *SupplyCtrler) RequestMint(bctx *ctrlertypes.BlockContext) {
@@ -63,7 +64,21 @@ func (ctrler *SupplyCtrler) waitMint(bctx *ctrlertypes.BlockContext) (*respMint,
 		return nil, xerr
 	}
 
+	// transfer the treasury share of minted amount to the treasury account.
+	if resp.treasuryAmt != nil && resp.treasuryAmt.Sign() > 0 {
+		if xerr := bctx.AcctHandler.AddBalance(btztypes.TreasuryAddress(), resp.treasuryAmt, true); xerr != nil {
+			return nil, xerr
+		}
+	}
+
 	ctrler.lastTotalSupply.Add(bctx.Height(), resp.sumMintedAmt)
+
+	ctrler.metrics.MintedSupply.Set(amountToFloat(resp.sumMintedAmt))
+	ctrler.metrics.TotalSupply.Set(amountToFloat(ctrler.lastTotalSupply.GetTotalSupply()))
+	ctrler.metrics.RewardRecipients.Set(float64(len(resp.rewards)))
+	for _, rwd := range resp.rewards {
+		ctrler.metrics.RewardAmount.Observe(amountToFloat(rwd.amt))
+	}
 	return resp, nil
 }
 
@@ -114,6 +129,11 @@ func computeIssuanceAndRewardRoutine(reqCh chan *reqMint, respCh chan *respMint)
 			continue
 		}
 
+		// the GovParams.TreasuryInflationRate % of added supply is sent to the treasury.
+		trsyRate := decimal.NewFromInt(int64(bctx.GovHandler.TreasuryInflationRate())).Div(decimal.NewFromInt(100))
+		toTreasury := addedSupply.Mul(trsyRate).Floor()
+		addedSupply = addedSupply.Sub(toTreasury)
+
 		decWaAll, _ := waAll.ToDecimal()
 		decWaVals, _ := waVals.ToDecimal()
 		rwdToVals := addedSupply.Mul(valRate).Floor()
@@ -157,11 +177,15 @@ func computeIssuanceAndRewardRoutine(reqCh chan *reqMint, respCh chan *respMint)
 			}
 		}
 
-		// sumMintedAmt should be equal to addedSupply.
+		treasuryAmt := uint256.MustFromBig(toTreasury.BigInt())
+		_ = sumMintedAmt.Add(sumMintedAmt, treasuryAmt)
+
+		// sumMintedAmt should be equal to addedSupply + toTreasury.
 		respCh <- &respMint{
 			xerr:         nil,
 			sumMintedAmt: sumMintedAmt,
 			rewards:      rewards,
+			treasuryAmt:  treasuryAmt,
 		}
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	govmock "github.com/beatoz/beatoz-go/ctrlers/mocks/gov"
 	vpowmock "github.com/beatoz/beatoz-go/ctrlers/mocks/vpower"
 	"github.com/beatoz/beatoz-go/ctrlers/types"
 	"github.com/beatoz/beatoz-go/ctrlers/vpower"
@@ -111,6 +112,53 @@ func Test_Mint(t *testing.T) {
 	require.NoError(t, os.RemoveAll(config.RootDir))
 }
 
+func Test_MintToTreasury(t *testing.T) {
+	require.NoError(t, os.RemoveAll(config.RootDir))
+
+	initSupply := btztypes.PowerToAmount(350_000_000)
+	ctrler, xerr := initLedger(initSupply)
+	require.NoError(t, xerr)
+
+	params := types.NewGovParams(1)
+	params.SetValue(func(v *types.GovParamsProto) {
+		v.TreasuryInflationRate = 10
+	})
+	localGovMock := govmock.NewGovHandlerMock(params)
+
+	valWals := make([]*web3.Wallet, 10)
+	for i := 0; i < len(valWals); i++ {
+		valWals[i] = acctMock.GetWallet(i)
+	}
+	vpowMock := vpowmock.NewVPowerHandlerMock(valWals, len(valWals))
+
+	treasuryBalance := uint256.NewInt(0)
+	if acct := acctMock.FindAccount(btztypes.TreasuryAddress(), true); acct != nil {
+		treasuryBalance = acct.GetBalance()
+	}
+
+	currHeight := localGovMock.InflationCycleBlocks()
+	bctx := types.TempBlockContext("mint-test-chain", currHeight, time.Now(), localGovMock, acctMock, nil, nil, vpowMock)
+	ctrler.requestMint(bctx)
+	result, xerr := ctrler.waitMint(bctx)
+	require.NoError(t, xerr)
+
+	// 10% of the minted amount is sent to the treasury.
+	require.True(t, result.treasuryAmt.Sign() > 0)
+	expectedTreasury := new(uint256.Int).Div(new(uint256.Int).Mul(result.sumMintedAmt, uint256.NewInt(10)), uint256.NewInt(100))
+	require.LessOrEqual(t, absDiff(expectedTreasury, result.treasuryAmt).Uint64(), uint64(1))
+	require.Equal(t, new(uint256.Int).Add(treasuryBalance, result.treasuryAmt), acctMock.FindAccount(btztypes.TreasuryAddress(), true).GetBalance())
+
+	sumRwd := result.treasuryAmt.Clone()
+	for _, rwd := range result.rewards {
+		_ = sumRwd.Add(sumRwd, rwd.amt)
+	}
+	require.Equal(t, result.sumMintedAmt, sumRwd)
+	require.Equal(t, new(uint256.Int).Add(initSupply, result.sumMintedAmt), ctrler.lastTotalSupply.GetTotalSupply())
+
+	require.NoError(t, ctrler.Close())
+	require.NoError(t, os.RemoveAll(config.RootDir))
+}
+
 //// the following results are calculated by google spreadsheets
 //var expectedSupplys = []struct {
 //	height int64
@@ -19,6 +19,8 @@ func (ctrler *SupplyCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertype
 		return ctrler.queryReward(req.Height, types.Address(req.Data))
 	case "total_supply":
 		return ctrler.queryTotalSupply(req.Height)
+	case "rewards":
+		return ctrler.queryRewards(req.Height, req.Data)
 	default:
 		return nil, xerrors.ErrQuery.Wrapf("unknown query path")
 	}
@@ -63,3 +65,62 @@ func (ctrler *SupplyCtrler) queryTotalSupply(height int64) ([]byte, xerrors.XErr
 
 	return []byte(fmt.Sprintf("\"%v\"", supply.totalSupply.Dec())), nil
 }
+
+// queryRewards returns the rewards in the page, in ascending order of address.
+func (ctrler *SupplyCtrler) queryRewards(height int64, data []byte) ([]byte, xerrors.XError) {
+	type respReward struct {
+		Address   types.Address `json:"address"`
+		Issued    string        `json:"issued"`
+		Withdrawn string        `json:"withdrawn"`
+		Slashed   string        `json:"slashed"`
+		Cumulated string        `json:"cumulated"`
+		Height    int64         `json:"height,string"`
+	}
+
+	qry := &ctrlertypes.ListQuery{}
+	if len(data) > 0 {
+		if err := jsonx.Unmarshal(data, qry); err != nil {
+			return nil, xerrors.ErrInvalidQueryParams.Wrap(err)
+		}
+	}
+	page, perPage := ctrlertypes.NormalizePage(qry.Page, qry.PerPage)
+
+	atledger, xerr := ctrler.supplyState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	var rwds []*Reward
+	xerr = atledger.Seek(v1.KeyPrefixReward, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		rwd, _ := item.(*Reward)
+		rwds = append(rwds, rwd)
+		return nil
+	})
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	start, end := ctrlertypes.PageRange(len(rwds), page, perPage)
+	items := make([]*respReward, 0, end-start)
+	for _, rwd := range rwds[start:end] {
+		items = append(items, &respReward{
+			Address:   rwd.Address(),
+			Issued:    rwd.MintedAmount().Dec(),
+			Withdrawn: rwd.WithdrawnAmount().Dec(),
+			Slashed:   rwd.SlashedAmount().Dec(),
+			Cumulated: rwd.CumulatedAmount().Dec(),
+			Height:    rwd.Height(),
+		})
+	}
+
+	bz, err := jsonx.Marshal(&ctrlertypes.PageResult{
+		Total:   len(rwds),
+		Page:    page,
+		PerPage: perPage,
+		Items:   items,
+	})
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return bz, nil
+}
@@ -0,0 +1,68 @@
+package supply
+
+import (
+	"github.com/beatoz/beatoz-go/ctrlers/crisis"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+)
+
+// TotalSupplyAt returns the total supply committed at `height`.
+func (ctrler *SupplyCtrler) TotalSupplyAt(height int64) (*uint256.Int, xerrors.XError) {
+	atledger, xerr := ctrler.supplyState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	item, xerr := atledger.Get(v1.LedgerKeyTotalSupply())
+	if xerr != nil {
+		return nil, xerr
+	}
+	supply, _ := item.(*Supply)
+	if supply == nil {
+		return nil, xerrors.ErrNotFoundResult
+	}
+	return supply.GetTotalSupply(), nil
+}
+
+// SumRewardAt returns the sum of the rewards which are not withdrawn yet at `height`.
+func (ctrler *SupplyCtrler) SumRewardAt(height int64) (*uint256.Int, xerrors.XError) {
+	atledger, xerr := ctrler.supplyState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	sum := uint256.NewInt(0)
+	xerr = atledger.Seek(v1.KeyPrefixReward, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		rwd, _ := item.(*Reward)
+		_ = sum.Add(sum, rwd.cumulated)
+		return nil
+	})
+	if xerr != nil {
+		return nil, xerr
+	}
+	return sum, nil
+}
+
+// SetInvariantReaders sets the controllers of the balances and the powers,
+// which the total-supply invariant sums up with the rewards.
+// It should be called before RegisterInvariants.
+func (ctrler *SupplyCtrler) SetInvariantReaders(acct crisis.IBalanceReader, vpow crisis.IPowerReader) {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	ctrler.balanceReader, ctrler.powerReader = acct, vpow
+}
+
+func (ctrler *SupplyCtrler) RegisterInvariants(reg ctrlertypes.IInvariantRegistry) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	if ctrler.balanceReader == nil || ctrler.powerReader == nil {
+		panic("supply: the invariant readers are not set")
+	}
+	reg.RegisterRoute("supply", "total-supply", crisis.TotalSupplyInvariant(ctrler, ctrler.balanceReader, ctrler.powerReader))
+}
+
+var _ ctrlertypes.IInvariantHandler = (*SupplyCtrler)(nil)
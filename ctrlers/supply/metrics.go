@@ -0,0 +1,79 @@
+package supply
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	"github.com/holiman/uint256"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this package.
+	MetricsSubsystem = "beatoz_supply"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// The amount of supply minted at the last minting block, in BEATOZ.
+	MintedSupply metrics.Gauge
+	// The total supply, in BEATOZ.
+	TotalSupply metrics.Gauge
+	// The number of accounts rewarded at the last minting block.
+	RewardRecipients metrics.Gauge
+	// Histogram of the reward amounts issued to each account, in BEATOZ.
+	RewardAmount metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo", "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		MintedSupply: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "minted",
+			Help:      "Amount of supply minted at the last minting block, in BEATOZ.",
+		}, labels).With(labelsAndValues...),
+		TotalSupply: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "total",
+			Help:      "Total supply, in BEATOZ.",
+		}, labels).With(labelsAndValues...),
+		RewardRecipients: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reward_recipients",
+			Help:      "Number of accounts rewarded at the last minting block.",
+		}, labels).With(labelsAndValues...),
+		RewardAmount: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reward_amount",
+			Help:      "Reward amounts issued to each account, in BEATOZ.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 10, 12),
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		MintedSupply:     discard.NewGauge(),
+		TotalSupply:      discard.NewGauge(),
+		RewardRecipients: discard.NewGauge(),
+		RewardAmount:     discard.NewHistogram(),
+	}
+}
+
+// amountToFloat converts `amt` in grans to the value in BEATOZ.
+// It is only for metrics, so the precision loss does not matter.
+func amountToFloat(amt *uint256.Int) float64 {
+	return decimal.NewFromBigInt(amt.ToBig(), -18).InexactFloat64()
+}
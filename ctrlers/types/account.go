@@ -26,7 +26,11 @@ type Account struct {
 	Balance *uint256.Int  `json:"balance"`
 	Code    []byte        `json:"code,omitempty"`
 	DocURL  string        `json:"docURL,omitempty"`
-	mtx     sync.RWMutex
+
+	// PubKeyP256 is the secp256r1(P-256) public key registered by TRX_SETPUBKEY.
+	// If it is set, the account can sign transactions with the WebAuthn assertion of the key.
+	PubKeyP256 abytes.HexBytes `json:"pubKeyP256,omitempty"`
+	mtx        sync.RWMutex
 }
 
 var _ v1.ILedgerItem = (*Account)(nil)
@@ -55,6 +59,8 @@ func (acct *Account) Clone() *Account {
 		Nonce:   acct.Nonce,
 		Balance: acct.Balance.Clone(),
 		Code:    acct.Code,
+
+		PubKeyP256: acct.PubKeyP256,
 	}
 }
 
@@ -93,6 +99,20 @@ func (acct *Account) GetDocURL() string {
 	return acct.DocURL
 }
 
+func (acct *Account) SetPubKeyP256(pubKey []byte) {
+	acct.mtx.Lock()
+	defer acct.mtx.Unlock()
+
+	acct.PubKeyP256 = pubKey
+}
+
+func (acct *Account) GetPubKeyP256() []byte {
+	acct.mtx.RLock()
+	defer acct.mtx.RUnlock()
+
+	return acct.PubKeyP256
+}
+
 func (acct *Account) AddNonce() {
 	acct.mtx.Lock()
 	defer acct.mtx.Unlock()
@@ -202,6 +222,8 @@ func (acct *Account) Encode() ([]byte, xerrors.XError) {
 		XBalance: acct.Balance.Bytes(),
 		XCode:    acct.Code,
 		DocUrl:   acct.DocURL,
+
+		P256PubKey: acct.PubKeyP256,
 	}); err != nil {
 		return nil, xerrors.From(err)
 	} else {
@@ -221,6 +243,7 @@ func (acct *Account) Decode(k, v []byte) xerrors.XError {
 	acct.Balance = new(uint256.Int).SetBytes(pm.XBalance)
 	acct.Code = pm.XCode
 	acct.DocURL = pm.DocUrl
+	acct.PubKeyP256 = pm.P256PubKey
 	return nil
 }
 
@@ -29,6 +29,7 @@ type AcctProto struct {
 	XBalance      []byte                 `protobuf:"bytes,4,opt,name=_balance,json=Balance,proto3" json:"_balance,omitempty"`
 	XCode         []byte                 `protobuf:"bytes,5,opt,name=_code,json=Code,proto3" json:"_code,omitempty"`
 	DocUrl        string                 `protobuf:"bytes,6,opt,name=doc_url,json=docUrl,proto3" json:"doc_url,omitempty"`
+	P256PubKey    []byte                 `protobuf:"bytes,7,opt,name=p256_pub_key,json=p256PubKey,proto3" json:"p256_pub_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -105,18 +106,27 @@ func (x *AcctProto) GetDocUrl() string {
 	return ""
 }
 
+func (x *AcctProto) GetP256PubKey() []byte {
+	if x != nil {
+		return x.P256PubKey
+	}
+	return nil
+}
+
 var File_account_proto protoreflect.FileDescriptor
 
 const file_account_proto_rawDesc = "" +
 	"\n" +
-	"\raccount.proto\x12\x05types\"\x98\x01\n" +
+	"\raccount.proto\x12\x05types\"\xba\x01\n" +
 	"\tAcctProto\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05nonce\x18\x03 \x01(\x03R\x05nonce\x12\x19\n" +
 	"\b_balance\x18\x04 \x01(\fR\aBalance\x12\x13\n" +
 	"\x05_code\x18\x05 \x01(\fR\x04Code\x12\x17\n" +
-	"\adoc_url\x18\x06 \x01(\tR\x06docUrlB+Z)github.com/beatoz/beatoz-go/ctrlers/typesb\x06proto3"
+	"\adoc_url\x18\x06 \x01(\tR\x06docUrl\x12 \n" +
+	"\fp256_pub_key\x18\a \x01(\fR\n" +
+	"p256PubKeyB+Z)github.com/beatoz/beatoz-go/ctrlers/typesb\x06proto3"
 
 var (
 	file_account_proto_rawDescOnce sync.Once
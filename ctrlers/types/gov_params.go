@@ -3,7 +3,9 @@ package types
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"reflect"
+	"strings"
 	"sync"
 
 	v1 "github.com/beatoz/beatoz-go/ledger/v1"
@@ -65,6 +67,18 @@ func NewGovParams(interval int) *GovParams {
 			MinVotingPeriodBlocks:     DaySeconds / int64(interval),     // 1 days blocks
 			MaxVotingPeriodBlocks:     7 * DaySeconds / int64(interval), // 7 day blocks
 			LazyApplyingBlocks:        DaySeconds / int64(interval),     // 1days blocks
+			QuorumRate:                50,                               // 50%
+			PassThresholdRate:         67,                               // 67%
+			VetoThresholdRate:         33,                               // 33%
+			XMinDeposit:               nil,                              // no deposit is required. only validators can propose.
+			MaxDepositPeriodBlocks:    2 * DaySeconds / int64(interval), // 2 days blocks
+			TreasuryFeeRate:           0,                                // 0%. no fee is sent to the treasury.
+			TreasuryInflationRate:     0,                                // 0%. no inflation is sent to the treasury.
+			XCancelPenalty:            nil,                              // no penalty for cancelling a proposal in voting.
+			EvmShanghaiHeight:         0,                                // activated from the genesis.
+			EvmCancunHeight:           0,                                // activated from the genesis.
+			EvmPragueHeight:           0,                                // not scheduled.
+			EvmTokenViewHeight:        1,                                // activated from the first block.
 		},
 		mtx: sync.RWMutex{},
 	}
@@ -107,7 +121,7 @@ func (govParams *GovParams) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 	for k, v := range tmp {
-		if k == "maxTotalSupply" || k == "gasPrice" {
+		if k == "maxTotalSupply" || k == "gasPrice" || k == "minDeposit" || k == "cancelPenalty" {
 			// v is base64 string
 			_v, err := base64.StdEncoding.DecodeString(v.(string))
 			if err != nil {
@@ -137,7 +151,7 @@ func (govParams *GovParams) UnmarshalJSON(d []byte) error {
 	}
 
 	for k, v := range tmp {
-		if k == "maxTotalSupply" || k == "gasPrice" {
+		if k == "maxTotalSupply" || k == "gasPrice" || k == "minDeposit" || k == "cancelPenalty" {
 			tmp[k] = base64.StdEncoding.EncodeToString(uint256.MustFromDecimal(v.(string)).Bytes())
 		} else if k == "deadAddress" || k == "rewardPoolAddress" {
 			_v, err := hex.DecodeString(v.(string))
@@ -346,6 +360,91 @@ func (govParams *GovParams) LazyApplyingBlocks() int64 {
 
 	return govParams._v.LazyApplyingBlocks
 }
+func (govParams *GovParams) QuorumRate() int32 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.QuorumRate
+}
+func (govParams *GovParams) PassThresholdRate() int32 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.PassThresholdRate
+}
+func (govParams *GovParams) VetoThresholdRate() int32 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.VetoThresholdRate
+}
+func (govParams *GovParams) MinDeposit() *uint256.Int {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return new(uint256.Int).SetBytes(govParams._v.XMinDeposit)
+}
+func (govParams *GovParams) MaxDepositPeriodBlocks() int64 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.MaxDepositPeriodBlocks
+}
+func (govParams *GovParams) TreasuryFeeRate() int32 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.TreasuryFeeRate
+}
+func (govParams *GovParams) TreasuryInflationRate() int32 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.TreasuryInflationRate
+}
+func (govParams *GovParams) CancelPenalty() *uint256.Int {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return new(uint256.Int).SetBytes(govParams._v.XCancelPenalty)
+}
+
+// EVMShanghaiHeight returns the block height from which the EVM Shanghai fork is activated.
+// 0 means that it is activated from the genesis.
+func (govParams *GovParams) EVMShanghaiHeight() int64 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.EvmShanghaiHeight
+}
+
+// EVMCancunHeight returns the block height from which the EVM Cancun fork is activated.
+// 0 means that it is activated from the genesis.
+func (govParams *GovParams) EVMCancunHeight() int64 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.EvmCancunHeight
+}
+
+// EVMPragueHeight returns the block height from which the EVM Prague fork is activated.
+// It is reserved and always 0 (not scheduled) because the EVM of this version does not implement Prague.
+func (govParams *GovParams) EVMPragueHeight() int64 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.EvmPragueHeight
+}
+
+// EVMTokenViewHeight returns the block height from which the precompiled contract of the native token view is activated.
+// Unlike the EVM forks, 0 means that it is not scheduled,
+// because the parameters stored before it was added have 0.
+func (govParams *GovParams) EVMTokenViewHeight() int64 {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	return govParams._v.EvmTokenViewHeight
+}
 
 func (govParams *GovParams) GetValues() *GovParamsProto {
 	govParams.mtx.RLock()
@@ -401,6 +500,64 @@ func MergeGovParams(fromPrams, toParams *GovParams) {
 	}
 }
 
+// MergeGovParamsJSON returns the parameters which the fields present in the JSON `option` override from `current`.
+// Unlike MergeGovParams, a present field overrides even if its value is 0,
+// so that an option like `{"quorumRate":0}` can set the field to 0.
+func MergeGovParamsJSON(current *GovParams, option []byte) (*GovParams, xerrors.XError) {
+	jz, err := jsonx.Marshal(current)
+	if err != nil {
+		return nil, xerrors.ErrInvalidGovParams.Wrap(err)
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := jsonx.Unmarshal(jz, &fields); err != nil {
+		return nil, xerrors.ErrInvalidGovParams.Wrap(err)
+	}
+
+	overrides := make(map[string]json.RawMessage)
+	if err := jsonx.Unmarshal(option, &overrides); err != nil {
+		return nil, xerrors.ErrInvalidGovParams.Wrap(err)
+	}
+	for k, v := range overrides {
+		if _, ok := govParamsKeys[k]; !ok {
+			// the unknown field would be silently dropped and the option would change nothing.
+			return nil, xerrors.ErrInvalidGovParams.Wrapf("unknown field: %s", k)
+		}
+		fields[k] = v
+	}
+
+	if jz, err = jsonx.Marshal(fields); err != nil {
+		return nil, xerrors.ErrInvalidGovParams.Wrap(err)
+	}
+	ret := &GovParams{}
+	if err := jsonx.Unmarshal(jz, ret); err != nil {
+		return nil, xerrors.ErrInvalidGovParams.Wrap(err)
+	}
+	return ret, nil
+}
+
+// govParamsKeys has the field names of GovParams JSON, e.g. `quorumRate` and `minDeposit`.
+// They are the JSON names (or the names if not specified) in the protobuf tags of GovParamsProto
+// with the first letter lowered.
+var govParamsKeys = func() map[string]struct{} {
+	ret := make(map[string]struct{})
+	refT := reflect.TypeOf(GovParamsProto{})
+	for i := 0; i < refT.NumField(); i++ {
+		var name string
+		for _, opt := range strings.Split(refT.Field(i).Tag.Get("protobuf"), ",") {
+			if v, ok := strings.CutPrefix(opt, "json="); ok {
+				name = v
+				break
+			} else if v, ok := strings.CutPrefix(opt, "name="); ok {
+				name = v
+			}
+		}
+		if name != "" {
+			ret[strings.ToLower(name[:1])+name[1:]] = struct{}{}
+		}
+	}
+	return ret
+}()
+
 var _ v1.ILedgerItem = (*GovParams)(nil)
 var _ IGovParams = (*GovParams)(nil)
 
@@ -53,6 +53,18 @@ type GovParamsProto struct {
 	MinVotingPeriodBlocks     int64                  `protobuf:"varint,28,opt,name=min_voting_period_blocks,json=minVotingPeriodBlocks,proto3" json:"min_voting_period_blocks,omitempty"`
 	MaxVotingPeriodBlocks     int64                  `protobuf:"varint,29,opt,name=max_voting_period_blocks,json=maxVotingPeriodBlocks,proto3" json:"max_voting_period_blocks,omitempty"`
 	LazyApplyingBlocks        int64                  `protobuf:"varint,30,opt,name=lazy_applying_blocks,json=lazyApplyingBlocks,proto3" json:"lazy_applying_blocks,omitempty"`
+	QuorumRate                int32                  `protobuf:"varint,31,opt,name=quorum_rate,json=quorumRate,proto3" json:"quorum_rate,omitempty"`
+	PassThresholdRate         int32                  `protobuf:"varint,32,opt,name=pass_threshold_rate,json=passThresholdRate,proto3" json:"pass_threshold_rate,omitempty"`
+	VetoThresholdRate         int32                  `protobuf:"varint,33,opt,name=veto_threshold_rate,json=vetoThresholdRate,proto3" json:"veto_threshold_rate,omitempty"`
+	XMinDeposit               []byte                 `protobuf:"bytes,34,opt,name=_min_deposit,json=MinDeposit,proto3" json:"_min_deposit,omitempty"`
+	MaxDepositPeriodBlocks    int64                  `protobuf:"varint,35,opt,name=max_deposit_period_blocks,json=maxDepositPeriodBlocks,proto3" json:"max_deposit_period_blocks,omitempty"`
+	TreasuryFeeRate           int32                  `protobuf:"varint,36,opt,name=treasury_fee_rate,json=treasuryFeeRate,proto3" json:"treasury_fee_rate,omitempty"`
+	TreasuryInflationRate     int32                  `protobuf:"varint,37,opt,name=treasury_inflation_rate,json=treasuryInflationRate,proto3" json:"treasury_inflation_rate,omitempty"`
+	XCancelPenalty            []byte                 `protobuf:"bytes,38,opt,name=_cancel_penalty,json=CancelPenalty,proto3" json:"_cancel_penalty,omitempty"`
+	EvmShanghaiHeight         int64                  `protobuf:"varint,39,opt,name=evm_shanghai_height,json=evmShanghaiHeight,proto3" json:"evm_shanghai_height,omitempty"`
+	EvmCancunHeight           int64                  `protobuf:"varint,40,opt,name=evm_cancun_height,json=evmCancunHeight,proto3" json:"evm_cancun_height,omitempty"`
+	EvmPragueHeight           int64                  `protobuf:"varint,41,opt,name=evm_prague_height,json=evmPragueHeight,proto3" json:"evm_prague_height,omitempty"`
+	EvmTokenViewHeight        int64                  `protobuf:"varint,42,opt,name=evm_token_view_height,json=evmTokenViewHeight,proto3" json:"evm_token_view_height,omitempty"`
 	unknownFields             protoimpl.UnknownFields
 	sizeCache                 protoimpl.SizeCache
 }
@@ -297,11 +309,95 @@ func (x *GovParamsProto) GetLazyApplyingBlocks() int64 {
 	return 0
 }
 
+func (x *GovParamsProto) GetQuorumRate() int32 {
+	if x != nil {
+		return x.QuorumRate
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetPassThresholdRate() int32 {
+	if x != nil {
+		return x.PassThresholdRate
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetVetoThresholdRate() int32 {
+	if x != nil {
+		return x.VetoThresholdRate
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetXMinDeposit() []byte {
+	if x != nil {
+		return x.XMinDeposit
+	}
+	return nil
+}
+
+func (x *GovParamsProto) GetMaxDepositPeriodBlocks() int64 {
+	if x != nil {
+		return x.MaxDepositPeriodBlocks
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetTreasuryFeeRate() int32 {
+	if x != nil {
+		return x.TreasuryFeeRate
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetTreasuryInflationRate() int32 {
+	if x != nil {
+		return x.TreasuryInflationRate
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetXCancelPenalty() []byte {
+	if x != nil {
+		return x.XCancelPenalty
+	}
+	return nil
+}
+
+func (x *GovParamsProto) GetEvmShanghaiHeight() int64 {
+	if x != nil {
+		return x.EvmShanghaiHeight
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetEvmCancunHeight() int64 {
+	if x != nil {
+		return x.EvmCancunHeight
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetEvmPragueHeight() int64 {
+	if x != nil {
+		return x.EvmPragueHeight
+	}
+	return 0
+}
+
+func (x *GovParamsProto) GetEvmTokenViewHeight() int64 {
+	if x != nil {
+		return x.EvmTokenViewHeight
+	}
+	return 0
+}
+
 var File_gov_params_proto protoreflect.FileDescriptor
 
 const file_gov_params_proto_rawDesc = "" +
 	"\n" +
-	"\x10gov_params.proto\x12\x05types\"\xac\v\n" +
+	"\x10gov_params.proto\x12\x05types\"\xd1\x0f\n" +
 	"\x0eGovParamsProto\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x05R\aversion\x129\n" +
 	"\x19empty_block_interval_secs\x18\x02 \x01(\x05R\x16emptyBlockIntervalSecs\x12*\n" +
@@ -335,7 +431,21 @@ const file_gov_params_proto_rawDesc = "" +
 	"\x0fblock_gas_limit\x18\x1b \x01(\x03R\rblockGasLimit\x127\n" +
 	"\x18min_voting_period_blocks\x18\x1c \x01(\x03R\x15minVotingPeriodBlocks\x127\n" +
 	"\x18max_voting_period_blocks\x18\x1d \x01(\x03R\x15maxVotingPeriodBlocks\x120\n" +
-	"\x14lazy_applying_blocks\x18\x1e \x01(\x03R\x12lazyApplyingBlocksB+Z)github.com/beatoz/beatoz-go/ctrlers/typesb\x06proto3"
+	"\x14lazy_applying_blocks\x18\x1e \x01(\x03R\x12lazyApplyingBlocks\x12\x1f\n" +
+	"\vquorum_rate\x18\x1f \x01(\x05R\n" +
+	"quorumRate\x12.\n" +
+	"\x13pass_threshold_rate\x18  \x01(\x05R\x11passThresholdRate\x12.\n" +
+	"\x13veto_threshold_rate\x18! \x01(\x05R\x11vetoThresholdRate\x12 \n" +
+	"\f_min_deposit\x18\" \x01(\fR\n" +
+	"MinDeposit\x129\n" +
+	"\x19max_deposit_period_blocks\x18# \x01(\x03R\x16maxDepositPeriodBlocks\x12*\n" +
+	"\x11treasury_fee_rate\x18$ \x01(\x05R\x0ftreasuryFeeRate\x126\n" +
+	"\x17treasury_inflation_rate\x18% \x01(\x05R\x15treasuryInflationRate\x12&\n" +
+	"\x0f_cancel_penalty\x18& \x01(\fR\rCancelPenalty\x12.\n" +
+	"\x13evm_shanghai_height\x18' \x01(\x03R\x11evmShanghaiHeight\x12*\n" +
+	"\x11evm_cancun_height\x18( \x01(\x03R\x0fevmCancunHeight\x12*\n" +
+	"\x11evm_prague_height\x18) \x01(\x03R\x0fevmPragueHeight\x121\n" +
+	"\x15evm_token_view_height\x18* \x01(\x03R\x12evmTokenViewHeightB+Z)github.com/beatoz/beatoz-go/ctrlers/typesb\x06proto3"
 
 var (
 	file_gov_params_proto_rawDescOnce sync.Once
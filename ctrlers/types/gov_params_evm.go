@@ -0,0 +1,71 @@
+package types
+
+import (
+	"github.com/beatoz/beatoz-go/types/xerrors"
+)
+
+// EVMForks has the block heights from which the time based EVM forks are activated.
+// Only the forks implemented by the EVM of this version (geth v1.13) are included,
+// and `TokenView` is the height of the precompiled contract of the native token view.
+type EVMForks struct {
+	Shanghai  int64
+	Cancun    int64
+	TokenView int64
+}
+
+// EVMForksOf returns the activation heights of the EVM forks in `govParams`.
+// The zero value of the fork height in GovParams means that the fork is activated from the genesis,
+// but the zero `TokenView` means that it is not scheduled.
+func EVMForksOf(govParams IGovParams) EVMForks {
+	return EVMForks{
+		Shanghai:  govParams.EVMShanghaiHeight(),
+		Cancun:    govParams.EVMCancunHeight(),
+		TokenView: govParams.EVMTokenViewHeight(),
+	}
+}
+
+func (forks EVMForks) IsShanghai(height int64) bool {
+	return height >= forks.Shanghai
+}
+
+func (forks EVMForks) IsCancun(height int64) bool {
+	return height >= forks.Cancun
+}
+
+func (forks EVMForks) IsTokenView(height int64) bool {
+	return forks.TokenView > 0 && height >= forks.TokenView
+}
+
+// ValidateEVMForksChange checks that the change of the EVM forks from `current` to `proposed`
+// does not affect the blocks at or below `applyHeight`.
+// The fork which is already activated can not be changed,
+// and the fork can be scheduled only at a height greater than `applyHeight`.
+// The token view which is not scheduled (0) can be scheduled, and the scheduled one can be unscheduled.
+func ValidateEVMForksChange(current, proposed IGovParams, applyHeight int64) xerrors.XError {
+	curr, prop := EVMForksOf(current), EVMForksOf(proposed)
+
+	forks := []struct {
+		name        string
+		curr, prop  int64
+		unscheduled bool // 0 means that it is not scheduled.
+	}{
+		{"evmShanghaiHeight", curr.Shanghai, prop.Shanghai, false},
+		{"evmCancunHeight", curr.Cancun, prop.Cancun, false},
+		{"evmTokenViewHeight", curr.TokenView, prop.TokenView, true},
+	}
+	for _, f := range forks {
+		if f.curr == f.prop {
+			continue
+		}
+		if f.curr <= applyHeight && !(f.unscheduled && f.curr == 0) {
+			return xerrors.ErrInvalidGovParams.Wrapf("%s can not be changed because it was already activated at %d", f.name, f.curr)
+		}
+		if f.unscheduled && f.prop == 0 {
+			continue
+		}
+		if f.prop <= applyHeight {
+			return xerrors.ErrInvalidGovParams.Wrapf("%s(%d) must be greater than the applying height(%d)", f.name, f.prop, applyHeight)
+		}
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	"reflect"
@@ -37,3 +39,177 @@ func Test_JsonCodec(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, jz, jz2)
 }
+
+func Test_JsonCodec_MinDeposit(t *testing.T) {
+	govParams := DefaultGovParams()
+	govParams.SetValue(func(v *GovParamsProto) {
+		v.XMinDeposit = uint256.NewInt(1_000_000).Bytes()
+		v.XCancelPenalty = uint256.NewInt(500).Bytes()
+	})
+	jz, err := jsonx.Marshal(govParams)
+	require.NoError(t, err)
+	require.Contains(t, string(jz), `"minDeposit":"1000000"`)
+	require.Contains(t, string(jz), `"cancelPenalty":"500"`)
+
+	govParams2 := &GovParams{}
+	require.NoError(t, jsonx.Unmarshal(jz, govParams2))
+	require.Equal(t, uint256.NewInt(1_000_000), govParams2.MinDeposit())
+	require.Equal(t, uint256.NewInt(500), govParams2.CancelPenalty())
+	require.True(t, govParams.Equal(govParams2))
+}
+
+func Test_GovParams_Validate(t *testing.T) {
+	require.NoError(t, DefaultGovParams().Validate())
+
+	cases := []struct {
+		name string
+		set  setFunc
+	}{
+		{"maxValidatorCnt", func(v *GovParamsProto) { v.MaxValidatorCnt = 0 }},
+		{"minSelfPowerRate", func(v *GovParamsProto) { v.MinSelfPowerRate = 101 }},
+		{"inflationWeightPermil", func(v *GovParamsProto) { v.InflationWeightPermil = 1001 }},
+		{"inflationCycleBlocks", func(v *GovParamsProto) { v.InflationCycleBlocks = 0 }},
+		{"slashRate", func(v *GovParamsProto) { v.SlashRate = -1 }},
+		{"maxTotalSupply", func(v *GovParamsProto) { v.XMaxTotalSupply = nil }},
+		{"deadAddress", func(v *GovParamsProto) { v.XDeadAddress = []byte{0x01} }},
+		{"blockGasLimit", func(v *GovParamsProto) { v.BlockGasLimit = v.MinTrxGas - 1 }},
+		{"maxVotingPeriodBlocks", func(v *GovParamsProto) { v.MaxVotingPeriodBlocks = v.MinVotingPeriodBlocks - 1 }},
+		{"treasuryFeeRate", func(v *GovParamsProto) { v.TreasuryFeeRate = 100 - v.TxFeeRewardRate + 1 }},
+		{"passThresholdRate", func(v *GovParamsProto) { v.PassThresholdRate = 0 }},
+		{"maxDepositPeriodBlocks", func(v *GovParamsProto) {
+			v.XMinDeposit = uint256.NewInt(1).Bytes()
+			v.MaxDepositPeriodBlocks = 0
+		}},
+		{"evmPragueHeight", func(v *GovParamsProto) { v.EvmPragueHeight = -1 }},
+		{"evmCancunHeight", func(v *GovParamsProto) { v.EvmShanghaiHeight, v.EvmCancunHeight = 100, 99 }},
+		{"evmPragueHeight", func(v *GovParamsProto) { v.EvmPragueHeight = 1000 }},
+		{"evmTokenViewHeight", func(v *GovParamsProto) { v.EvmTokenViewHeight = -1 }},
+	}
+	for _, c := range cases {
+		params := DefaultGovParams()
+		params.SetValue(c.set)
+		xerr := params.Validate()
+		require.ErrorContains(t, xerr, xerrors.ErrInvalidGovParams.Error(), c.name)
+		require.ErrorContains(t, xerr, c.name)
+	}
+
+	// the parameters stored before `quorumRate` and `maxDepositPeriodBlocks` are added.
+	params := DefaultGovParams()
+	params.SetValue(func(v *GovParamsProto) {
+		v.QuorumRate = 0
+		v.PassThresholdRate = 0
+		v.VetoThresholdRate = 0
+		v.MaxDepositPeriodBlocks = 0
+	})
+	require.NoError(t, params.Validate())
+}
+
+func Test_EVMForks(t *testing.T) {
+	forks := EVMForksOf(DefaultGovParams())
+	require.Equal(t, EVMForks{Shanghai: 0, Cancun: 0, TokenView: 1}, forks)
+	require.True(t, forks.IsShanghai(1))
+	require.True(t, forks.IsCancun(1))
+	require.True(t, forks.IsTokenView(1))
+	// the token view is not scheduled in the parameters stored before it was added.
+	require.False(t, EVMForks{}.IsTokenView(1000))
+
+	current := DefaultGovParams()
+	current.SetValue(func(v *GovParamsProto) {
+		v.EvmCancunHeight = 1000
+	})
+	forks = EVMForksOf(current)
+	require.False(t, forks.IsCancun(999))
+	require.True(t, forks.IsCancun(1000))
+
+	proposed := func(cb setFunc) *GovParams {
+		ret := DefaultGovParams()
+		ret.SetValue(func(v *GovParamsProto) {
+			v.EvmCancunHeight = 1000
+		})
+		ret.SetValue(cb)
+		return ret
+	}
+
+	// not changed
+	require.NoError(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) {}), 2000))
+	// reschedule the fork which is not activated yet.
+	require.NoError(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) { v.EvmCancunHeight = 2000 }), 500))
+	// the fork must be scheduled after the applying height.
+	require.ErrorContains(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) { v.EvmCancunHeight = 500 }), 500), "evmCancunHeight")
+	// the activated fork can not be changed.
+	require.ErrorContains(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) { v.EvmCancunHeight = 3000 }), 1000), "evmCancunHeight")
+	require.ErrorContains(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) { v.EvmShanghaiHeight = 10 }), 1), "evmShanghaiHeight")
+
+	// the token view which is not scheduled can be scheduled after the applying height.
+	current.SetValue(func(v *GovParamsProto) { v.EvmTokenViewHeight = 0 })
+	require.NoError(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) { v.EvmTokenViewHeight = 2000 }), 1000))
+	require.ErrorContains(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) { v.EvmTokenViewHeight = 1000 }), 1000), "evmTokenViewHeight")
+	// the scheduled one can be unscheduled until it is activated.
+	current.SetValue(func(v *GovParamsProto) { v.EvmTokenViewHeight = 2000 })
+	require.NoError(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) { v.EvmTokenViewHeight = 0 }), 1000))
+	require.ErrorContains(t, ValidateEVMForksChange(current, proposed(func(v *GovParamsProto) { v.EvmTokenViewHeight = 0 }), 2000), "evmTokenViewHeight")
+}
+
+func Test_DiffGovParams(t *testing.T) {
+	params0 := DefaultGovParams()
+	params1 := DefaultGovParams()
+	params1.SetValue(func(v *GovParamsProto) {
+		v.MaxValidatorCnt = 30
+		v.XMinDeposit = uint256.NewInt(1000).Bytes()
+	})
+
+	diffs, xerr := DiffGovParams(params0, params0)
+	require.NoError(t, xerr)
+	require.Empty(t, diffs)
+
+	diffs, xerr = DiffGovParams(params0, params1)
+	require.NoError(t, xerr)
+	require.Len(t, diffs, 2)
+	require.Equal(t, "maxValidatorCnt", diffs[0].Field)
+	require.EqualValues(t, 21, diffs[0].Current)
+	require.EqualValues(t, 30, diffs[0].Proposed)
+	require.Equal(t, "minDeposit", diffs[1].Field)
+	require.Nil(t, diffs[1].Current)
+	require.Equal(t, "1000", diffs[1].Proposed)
+}
+
+func Test_MergeGovParamsJSON(t *testing.T) {
+	current := DefaultGovParams()
+	require.NotZero(t, current.QuorumRate())
+	require.NotZero(t, current.VetoThresholdRate())
+
+	// the present fields override the current ones even if they are 0.
+	merged, xerr := MergeGovParamsJSON(current, []byte(`{"quorumRate":0,"vetoThresholdRate":0,"maxValidatorCnt":30}`))
+	require.NoError(t, xerr)
+	require.Zero(t, merged.QuorumRate())
+	require.Zero(t, merged.VetoThresholdRate())
+	require.EqualValues(t, 30, merged.MaxValidatorCnt())
+
+	// the absent fields keep the current ones.
+	require.Equal(t, current.PassThresholdRate(), merged.PassThresholdRate())
+	require.Equal(t, current.MaxTotalSupply(), merged.MaxTotalSupply())
+	require.Equal(t, current.DeadAddress(), merged.DeadAddress())
+	require.EqualValues(t, 21, current.MaxValidatorCnt())
+
+	merged, xerr = MergeGovParamsJSON(current, []byte(`{}`))
+	require.NoError(t, xerr)
+	require.True(t, current.Equal(merged))
+
+	_, xerr = MergeGovParamsJSON(current, []byte(`not json`))
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidGovParams.Error())
+
+	// the unknown or misspelled fields are rejected.
+	for _, opt := range []string{`{"quorumrate":0}`, `{"quorum_rate":0}`, `{"maxValidatorCnt":30,"unknown":1}`} {
+		_, xerr = MergeGovParamsJSON(current, []byte(opt))
+		require.ErrorContains(t, xerr, xerrors.ErrInvalidGovParams.Error(), opt)
+		require.ErrorContains(t, xerr, "unknown field", opt)
+	}
+
+	// all fields of GovParams JSON are known.
+	jz, err := jsonx.Marshal(current)
+	require.NoError(t, err)
+	_, xerr = MergeGovParamsJSON(current, jz)
+	require.NoError(t, xerr)
+	require.Contains(t, govParamsKeys, "minDeposit")
+	require.Contains(t, govParamsKeys, "evmPragueHeight")
+}
@@ -0,0 +1,174 @@
+package types
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+)
+
+// Validate checks the range of each field and the constraints between fields.
+// It should be called with the complete parameters, e.g. the result of MergeGovParamsJSON,
+// because the zero value of some fields is not allowed.
+// The fields added after the genesis (e.g. `quorumRate`) allow the zero value for the backward compatibility.
+func (govParams *GovParams) Validate() xerrors.XError {
+	govParams.mtx.RLock()
+	defer govParams.mtx.RUnlock()
+
+	v := &govParams._v
+
+	type intRange struct {
+		name     string
+		val      int64
+		min, max int64
+	}
+	const noMax = int64(^uint64(0) >> 1)
+	ranges := []intRange{
+		{"version", int64(v.Version), 1, noMax},
+		{"emptyBlockIntervalSecs", int64(v.EmptyBlockIntervalSecs), 0, noMax},
+		{"maxValidatorCnt", int64(v.MaxValidatorCnt), 1, noMax},
+		{"minValidatorPower", v.MinValidatorPower, 0, noMax},
+		{"minDelegatorPower", v.MinDelegatorPower, 0, noMax},
+		{"maxValidatorsOfDelegator", int64(v.MaxValidatorsOfDelegator), 1, noMax},
+		{"maxDelegatorsOfValidator", int64(v.MaxDelegatorsOfValidator), 1, noMax},
+		{"minSelfPowerRate", int64(v.MinSelfPowerRate), 0, 100},
+		{"maxUpdatablePowerRate", int64(v.MaxUpdatablePowerRate), 0, 100},
+		{"maxIndividualPowerRate", int64(v.MaxIndividualPowerRate), 0, 100},
+		{"minBondingBlocks", v.MinBondingBlocks, 0, noMax},
+		{"minSignedBlocks", v.MinSignedBlocks, 0, noMax},
+		{"lazyUnbondingBlocks", v.LazyUnbondingBlocks, 0, noMax},
+		{"inflationWeightPermil", int64(v.InflationWeightPermil), 0, 1000},
+		{"inflationCycleBlocks", v.InflationCycleBlocks, 1, noMax},
+		{"bondingBlocksWeightPermil", int64(v.BondingBlocksWeightPermil), 0, 1000},
+		{"ripeningBlocks", v.RipeningBlocks, 1, noMax},
+		{"validatorRewardRate", int64(v.ValidatorRewardRate), 0, 100},
+		{"txFeeRewardRate", int64(v.TxFeeRewardRate), 0, 100},
+		{"slashRate", int64(v.SlashRate), 0, 100},
+		{"minTrxGas", v.MinTrxGas, 0, noMax},
+		{"blockSizeLimit", v.BlockSizeLimit, 1, noMax},
+		{"blockGasLimit", v.BlockGasLimit, 1, noMax},
+		{"minVotingPeriodBlocks", v.MinVotingPeriodBlocks, 1, noMax},
+		{"maxVotingPeriodBlocks", v.MaxVotingPeriodBlocks, 1, noMax},
+		{"lazyApplyingBlocks", v.LazyApplyingBlocks, 0, noMax},
+		{"quorumRate", int64(v.QuorumRate), 0, 100},
+		{"passThresholdRate", int64(v.PassThresholdRate), 0, 100},
+		{"vetoThresholdRate", int64(v.VetoThresholdRate), 0, 100},
+		{"maxDepositPeriodBlocks", v.MaxDepositPeriodBlocks, 0, noMax},
+		{"treasuryFeeRate", int64(v.TreasuryFeeRate), 0, 100},
+		{"treasuryInflationRate", int64(v.TreasuryInflationRate), 0, 100},
+		{"evmShanghaiHeight", v.EvmShanghaiHeight, 0, noMax},
+		{"evmCancunHeight", v.EvmCancunHeight, 0, noMax},
+		{"evmTokenViewHeight", v.EvmTokenViewHeight, 0, noMax},
+	}
+	for _, r := range ranges {
+		if r.val < r.min || r.val > r.max {
+			if r.max == noMax {
+				return xerrors.ErrInvalidGovParams.Wrapf("%s must be equal to or greater than %d (got %d)", r.name, r.min, r.val)
+			}
+			return xerrors.ErrInvalidGovParams.Wrapf("%s must be in [%d, %d] (got %d)", r.name, r.min, r.max, r.val)
+		}
+	}
+
+	if len(v.XMaxTotalSupply) == 0 || len(v.XMaxTotalSupply) > 32 {
+		return xerrors.ErrInvalidGovParams.Wrapf("maxTotalSupply must be greater than 0")
+	}
+	if len(v.XGasPrice) > 32 || len(v.XMinDeposit) > 32 || len(v.XCancelPenalty) > 32 {
+		return xerrors.ErrInvalidGovParams.Wrapf("gasPrice, minDeposit and cancelPenalty must be 256 bits or less")
+	}
+	if len(v.XRewardPoolAddress) != types.AddrSize {
+		return xerrors.ErrInvalidGovParams.Wrapf("wrong length of rewardPoolAddress: %v", len(v.XRewardPoolAddress))
+	}
+	if len(v.XDeadAddress) != types.AddrSize {
+		return xerrors.ErrInvalidGovParams.Wrapf("wrong length of deadAddress: %v", len(v.XDeadAddress))
+	}
+
+	//
+	// constraints between fields
+	if v.BlockGasLimit < v.MinTrxGas {
+		return xerrors.ErrInvalidGovParams.Wrapf("blockGasLimit(%d) must be equal to or greater than minTrxGas(%d)", v.BlockGasLimit, v.MinTrxGas)
+	}
+	if v.MaxVotingPeriodBlocks < v.MinVotingPeriodBlocks {
+		return xerrors.ErrInvalidGovParams.Wrapf("maxVotingPeriodBlocks(%d) must be equal to or greater than minVotingPeriodBlocks(%d)",
+			v.MaxVotingPeriodBlocks, v.MinVotingPeriodBlocks)
+	}
+	if v.TxFeeRewardRate+v.TreasuryFeeRate > 100 {
+		return xerrors.ErrInvalidGovParams.Wrapf("the sum of txFeeRewardRate(%d) and treasuryFeeRate(%d) must not exceed 100",
+			v.TxFeeRewardRate, v.TreasuryFeeRate)
+	}
+	if v.QuorumRate > 0 && v.PassThresholdRate == 0 {
+		return xerrors.ErrInvalidGovParams.Wrapf("passThresholdRate must be greater than 0 when quorumRate is set")
+	}
+	if len(v.XMinDeposit) > 0 && v.MaxDepositPeriodBlocks == 0 {
+		return xerrors.ErrInvalidGovParams.Wrapf("maxDepositPeriodBlocks must be greater than 0 when minDeposit is set")
+	}
+	// the EVM forks must be activated in order.
+	if v.EvmCancunHeight < v.EvmShanghaiHeight {
+		return xerrors.ErrInvalidGovParams.Wrapf("evmCancunHeight(%d) must be equal to or greater than evmShanghaiHeight(%d)",
+			v.EvmCancunHeight, v.EvmShanghaiHeight)
+	}
+	// Prague is not implemented by the EVM of this version (geth v1.13),
+	// so `evmPragueHeight` is reserved and must be 0 (not scheduled).
+	if v.EvmPragueHeight != 0 {
+		return xerrors.ErrInvalidGovParams.Wrapf("evmPragueHeight(%d) must be 0 because Prague is not supported by the EVM of this version",
+			v.EvmPragueHeight)
+	}
+	return nil
+}
+
+// GovParamsDiff is the change of the field `Field` from `Current` to `Proposed`.
+// The field name and the values are the same as in the JSON of GovParams.
+type GovParamsDiff struct {
+	Field    string      `json:"field"`
+	Current  interface{} `json:"current"`
+	Proposed interface{} `json:"proposed"`
+}
+
+// DiffGovParams returns the fields whose values differ between `current` and `proposed`,
+// in the alphabetical order of the field name.
+func DiffGovParams(current, proposed *GovParams) ([]*GovParamsDiff, xerrors.XError) {
+	curr, xerr := govParamsToMap(current)
+	if xerr != nil {
+		return nil, xerr
+	}
+	prop, xerr := govParamsToMap(proposed)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	var fields []string
+	for k := range prop {
+		fields = append(fields, k)
+	}
+	for k := range curr {
+		if _, ok := prop[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+
+	var ret []*GovParamsDiff
+	for _, k := range fields {
+		if !reflect.DeepEqual(curr[k], prop[k]) {
+			ret = append(ret, &GovParamsDiff{
+				Field:    k,
+				Current:  curr[k],
+				Proposed: prop[k],
+			})
+		}
+	}
+	return ret, nil
+}
+
+func govParamsToMap(govParams *GovParams) (map[string]interface{}, xerrors.XError) {
+	jz, err := jsonx.Marshal(govParams)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	ret := make(map[string]interface{})
+	if err := jsonx.Unmarshal(jz, &ret); err != nil {
+		return nil, xerrors.From(err)
+	}
+	return ret, nil
+}
@@ -62,10 +62,25 @@ type IGovParams interface {
 	MaxVotingPeriodBlocks() int64
 	MinVotingPeriodBlocks() int64
 	LazyApplyingBlocks() int64
+	QuorumRate() int32
+	PassThresholdRate() int32
+	VetoThresholdRate() int32
+	MinDeposit() *uint256.Int
+	MaxDepositPeriodBlocks() int64
+	TreasuryFeeRate() int32
+	TreasuryInflationRate() int32
+	CancelPenalty() *uint256.Int
+
+	EVMShanghaiHeight() int64
+	EVMCancunHeight() int64
+	EVMPragueHeight() int64
+	EVMTokenViewHeight() int64
 }
 
 type IGovHandler interface {
 	IGovParams
+	IsAllowedDeployer(addr types.Address, exec bool) bool
+	IsAllowedCaller(contract, caller types.Address, exec bool) bool
 	ITrxHandler
 	IBlockHandler
 }
@@ -82,6 +97,8 @@ type IAccountHandler interface {
 	AddBalance(types.Address, *uint256.Int, bool) xerrors.XError
 	SubBalance(types.Address, *uint256.Int, bool) xerrors.XError
 	SetBalance(types.Address, *uint256.Int, bool) xerrors.XError
+	FindToken(types.Address, bool) *Token
+	TokenBalanceOf(types.Address, types.Address, bool) *uint256.Int
 	SimuAcctCtrlerAt(int64) (IAccountHandler, xerrors.XError)
 }
 
@@ -93,6 +110,7 @@ type IStakeHandler interface {
 	TotalPowerOf(types.Address) int64
 	SelfPowerOf(types.Address) int64
 	DelegatedPowerOf(types.Address) int64
+	VPowerOf(types.Address, types.Address) int64
 }
 
 type IEVMHandler interface {
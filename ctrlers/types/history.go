@@ -0,0 +1,79 @@
+package types
+
+import (
+	"github.com/beatoz/beatoz-go/types"
+	abytes "github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+)
+
+const (
+	HistorySortByHeight = "height"
+	HistorySortByAmount = "amount"
+
+	// MaxHistoryScan is the maximum number of the index entries which a history query reads.
+	// The query which needs to read more (e.g. a deep page or the sort by amount over a wide height range)
+	// fails and should be retried with a narrower height range.
+	MaxHistoryScan = 10_000
+)
+
+// HistoryRecord is an indexed transaction or block event related to accounts.
+// For a block event, `Index` is -1 and `Type` is the type of the event. (e.g. `vpower.slashing`)
+type HistoryRecord struct {
+	Height  int64             `json:"height,string"`
+	Index   int               `json:"index,string"`
+	TxHash  abytes.HexBytes   `json:"txhash,omitempty"`
+	Type    string            `json:"type"`
+	From    types.Address     `json:"from,omitempty"`
+	To      types.Address     `json:"to,omitempty"`
+	Payer   types.Address     `json:"payer,omitempty"`
+	Amount  string            `json:"amount"`
+	Code    uint32            `json:"code"`
+	GasUsed int64             `json:"gasUsed,string"`
+	Attrs   map[string]string `json:"attrs,omitempty"`
+}
+
+// HistoryFilter selects the history records of `Address`.
+// If `ToHeight` is 0, it means the latest height.
+type HistoryFilter struct {
+	Address    types.Address `json:"address"`
+	Types      []string      `json:"types,omitempty"`
+	FromHeight int64         `json:"fromHeight,string"`
+	ToHeight   int64         `json:"toHeight,string"`
+	SortBy     string        `json:"sortBy,omitempty"`
+	Desc       bool          `json:"desc"`
+	Page       int           `json:"page,string"`
+	PerPage    int           `json:"perPage,string"`
+}
+
+// Validate checks the fields of the filter.
+// `PerPage` must not exceed MaxPerPage and the records up to the requested page must not exceed MaxHistoryScan.
+func (filter *HistoryFilter) Validate() xerrors.XError {
+	if len(filter.Address) != types.AddrSize {
+		return xerrors.ErrInvalidQueryParams.Wrapf("invalid address: %v", filter.Address)
+	}
+	if filter.FromHeight < 0 || filter.ToHeight < 0 ||
+		(filter.ToHeight > 0 && filter.FromHeight > filter.ToHeight) {
+		return xerrors.ErrInvalidQueryParams.Wrapf("invalid height range: [%v, %v]", filter.FromHeight, filter.ToHeight)
+	}
+	if filter.SortBy != "" &&
+		filter.SortBy != HistorySortByHeight &&
+		filter.SortBy != HistorySortByAmount {
+		return xerrors.ErrInvalidQueryParams.Wrapf("invalid sort field: %v", filter.SortBy)
+	}
+	if filter.Page < 0 || filter.PerPage < 0 || filter.PerPage > MaxPerPage {
+		return xerrors.ErrInvalidQueryParams.Wrapf("invalid page: %v, perPage: %v (max %v)", filter.Page, filter.PerPage, MaxPerPage)
+	}
+	if page, perPage := NormalizePage(filter.Page, filter.PerPage); page*perPage > MaxHistoryScan {
+		return xerrors.ErrInvalidQueryParams.Wrapf("too deep page: %v, narrow the height range", page)
+	}
+	return nil
+}
+
+// HistoryResult has the records of the requested page.
+// `HasMore` is true if there are more records after the page.
+type HistoryResult struct {
+	Page    int              `json:"page,string"`
+	PerPage int              `json:"perPage,string"`
+	HasMore bool             `json:"hasMore"`
+	Records []*HistoryRecord `json:"records"`
+}
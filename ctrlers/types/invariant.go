@@ -0,0 +1,21 @@
+package types
+
+// Invariant checks a property of the committed state at `height` that must always hold.
+// It returns a message describing the checked property and whether the property is broken.
+type Invariant func(height int64) (string, bool)
+
+type IInvariantRegistry interface {
+	RegisterRoute(module, route string, invar Invariant)
+}
+
+// IInvariantHandler is implemented by the controller contributing its invariants to the registry.
+type IInvariantHandler interface {
+	RegisterInvariants(IInvariantRegistry)
+}
+
+type InvariantResult struct {
+	Module string `json:"module"`
+	Route  string `json:"route"`
+	Broken bool   `json:"broken"`
+	Msg    string `json:"msg,omitempty"`
+}
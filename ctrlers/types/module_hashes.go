@@ -0,0 +1,64 @@
+package types
+
+import (
+	"bytes"
+
+	abytes "github.com/beatoz/beatoz-go/types/bytes"
+)
+
+// NOTE: DON'T CHANGE the order.
+// It is the same order in which `BeatozApp.Commit` hashes the ledger roots of the controllers.
+var ModuleNames = []string{"gov", "acct", "supply", "vpower", "vm"}
+
+type ModuleHash struct {
+	Name string          `json:"name"`
+	Hash abytes.HexBytes `json:"hash"`
+}
+
+// ModuleHashes is the breakdown of the app hash at `Height`.
+// It has the root hash of each controller's ledger.
+type ModuleHashes struct {
+	Height  int64           `json:"height,string"`
+	AppHash abytes.HexBytes `json:"appHash"`
+	Hashes  []*ModuleHash   `json:"hashes"`
+}
+
+func NewModuleHashes(height int64) *ModuleHashes {
+	return &ModuleHashes{
+		Height: height,
+	}
+}
+
+func (mh *ModuleHashes) Add(name string, hash []byte) {
+	mh.Hashes = append(mh.Hashes, &ModuleHash{
+		Name: name,
+		Hash: hash,
+	})
+}
+
+func (mh *ModuleHashes) Find(name string) *ModuleHash {
+	for _, h := range mh.Hashes {
+		if h.Name == name {
+			return h
+		}
+	}
+	return nil
+}
+
+// Diff returns the names of the modules whose hashes differ from `other`.
+// A module that exists in only one of them is also regarded as different.
+func (mh *ModuleHashes) Diff(other *ModuleHashes) []string {
+	var ret []string
+	for _, h := range mh.Hashes {
+		o := other.Find(h.Name)
+		if o == nil || !bytes.Equal(h.Hash, o.Hash) {
+			ret = append(ret, h.Name)
+		}
+	}
+	for _, o := range other.Hashes {
+		if mh.Find(o.Name) == nil {
+			ret = append(ret, o.Name)
+		}
+	}
+	return ret
+}
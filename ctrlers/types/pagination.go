@@ -0,0 +1,54 @@
+package types
+
+import "github.com/beatoz/beatoz-go/types"
+
+const (
+	DefaultPerPage = 30
+	MaxPerPage     = 100
+)
+
+// ListQuery is the request data of the queries listing the ledger items.
+// `Address` and `Status` are used as filters by the queries that support them.
+type ListQuery struct {
+	Address types.Address `json:"address,omitempty"`
+	Status  string        `json:"status,omitempty"`
+	Page    int           `json:"page,string"`
+	PerPage int           `json:"perPage,string"`
+}
+
+// PageResult is the response of the list queries.
+// `Items` has the items of the requested page only and `Total` is the number of all items.
+type PageResult struct {
+	Total   int         `json:"total,string"`
+	Page    int         `json:"page,string"`
+	PerPage int         `json:"perPage,string"`
+	Items   interface{} `json:"items"`
+}
+
+// NormalizePage returns the page and the page size corrected to the valid range.
+// The page starts from 1.
+func NormalizePage(page, perPage int) (int, int) {
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	} else if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return page, perPage
+}
+
+// PageRange returns the range [start, end) of the items in the page.
+// If the page is out of `total`, `start` and `end` are the same.
+func PageRange(total, page, perPage int) (int, int) {
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	return start, end
+}
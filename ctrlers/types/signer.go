@@ -19,14 +19,18 @@ type ISigner interface {
 
 var signerV0 *SignerV0
 var signerV1 *SignerV1
+var signerEIP712 *SignerEIP712
+var signerWebAuthn *SignerWebAuthn
 
 func InitSigner(chainId *uint256.Int) {
 	signerV0 = NewSignerV0(chainId)
 	signerV1 = NewSignerV1(chainId)
+	signerEIP712 = NewSignerEIP712(chainId)
+	signerWebAuthn = NewSignerWebAuthn(chainId)
 }
 
 func getSigner(v byte) (ISigner, xerrors.XError) {
-	if signerV0 == nil || signerV1 == nil {
+	if signerV0 == nil || signerV1 == nil || signerEIP712 == nil {
 		panic("signer not initialized")
 	}
 	switch v {
@@ -34,6 +38,8 @@ func getSigner(v byte) (ISigner, xerrors.XError) {
 		return signerV0, nil
 	case 27, 28:
 		return signerV1, nil
+	case EIP712SigVOffset, EIP712SigVOffset + 1:
+		return signerEIP712, nil
 	default:
 		return nil, xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("invalid v value - %v", v))
 	}
@@ -55,6 +61,22 @@ func VerifyPayerTrxRLP(tx *Trx) (types.Address, bytes.HexBytes, xerrors.XError)
 	return signer.VerifyPayer(tx)
 }
 
+// VerifyTrxWebAuthn verifies the sender's WebAuthn assertion with `pubKey` registered to the sender's account.
+func VerifyTrxWebAuthn(tx *Trx, pubKey []byte) xerrors.XError {
+	if signerWebAuthn == nil {
+		panic("signer not initialized")
+	}
+	return signerWebAuthn.VerifySender(tx, pubKey)
+}
+
+// VerifyPayerTrxWebAuthn verifies the payer's WebAuthn assertion with `pubKey` registered to the payer's account.
+func VerifyPayerTrxWebAuthn(tx *Trx, pubKey []byte) xerrors.XError {
+	if signerWebAuthn == nil {
+		panic("signer not initialized")
+	}
+	return signerWebAuthn.VerifyPayer(tx, pubKey)
+}
+
 // DEPRECATED
 // GetPreimageSenderTrxRLP does not include the sender's sig.
 func GetPreimageSenderTrxRLP(tx *Trx, chainId string) ([]byte, xerrors.XError) {
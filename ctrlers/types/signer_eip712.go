@@ -0,0 +1,337 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/holiman/uint256"
+)
+
+const (
+	EIP712DomainName    = "BEATOZ"
+	EIP712DomainVersion = "1"
+
+	// EIP712SigVOffset is added to the recovery id (0 or 1) of the signature of SignerEIP712.
+	// The wallets return 27 or 28 as `v` of the EIP-712 signature like SignerV1,
+	// so `v` is moved to 29 or 30 to distinguish SignerEIP712 from SignerV1.
+	EIP712SigVOffset = 29
+)
+
+// SignerEIP712 signs `Trx` as the EIP-712 typed data,
+// so the wallets can show the fields of the transaction before signing it.
+// The sender signs the `Trx` typed data except the payer's fields,
+// and the payer signs the `PayerTrx` typed data which has the sender's signature additionally.
+type SignerEIP712 struct {
+	chainId *uint256.Int
+}
+
+func NewSignerEIP712(chainId *uint256.Int) *SignerEIP712 {
+	return &SignerEIP712{chainId: chainId}
+}
+
+func (s *SignerEIP712) SignSender(tx *Trx, prvBytes bytes.HexBytes) (bytes.HexBytes, error) {
+	return s.sign(tx, prvBytes, false)
+}
+
+func (s *SignerEIP712) SignPayer(tx *Trx, prvBytes bytes.HexBytes) (bytes.HexBytes, error) {
+	return s.sign(tx, prvBytes, true)
+}
+
+func (s *SignerEIP712) sign(tx *Trx, prvBytes bytes.HexBytes, isPayer bool) (bytes.HexBytes, error) {
+	hash, xerr := s.hash(tx, isPayer)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	prvKey, err := ethcrypto.ToECDSA(prvBytes)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+
+	sig, err := ethcrypto.Sign(hash, prvKey)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	sig[64] += EIP712SigVOffset
+
+	if isPayer {
+		tx.PayerSig = sig
+	} else {
+		tx.Sig = sig
+	}
+
+	return sig, nil
+}
+
+func (s *SignerEIP712) VerifySender(tx *Trx) (bytes.HexBytes, bytes.HexBytes, xerrors.XError) {
+	return s.verify(tx, false)
+}
+
+func (s *SignerEIP712) VerifyPayer(tx *Trx) (bytes.HexBytes, bytes.HexBytes, xerrors.XError) {
+	return s.verify(tx, true)
+}
+
+func (s *SignerEIP712) verify(tx *Trx, isPayer bool) (bytes.HexBytes, bytes.HexBytes, xerrors.XError) {
+	hash, xerr := s.hash(tx, isPayer)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	addr0 := tx.From
+	sig := tx.Sig
+	if isPayer {
+		addr0 = tx.Payer
+		sig = tx.PayerSig
+	}
+	if len(sig) != ethcrypto.SignatureLength {
+		return nil, nil, xerrors.From(fmt.Errorf("invalid signature length - expected: %d, actual: %d", ethcrypto.SignatureLength, len(sig)))
+	}
+
+	v := sig[64]
+	if v == EIP712SigVOffset || v == EIP712SigVOffset+1 {
+		sig[64] = v - EIP712SigVOffset
+	} else {
+		return nil, nil, xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("invalid signature v - expected: %d or %d, actual: %d", EIP712SigVOffset, EIP712SigVOffset+1, v))
+	}
+	defer func() {
+		sig[64] = v
+	}()
+
+	pub, err := ethcrypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, nil, xerrors.ErrInvalidTrxSig.Wrap(err)
+	}
+	addr := crypto.Pub2Addr(pub)
+	if bytes.Compare(addr0, addr) != 0 {
+		return nil, nil, xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("wrong recover address - expected: %v, actual: %v", addr0, addr))
+	}
+	return addr, crypto.CompressPubkey(pub), nil
+}
+
+// TypedDataSender returns the typed data signed by the sender of `tx`.
+func (s *SignerEIP712) TypedDataSender(tx *Trx) (*apitypes.TypedData, xerrors.XError) {
+	return s.typedData(tx, false)
+}
+
+// TypedDataPayer returns the typed data signed by the payer of `tx`.
+func (s *SignerEIP712) TypedDataPayer(tx *Trx) (*apitypes.TypedData, xerrors.XError) {
+	return s.typedData(tx, true)
+}
+
+func (s *SignerEIP712) hash(tx *Trx, isPayer bool) ([]byte, xerrors.XError) {
+	typedData, xerr := s.typedData(tx, isPayer)
+	if xerr != nil {
+		return nil, xerr
+	}
+	hash, _, err := apitypes.TypedDataAndHash(*typedData)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	return hash, nil
+}
+
+func (s *SignerEIP712) typedData(tx *Trx, isPayer bool) (*apitypes.TypedData, xerrors.XError) {
+	trxType := []apitypes.Type{
+		{Name: "version", Type: "int32"},
+		{Name: "time", Type: "int64"},
+		{Name: "nonce", Type: "int64"},
+		{Name: "from", Type: "address"},
+		{Name: "to", Type: "address"},
+		{Name: "amount", Type: "uint256"},
+		{Name: "gas", Type: "int64"},
+		{Name: "gasPrice", Type: "uint256"},
+		{Name: "type", Type: "int32"},
+	}
+	message := apitypes.TypedDataMessage{
+		"version":  fmt.Sprintf("%d", tx.Version),
+		"time":     fmt.Sprintf("%d", tx.Time),
+		"nonce":    fmt.Sprintf("%d", tx.Nonce),
+		"from":     eip712Address(tx.From),
+		"to":       eip712Address(tx.To),
+		"amount":   eip712Uint256(tx.Amount),
+		"gas":      fmt.Sprintf("%d", tx.Gas),
+		"gasPrice": eip712Uint256(tx.GasPrice),
+		"type":     fmt.Sprintf("%d", tx.Type),
+	}
+	typeDefs := apitypes.Types{
+		"EIP712Domain": {
+			{Name: "name", Type: "string"},
+			{Name: "version", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+		},
+	}
+
+	payloadTypeName, payloadType, payload, xerr := eip712Payload(tx.Payload)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if payloadTypeName != "" {
+		trxType = append(trxType, apitypes.Type{Name: "payload", Type: payloadTypeName})
+		message["payload"] = payload
+		typeDefs[payloadTypeName] = payloadType
+	}
+
+	primaryType := "Trx"
+	if isPayer {
+		// the payer signs the sender's signature together.
+		primaryType = "PayerTrx"
+		trxType = append(trxType, apitypes.Type{Name: "sig", Type: "bytes"})
+		message["sig"] = hexutil.Encode(tx.Sig)
+	}
+	typeDefs[primaryType] = trxType
+
+	return &apitypes.TypedData{
+		Types:       typeDefs,
+		PrimaryType: primaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:    EIP712DomainName,
+			Version: EIP712DomainVersion,
+			ChainId: (*math.HexOrDecimal256)(s.chainId.ToBig()),
+		},
+		Message: message,
+	}, nil
+}
+
+// eip712Payload returns the type name, the type and the message of `payload`.
+// If `payload` has no field, the type name is empty.
+func eip712Payload(payload ITrxPayload) (string, []apitypes.Type, apitypes.TypedDataMessage, xerrors.XError) {
+	switch p := payload.(type) {
+	case nil, *TrxPayloadAssetTransfer, *TrxPayloadStaking:
+		return "", nil, nil, nil
+	case *TrxPayloadUnstaking:
+		return "Unstaking",
+			[]apitypes.Type{{Name: "txHash", Type: "bytes"}},
+			apitypes.TypedDataMessage{"txHash": hexutil.Encode(p.TxHash)},
+			nil
+	case *TrxPayloadWithdraw:
+		return "Withdraw",
+			[]apitypes.Type{{Name: "reqAmt", Type: "uint256"}},
+			apitypes.TypedDataMessage{"reqAmt": eip712Uint256(p.ReqAmt)},
+			nil
+	case *TrxPayloadProposal:
+		options := make([]interface{}, len(p.Options))
+		for i, opt := range p.Options {
+			options[i] = hexutil.Encode(opt)
+		}
+		return "Proposal",
+			[]apitypes.Type{
+				{Name: "message", Type: "string"},
+				{Name: "startVotingHeight", Type: "int64"},
+				{Name: "votingPeriodBlocks", Type: "int64"},
+				{Name: "applyingHeight", Type: "int64"},
+				{Name: "optType", Type: "int32"},
+				{Name: "options", Type: "bytes[]"},
+			},
+			apitypes.TypedDataMessage{
+				"message":            p.Message,
+				"startVotingHeight":  fmt.Sprintf("%d", p.StartVotingHeight),
+				"votingPeriodBlocks": fmt.Sprintf("%d", p.VotingPeriodBlocks),
+				"applyingHeight":     fmt.Sprintf("%d", p.ApplyingHeight),
+				"optType":            fmt.Sprintf("%d", p.OptType),
+				"options":            options,
+			},
+			nil
+	case *TrxPayloadVoting:
+		return "Voting",
+			[]apitypes.Type{
+				{Name: "txHash", Type: "bytes"},
+				{Name: "choice", Type: "int32"},
+			},
+			apitypes.TypedDataMessage{
+				"txHash": hexutil.Encode(p.TxHash),
+				"choice": fmt.Sprintf("%d", p.Choice),
+			},
+			nil
+	case *TrxPayloadContract:
+		return "Contract",
+			[]apitypes.Type{{Name: "data", Type: "bytes"}},
+			apitypes.TypedDataMessage{"data": hexutil.Encode(p.Data)},
+			nil
+	case *TrxPayloadSetDoc:
+		return "SetDoc",
+			[]apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "url", Type: "string"},
+			},
+			apitypes.TypedDataMessage{
+				"name": p.Name,
+				"url":  p.URL,
+			},
+			nil
+	case *TrxPayloadDeposit:
+		return "Deposit",
+			[]apitypes.Type{{Name: "txHash", Type: "bytes"}},
+			apitypes.TypedDataMessage{"txHash": hexutil.Encode(p.TxHash)},
+			nil
+	case *TrxPayloadCancelProposal:
+		return "CancelProposal",
+			[]apitypes.Type{{Name: "txHash", Type: "bytes"}},
+			apitypes.TypedDataMessage{"txHash": hexutil.Encode(p.TxHash)},
+			nil
+	case *TrxPayloadTokenIssue:
+		return "TokenIssue",
+			[]apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "symbol", Type: "string"},
+				{Name: "decimals", Type: "int32"},
+				{Name: "maxSupply", Type: "uint256"},
+				{Name: "initialSupply", Type: "uint256"},
+				{Name: "mintAuthority", Type: "address"},
+			},
+			apitypes.TypedDataMessage{
+				"name":          p.Name,
+				"symbol":        p.Symbol,
+				"decimals":      fmt.Sprintf("%d", p.Decimals),
+				"maxSupply":     eip712Uint256(p.MaxSupply),
+				"initialSupply": eip712Uint256(p.InitialSupply),
+				"mintAuthority": eip712Address(p.MintAuthority),
+			},
+			nil
+	case *TrxPayloadTokenMint:
+		return "TokenMint", eip712TokenAmountType, eip712TokenAmount(&p.TokenAmount), nil
+	case *TrxPayloadTokenBurn:
+		return "TokenBurn", eip712TokenAmountType, eip712TokenAmount(&p.TokenAmount), nil
+	case *TrxPayloadTokenTransfer:
+		return "TokenTransfer", eip712TokenAmountType, eip712TokenAmount(&p.TokenAmount), nil
+	case *TrxPayloadSetPubKey:
+		return "SetPubKey",
+			[]apitypes.Type{{Name: "pubKey", Type: "bytes"}},
+			apitypes.TypedDataMessage{"pubKey": hexutil.Encode(p.PubKey)},
+			nil
+	default:
+		return "", nil, nil, xerrors.ErrInvalidTrxPayloadType.Wrap(fmt.Errorf("unsupported payload for EIP-712: %T", payload))
+	}
+}
+
+var eip712TokenAmountType = []apitypes.Type{
+	{Name: "token", Type: "address"},
+	{Name: "amount", Type: "uint256"},
+}
+
+func eip712TokenAmount(ta *TokenAmount) apitypes.TypedDataMessage {
+	return apitypes.TypedDataMessage{
+		"token":  eip712Address(ta.Token),
+		"amount": eip712Uint256(ta.Amount),
+	}
+}
+
+// eip712Address returns the hex string of `addr`.
+// `nil` is the zero address as it is handled so when the tx is executed.
+func eip712Address(addr types.Address) string {
+	return common.BytesToAddress(addr).Hex()
+}
+
+func eip712Uint256(v *uint256.Int) string {
+	if v == nil {
+		return "0"
+	}
+	return v.Dec()
+}
@@ -1,6 +1,7 @@
 package types_test
 
 import (
+	"encoding/json"
 	"math/rand"
 	"testing"
 	"time"
@@ -9,9 +10,12 @@ import (
 	"github.com/beatoz/beatoz-go/types/bytes"
 	"github.com/beatoz/beatoz-go/types/crypto"
 	"github.com/beatoz/beatoz-sdk-go/web3"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
+	bzweb3 "github.com/beatoz/beatoz-go/cmd/commands/web3"
 	ctrtypes "github.com/beatoz/beatoz-go/ctrlers/types"
 )
 
@@ -126,3 +130,114 @@ func TestSignerV1_Recover(t *testing.T) {
 	addr, pubBytes1, err = signer.VerifyPayer(tx)
 	require.Error(t, err)
 }
+
+func TestSignerEIP712_Recover(t *testing.T) {
+	senderPrvBz, senderPubBz0 := crypto.NewKeypairBytes()
+	senderAddr := crypto.PubKeyBytes2Addr(senderPubBz0)
+	payerPrvBz, payerPubBz0 := crypto.NewKeypairBytes()
+	payerAddr := crypto.PubKeyBytes2Addr(payerPubBz0)
+
+	payloads := []ctrtypes.ITrxPayload{
+		&ctrtypes.TrxPayloadAssetTransfer{},
+		&ctrtypes.TrxPayloadStaking{},
+		&ctrtypes.TrxPayloadUnstaking{TxHash: bytes.RandBytes(32)},
+		&ctrtypes.TrxPayloadWithdraw{ReqAmt: bytes.RandU256Int()},
+		&ctrtypes.TrxPayloadProposal{
+			Message:            "proposal",
+			StartVotingHeight:  10,
+			VotingPeriodBlocks: 100,
+			ApplyingHeight:     200,
+			OptType:            1,
+			Options:            [][]byte{bytes.RandBytes(32), bytes.RandBytes(32)},
+		},
+		&ctrtypes.TrxPayloadVoting{TxHash: bytes.RandBytes(32), Choice: 1},
+		&ctrtypes.TrxPayloadContract{Data: bytes.RandBytes(100)},
+		&ctrtypes.TrxPayloadSetDoc{Name: "name", URL: "https://beatoz.io"},
+		&ctrtypes.TrxPayloadSetPubKey{PubKey: bytes.RandBytes(33)},
+		&ctrtypes.TrxPayloadTokenIssue{
+			Name:          "Test Token",
+			Symbol:        "TST",
+			Decimals:      18,
+			MaxSupply:     bytes.RandU256Int(),
+			InitialSupply: bytes.RandU256Int(),
+			MintAuthority: types.RandAddress(),
+		},
+		&ctrtypes.TrxPayloadTokenTransfer{TokenAmount: ctrtypes.TokenAmount{Token: types.RandAddress(), Amount: bytes.RandU256Int()}},
+	}
+
+	signer := ctrtypes.NewSignerEIP712(chainId)
+	for _, payload := range payloads {
+		tx := ctrtypes.NewTrx(1, senderAddr, types.RandAddress(), rand.Int63(), rand.Int63(), uint256.NewInt(rand.Uint64()), bytes.RandU256Int(), payload)
+		tx.Payer = payerAddr
+
+		// for Sender
+		sig, err := signer.SignSender(tx, senderPrvBz)
+		require.NoError(t, err)
+		require.Contains(t, []byte{ctrtypes.EIP712SigVOffset, ctrtypes.EIP712SigVOffset + 1}, sig[64])
+
+		addr, pubBytes1, err := ctrtypes.VerifyTrxRLP(tx)
+		require.NoError(t, err)
+		require.Equal(t, senderAddr, addr)
+		require.Equal(t, senderPubBz0, pubBytes1)
+
+		// for Payer
+		_, err = signer.SignPayer(tx, payerPrvBz)
+		require.NoError(t, err)
+
+		addr, pubBytes1, err = ctrtypes.VerifyPayerTrxRLP(tx)
+		require.NoError(t, err)
+		require.Equal(t, payerAddr, addr)
+		require.Equal(t, payerPubBz0, pubBytes1)
+
+		// fail expected: the sender's sig is covered by the payer's sig.
+		tx.Sig[0]++
+		_, _, err = ctrtypes.VerifyPayerTrxRLP(tx)
+		require.Error(t, err)
+		tx.Sig[0]--
+
+		// fail expected: the tampered amount
+		tx.Amount = new(uint256.Int).AddUint64(tx.Amount, 1)
+		_, _, err = ctrtypes.VerifyTrxRLP(tx)
+		require.Error(t, err)
+
+		// fail expected: the other chain
+		_, _, err = ctrtypes.NewSignerEIP712(uint256.NewInt(1)).VerifySender(tx)
+		require.Error(t, err)
+	}
+}
+
+// TestSignerEIP712_Wallet signs the typed data as a wallet does with `eth_signTypedData_v4`.
+func TestSignerEIP712_Wallet(t *testing.T) {
+	prvBz, pubBz := crypto.NewKeypairBytes()
+	addr := crypto.PubKeyBytes2Addr(pubBz)
+	prvKey, err := ethcrypto.ToECDSA(prvBz)
+	require.NoError(t, err)
+
+	tx := bzweb3.NewTrxTransfer(addr, types.RandAddress(), 1, 100_000, uint256.NewInt(10_000_000_000), bytes.RandU256Int())
+
+	typedData, err := bzweb3.TrxTypedData(tx, chainId)
+	require.NoError(t, err)
+
+	// the typed data is passed to the wallet as json.
+	bz, err := json.Marshal(typedData)
+	require.NoError(t, err)
+	walletTypedData := apitypes.TypedData{}
+	require.NoError(t, json.Unmarshal(bz, &walletTypedData))
+	require.Equal(t, ctrtypes.EIP712DomainName, walletTypedData.Domain.Name)
+	require.Equal(t, tx.Amount.Dec(), walletTypedData.Message["amount"])
+
+	hash, _, err := apitypes.TypedDataAndHash(walletTypedData)
+	require.NoError(t, err)
+	sig, err := ethcrypto.Sign(hash, prvKey)
+	require.NoError(t, err)
+	sig[64] += 27 // wallets return v as 27 or 28.
+
+	require.NoError(t, bzweb3.SetTrxEIP712Sig(tx, sig))
+	require.Equal(t, sig[64]-27+ctrtypes.EIP712SigVOffset, tx.Sig[64])
+
+	addr1, _, err := ctrtypes.VerifyTrxRLP(tx)
+	require.NoError(t, err)
+	require.Equal(t, addr, addr1)
+
+	require.Error(t, bzweb3.SetTrxEIP712Sig(tx, sig[:64]))
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+)
+
+const (
+	WebAuthnTypeGet = "webauthn.get"
+
+	// the authenticator data has rpIdHash(32), flags(1) and signCount(4) at least.
+	webAuthnMinAuthDataLen = 37
+	webAuthnFlagUP         = 0x01
+)
+
+// WebAuthnSig is the WebAuthn assertion of the secp256r1(P-256) key registered to an account.
+// It is set to `Trx.Sig` or `Trx.PayerSig` as the RLP encoded bytes.
+// `Signature` is the ASN.1 DER encoded signature returned by the authenticator.
+type WebAuthnSig struct {
+	AuthenticatorData bytes.HexBytes `json:"authenticatorData"`
+	ClientDataJSON    bytes.HexBytes `json:"clientDataJSON"`
+	Signature         bytes.HexBytes `json:"signature"`
+}
+
+func (ws *WebAuthnSig) Encode() (bytes.HexBytes, xerrors.XError) {
+	bz, err := rlp.EncodeToBytes(ws)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	return bz, nil
+}
+
+func DecodeWebAuthnSig(bz []byte) (*WebAuthnSig, xerrors.XError) {
+	ws := &WebAuthnSig{}
+	if err := rlp.DecodeBytes(bz, ws); err != nil {
+		return nil, xerrors.ErrInvalidTrxSig.Wrap(err)
+	}
+	return ws, nil
+}
+
+// IsWebAuthnSig returns true if `sig` is not the secp256k1 signature of 65 bytes.
+// The encoded WebAuthnSig is always longer than the secp256k1 signature.
+func IsWebAuthnSig(sig []byte) bool {
+	return len(sig) > ethcrypto.SignatureLength
+}
+
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+}
+
+// SignerWebAuthn verifies the WebAuthn assertion of the P-256 key registered to the sender or the payer.
+// The challenge of the assertion is the hash of the same preimage as SignerV1.
+// Unlike the secp256k1 signers, the public key can not be recovered from the signature,
+// so the registered key of the account should be given to verify it.
+type SignerWebAuthn struct {
+	signerV1 *SignerV1
+}
+
+func NewSignerWebAuthn(chainId *uint256.Int) *SignerWebAuthn {
+	return &SignerWebAuthn{signerV1: NewSignerV1(chainId)}
+}
+
+// ChallengeSender returns the challenge which the sender's authenticator should sign.
+func (s *SignerWebAuthn) ChallengeSender(tx *Trx) (bytes.HexBytes, xerrors.XError) {
+	return s.challenge(tx, false)
+}
+
+// ChallengePayer returns the challenge which the payer's authenticator should sign.
+// The sender's signature should be set to `tx` before it is called.
+func (s *SignerWebAuthn) ChallengePayer(tx *Trx) (bytes.HexBytes, xerrors.XError) {
+	return s.challenge(tx, true)
+}
+
+func (s *SignerWebAuthn) challenge(tx *Trx, isPayer bool) (bytes.HexBytes, xerrors.XError) {
+	preimg, xerr := s.signerV1.getPreimage(tx, isPayer)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return crypto.DefaultHash(preimg), nil
+}
+
+func (s *SignerWebAuthn) VerifySender(tx *Trx, pubKey []byte) xerrors.XError {
+	return s.verify(tx, pubKey, false)
+}
+
+func (s *SignerWebAuthn) VerifyPayer(tx *Trx, pubKey []byte) xerrors.XError {
+	return s.verify(tx, pubKey, true)
+}
+
+func (s *SignerWebAuthn) verify(tx *Trx, pubKey []byte, isPayer bool) xerrors.XError {
+	if len(pubKey) == 0 {
+		return xerrors.ErrInvalidTrxSig.Wrap(fmt.Errorf("no P-256 public key is registered"))
+	}
+	pub, xerr := ParseP256PubKey(pubKey)
+	if xerr != nil {
+		return xerrors.ErrInvalidTrxSig.Wrap(xerr)
+	}
+
+	sig := tx.Sig
+	if isPayer {
+		sig = tx.PayerSig
+	}
+	ws, xerr := DecodeWebAuthnSig(sig)
+	if xerr != nil {
+		return xerr
+	}
+
+	challenge, xerr := s.challenge(tx, isPayer)
+	if xerr != nil {
+		return xerr
+	}
+	if xerr := verifyWebAuthnAssertion(ws, challenge, pub); xerr != nil {
+		return xerrors.ErrInvalidTrxSig.Wrap(xerr)
+	}
+	return nil
+}
+
+func verifyWebAuthnAssertion(ws *WebAuthnSig, challenge []byte, pub *ecdsa.PublicKey) xerrors.XError {
+	if len(ws.AuthenticatorData) < webAuthnMinAuthDataLen {
+		return xerrors.NewOrdinary("too short authenticator data")
+	}
+	if ws.AuthenticatorData[32]&webAuthnFlagUP == 0 {
+		return xerrors.NewOrdinary("user presence flag is not set")
+	}
+
+	clientData := &webAuthnClientData{}
+	if err := json.Unmarshal(ws.ClientDataJSON, clientData); err != nil {
+		return xerrors.From(err)
+	}
+	if clientData.Type != WebAuthnTypeGet {
+		return xerrors.NewOrdinary(fmt.Sprintf("wrong client data type - expected: %s, actual: %s", WebAuthnTypeGet, clientData.Type))
+	}
+	if clientData.Challenge != base64.RawURLEncoding.EncodeToString(challenge) {
+		return xerrors.NewOrdinary("wrong challenge")
+	}
+
+	clientDataHash := sha256.Sum256(ws.ClientDataJSON)
+	msg := sha256.Sum256(append(append([]byte{}, ws.AuthenticatorData...), clientDataHash[:]...))
+	if !ecdsa.VerifyASN1(pub, msg[:], ws.Signature) {
+		return xerrors.NewOrdinary("wrong signature")
+	}
+	return nil
+}
+
+// ParseP256PubKey parses the compressed(33 bytes) or uncompressed(65 bytes) secp256r1(P-256) public key.
+func ParseP256PubKey(pubKey []byte) (*ecdsa.PublicKey, xerrors.XError) {
+	var x, y *big.Int
+	switch len(pubKey) {
+	case 33:
+		x, y = elliptic.UnmarshalCompressed(elliptic.P256(), pubKey)
+	case 65:
+		x, y = elliptic.Unmarshal(elliptic.P256(), pubKey)
+	}
+	if x == nil || y == nil {
+		return nil, xerrors.NewOrdinary("invalid P-256 public key")
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
@@ -0,0 +1,164 @@
+package types
+
+import (
+	"sync"
+
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	MAX_TOKEN_NAME     = 64
+	MAX_TOKEN_SYMBOL   = 16
+	MAX_TOKEN_DECIMALS = 18
+)
+
+// Token is the native fungible token issued by TRX_TOKEN_ISSUE.
+// Its balances are kept in the account ledger apart from the native coin balance of `Account`.
+type Token struct {
+	Address     types.Address `json:"address"`
+	Issuer      types.Address `json:"issuer"`
+	Name        string        `json:"name,omitempty"`
+	Symbol      string        `json:"symbol"`
+	Decimals    int32         `json:"decimals"`
+	MaxSupply   *uint256.Int  `json:"maxSupply"`
+	TotalSupply *uint256.Int  `json:"totalSupply"`
+
+	// MintAuthority is the account allowed to mint the token.
+	// If it is the zero address, the token can not be minted anymore.
+	MintAuthority types.Address `json:"mintAuthority"`
+	mtx           sync.RWMutex
+}
+
+var _ v1.ILedgerItem = (*Token)(nil)
+
+// TokenAddress returns the address of the token issued by `issuer` with the tx of `nonce`.
+// It is derived in the same way as the address of a contract,
+// so it does not collide with the contracts deployed by `issuer`.
+func TokenAddress(issuer types.Address, nonce int64) types.Address {
+	addr := ethcrypto.CreateAddress(issuer.Array20(), uint64(nonce))
+	return addr[:]
+}
+
+func NewToken(addr, issuer types.Address, name, symbol string, decimals int32, maxSupply *uint256.Int, mintAuthority types.Address) *Token {
+	return &Token{
+		Address:       addr,
+		Issuer:        issuer,
+		Name:          name,
+		Symbol:        symbol,
+		Decimals:      decimals,
+		MaxSupply:     maxSupply.Clone(),
+		TotalSupply:   uint256.NewInt(0),
+		MintAuthority: mintAuthority,
+	}
+}
+
+// CheckMint returns an error if minting `amt` makes the total supply exceed `MaxSupply`.
+// If `MaxSupply` is 0, the total supply is not capped.
+func (token *Token) CheckMint(amt *uint256.Int) xerrors.XError {
+	token.mtx.RLock()
+	defer token.mtx.RUnlock()
+
+	_, xerr := token.mintedSupply(amt)
+	return xerr
+}
+
+// Mint increases the total supply by `amt`.
+func (token *Token) Mint(amt *uint256.Int) xerrors.XError {
+	token.mtx.Lock()
+	defer token.mtx.Unlock()
+
+	sum, xerr := token.mintedSupply(amt)
+	if xerr != nil {
+		return xerr
+	}
+	token.TotalSupply = sum
+	return nil
+}
+
+func (token *Token) mintedSupply(amt *uint256.Int) (*uint256.Int, xerrors.XError) {
+	sum, overflow := new(uint256.Int).AddOverflow(token.TotalSupply, amt)
+	if overflow {
+		return nil, xerrors.ErrOverFlow.Wrapf("total supply of %v", token.Address)
+	}
+	if !token.MaxSupply.IsZero() && sum.Gt(token.MaxSupply) {
+		return nil, xerrors.ErrInvalidAmount.Wrapf("the total supply(%v) of %v would exceed the max supply(%v)", sum.Dec(), token.Address, token.MaxSupply.Dec())
+	}
+	return sum, nil
+}
+
+func (token *Token) Burn(amt *uint256.Int) xerrors.XError {
+	token.mtx.Lock()
+	defer token.mtx.Unlock()
+
+	if token.TotalSupply.Lt(amt) {
+		return xerrors.ErrInsufficientFund.Wrapf("total supply(%v) of %v is less than %v", token.TotalSupply.Dec(), token.Address, amt.Dec())
+	}
+	token.TotalSupply = new(uint256.Int).Sub(token.TotalSupply, amt)
+	return nil
+}
+
+func (token *Token) Encode() ([]byte, xerrors.XError) {
+	token.mtx.RLock()
+	defer token.mtx.RUnlock()
+
+	bz, err := proto.Marshal(&TokenProto{
+		Address:       token.Address,
+		Issuer:        token.Issuer,
+		Name:          token.Name,
+		Symbol:        token.Symbol,
+		Decimals:      token.Decimals,
+		XMaxSupply:    token.MaxSupply.Bytes(),
+		XTotalSupply:  token.TotalSupply.Bytes(),
+		MintAuthority: token.MintAuthority,
+	})
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	return bz, nil
+}
+
+func (token *Token) Decode(k, v []byte) xerrors.XError {
+	token.mtx.Lock()
+	defer token.mtx.Unlock()
+
+	pm := &TokenProto{}
+	if err := proto.Unmarshal(v, pm); err != nil {
+		return xerrors.From(err)
+	}
+	token.Address = pm.Address
+	token.Issuer = pm.Issuer
+	token.Name = pm.Name
+	token.Symbol = pm.Symbol
+	token.Decimals = pm.Decimals
+	token.MaxSupply = new(uint256.Int).SetBytes(pm.XMaxSupply)
+	token.TotalSupply = new(uint256.Int).SetBytes(pm.XTotalSupply)
+	token.MintAuthority = pm.MintAuthority
+	return nil
+}
+
+// TokenBalance is the balance of a native token held by an account.
+type TokenBalance struct {
+	uint256.Int
+}
+
+var _ v1.ILedgerItem = (*TokenBalance)(nil)
+
+func NewTokenBalance(amt *uint256.Int) *TokenBalance {
+	ret := &TokenBalance{}
+	ret.Set(amt)
+	return ret
+}
+
+func (bal *TokenBalance) Encode() ([]byte, xerrors.XError) {
+	return bal.Bytes(), nil
+}
+
+func (bal *TokenBalance) Decode(k, v []byte) xerrors.XError {
+	bal.SetBytes(v)
+	return nil
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"io"
 	"time"
 
@@ -21,8 +22,15 @@ const (
 	TRX_CONTRACT
 	TRX_SETDOC
 	TRX_WITHDRAW
+	TRX_SETPUBKEY
+	TRX_DEPOSIT
+	TRX_CANCEL_PROPOSAL
+	TRX_TOKEN_ISSUE
+	TRX_TOKEN_MINT
+	TRX_TOKEN_BURN
+	TRX_TOKEN_TRANSFER
 	TRX_MIN_TYPE = TRX_TRANSFER
-	TRX_MAX_TYPE = TRX_WITHDRAW
+	TRX_MAX_TYPE = TRX_TOKEN_TRANSFER
 )
 
 const (
@@ -203,6 +211,20 @@ func (tx *Trx) DecodeRLP(s *rlp.Stream) error {
 			payload = &TrxPayloadContract{}
 		case TRX_SETDOC:
 			payload = &TrxPayloadSetDoc{}
+		case TRX_SETPUBKEY:
+			payload = &TrxPayloadSetPubKey{}
+		case TRX_DEPOSIT:
+			payload = &TrxPayloadDeposit{}
+		case TRX_CANCEL_PROPOSAL:
+			payload = &TrxPayloadCancelProposal{}
+		case TRX_TOKEN_ISSUE:
+			payload = &TrxPayloadTokenIssue{}
+		case TRX_TOKEN_MINT:
+			payload = &TrxPayloadTokenMint{}
+		case TRX_TOKEN_BURN:
+			payload = &TrxPayloadTokenBurn{}
+		case TRX_TOKEN_TRANSFER:
+			payload = &TrxPayloadTokenTransfer{}
 		default:
 			return xerrors.ErrInvalidTrxPayloadType
 		}
@@ -223,6 +245,68 @@ func (tx *Trx) DecodeRLP(s *rlp.Stream) error {
 var _ rlp.Encoder = (*Trx)(nil)
 var _ rlp.Decoder = (*Trx)(nil)
 
+// UnmarshalJSON decodes the payload according to the type of the tx.
+func (tx *Trx) UnmarshalJSON(bz []byte) error {
+	type trxJSON Trx
+	aux := &struct {
+		*trxJSON
+		Payload json.RawMessage `json:"payload,omitempty"`
+	}{trxJSON: (*trxJSON)(tx)}
+	if err := json.Unmarshal(bz, aux); err != nil {
+		return err
+	}
+
+	tx.Payload = nil
+	if len(aux.Payload) > 0 && string(aux.Payload) != "null" {
+		payload := newTrxPayload(tx.Type)
+		if payload == nil {
+			return xerrors.ErrInvalidTrxPayloadType.Wrapf("unknown tx type(%v)", tx.Type)
+		}
+		if err := json.Unmarshal(aux.Payload, payload); err != nil {
+			return err
+		}
+		tx.Payload = payload
+	}
+	return nil
+}
+
+func newTrxPayload(txType int32) ITrxPayload {
+	switch txType {
+	case TRX_TRANSFER:
+		return &TrxPayloadAssetTransfer{}
+	case TRX_STAKING:
+		return &TrxPayloadStaking{}
+	case TRX_UNSTAKING:
+		return &TrxPayloadUnstaking{}
+	case TRX_WITHDRAW:
+		return &TrxPayloadWithdraw{}
+	case TRX_PROPOSAL:
+		return &TrxPayloadProposal{}
+	case TRX_VOTING:
+		return &TrxPayloadVoting{}
+	case TRX_CONTRACT:
+		return &TrxPayloadContract{}
+	case TRX_SETDOC:
+		return &TrxPayloadSetDoc{}
+	case TRX_SETPUBKEY:
+		return &TrxPayloadSetPubKey{}
+	case TRX_DEPOSIT:
+		return &TrxPayloadDeposit{}
+	case TRX_CANCEL_PROPOSAL:
+		return &TrxPayloadCancelProposal{}
+	case TRX_TOKEN_ISSUE:
+		return &TrxPayloadTokenIssue{}
+	case TRX_TOKEN_MINT:
+		return &TrxPayloadTokenMint{}
+	case TRX_TOKEN_BURN:
+		return &TrxPayloadTokenBurn{}
+	case TRX_TOKEN_TRANSFER:
+		return &TrxPayloadTokenTransfer{}
+	default:
+		return nil
+	}
+}
+
 func (tx *Trx) GetType() int32 {
 	return tx.Type
 }
@@ -288,6 +372,41 @@ func (tx *Trx) fromProto(txProto *TrxProto) xerrors.XError {
 		if err := payload.Decode(txProto.XPayload); err != nil {
 			return err
 		}
+	case TRX_SETPUBKEY:
+		payload = &TrxPayloadSetPubKey{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_DEPOSIT:
+		payload = &TrxPayloadDeposit{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_CANCEL_PROPOSAL:
+		payload = &TrxPayloadCancelProposal{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_TOKEN_ISSUE:
+		payload = &TrxPayloadTokenIssue{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_TOKEN_MINT:
+		payload = &TrxPayloadTokenMint{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_TOKEN_BURN:
+		payload = &TrxPayloadTokenBurn{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
+	case TRX_TOKEN_TRANSFER:
+		payload = &TrxPayloadTokenTransfer{}
+		if err := payload.Decode(txProto.XPayload); err != nil {
+			return err
+		}
 	default:
 		return xerrors.ErrInvalidTrxPayloadType
 	}
@@ -386,6 +505,20 @@ func TrxTypeString(t int32) string {
 		return "contract"
 	case TRX_SETDOC:
 		return "setdoc"
+	case TRX_SETPUBKEY:
+		return "setpubkey"
+	case TRX_DEPOSIT:
+		return "deposit"
+	case TRX_CANCEL_PROPOSAL:
+		return "cancel"
+	case TRX_TOKEN_ISSUE:
+		return "token_issue"
+	case TRX_TOKEN_MINT:
+		return "token_mint"
+	case TRX_TOKEN_BURN:
+		return "token_burn"
+	case TRX_TOKEN_TRANSFER:
+		return "token_transfer"
 	default:
 		return "unknown"
 	}
@@ -553,6 +553,275 @@ func (x *TrxPayloadSetDocProto) GetUrl() string {
 	return ""
 }
 
+type TrxPayloadSetPubKeyProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PubKey        []byte                 `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrxPayloadSetPubKeyProto) Reset() {
+	*x = TrxPayloadSetPubKeyProto{}
+	mi := &file_trx_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrxPayloadSetPubKeyProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrxPayloadSetPubKeyProto) ProtoMessage() {}
+
+func (x *TrxPayloadSetPubKeyProto) ProtoReflect() protoreflect.Message {
+	mi := &file_trx_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrxPayloadSetPubKeyProto.ProtoReflect.Descriptor instead.
+func (*TrxPayloadSetPubKeyProto) Descriptor() ([]byte, []int) {
+	return file_trx_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TrxPayloadSetPubKeyProto) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+type TrxPayloadDepositProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxHash        []byte                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrxPayloadDepositProto) Reset() {
+	*x = TrxPayloadDepositProto{}
+	mi := &file_trx_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrxPayloadDepositProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrxPayloadDepositProto) ProtoMessage() {}
+
+func (x *TrxPayloadDepositProto) ProtoReflect() protoreflect.Message {
+	mi := &file_trx_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrxPayloadDepositProto.ProtoReflect.Descriptor instead.
+func (*TrxPayloadDepositProto) Descriptor() ([]byte, []int) {
+	return file_trx_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TrxPayloadDepositProto) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+type TrxPayloadCancelProposalProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxHash        []byte                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrxPayloadCancelProposalProto) Reset() {
+	*x = TrxPayloadCancelProposalProto{}
+	mi := &file_trx_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrxPayloadCancelProposalProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrxPayloadCancelProposalProto) ProtoMessage() {}
+
+func (x *TrxPayloadCancelProposalProto) ProtoReflect() protoreflect.Message {
+	mi := &file_trx_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrxPayloadCancelProposalProto.ProtoReflect.Descriptor instead.
+func (*TrxPayloadCancelProposalProto) Descriptor() ([]byte, []int) {
+	return file_trx_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TrxPayloadCancelProposalProto) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+type TrxPayloadTokenIssueProto struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol         string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals       int32                  `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	XMaxSupply     []byte                 `protobuf:"bytes,4,opt,name=_max_supply,json=MaxSupply,proto3" json:"_max_supply,omitempty"`
+	XInitialSupply []byte                 `protobuf:"bytes,5,opt,name=_initial_supply,json=InitialSupply,proto3" json:"_initial_supply,omitempty"`
+	MintAuthority  []byte                 `protobuf:"bytes,6,opt,name=mint_authority,json=mintAuthority,proto3" json:"mint_authority,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TrxPayloadTokenIssueProto) Reset() {
+	*x = TrxPayloadTokenIssueProto{}
+	mi := &file_trx_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrxPayloadTokenIssueProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrxPayloadTokenIssueProto) ProtoMessage() {}
+
+func (x *TrxPayloadTokenIssueProto) ProtoReflect() protoreflect.Message {
+	mi := &file_trx_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrxPayloadTokenIssueProto.ProtoReflect.Descriptor instead.
+func (*TrxPayloadTokenIssueProto) Descriptor() ([]byte, []int) {
+	return file_trx_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TrxPayloadTokenIssueProto) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrxPayloadTokenIssueProto) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TrxPayloadTokenIssueProto) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *TrxPayloadTokenIssueProto) GetXMaxSupply() []byte {
+	if x != nil {
+		return x.XMaxSupply
+	}
+	return nil
+}
+
+func (x *TrxPayloadTokenIssueProto) GetXInitialSupply() []byte {
+	if x != nil {
+		return x.XInitialSupply
+	}
+	return nil
+}
+
+func (x *TrxPayloadTokenIssueProto) GetMintAuthority() []byte {
+	if x != nil {
+		return x.MintAuthority
+	}
+	return nil
+}
+
+// TrxPayloadTokenAmountProto is the payload of TRX_TOKEN_MINT, TRX_TOKEN_BURN and TRX_TOKEN_TRANSFER.
+type TrxPayloadTokenAmountProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         []byte                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XAmount       []byte                 `protobuf:"bytes,2,opt,name=_amount,json=Amount,proto3" json:"_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrxPayloadTokenAmountProto) Reset() {
+	*x = TrxPayloadTokenAmountProto{}
+	mi := &file_trx_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrxPayloadTokenAmountProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrxPayloadTokenAmountProto) ProtoMessage() {}
+
+func (x *TrxPayloadTokenAmountProto) ProtoReflect() protoreflect.Message {
+	mi := &file_trx_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrxPayloadTokenAmountProto.ProtoReflect.Descriptor instead.
+func (*TrxPayloadTokenAmountProto) Descriptor() ([]byte, []int) {
+	return file_trx_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TrxPayloadTokenAmountProto) GetToken() []byte {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *TrxPayloadTokenAmountProto) GetXAmount() []byte {
+	if x != nil {
+		return x.XAmount
+	}
+	return nil
+}
+
 var File_trx_proto protoreflect.FileDescriptor
 
 const file_trx_proto_rawDesc = "" +
@@ -593,7 +862,23 @@ const file_trx_proto_rawDesc = "" +
 	"\x06choice\x18\x02 \x01(\x05R\x06choice\"=\n" +
 	"\x15TrxPayloadSetDocProto\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
-	"\x03url\x18\x02 \x01(\tR\x03urlB+Z)github.com/beatoz/beatoz-go/ctrlers/typesb\x06proto3"
+	"\x03url\x18\x02 \x01(\tR\x03url\"3\n" +
+	"\x18TrxPayloadSetPubKeyProto\x12\x17\n" +
+	"\apub_key\x18\x01 \x01(\fR\x06pubKey\"1\n" +
+	"\x16TrxPayloadDepositProto\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\fR\x06txHash\"8\n" +
+	"\x1dTrxPayloadCancelProposalProto\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\fR\x06txHash\"\xd2\x01\n" +
+	"\x19TrxPayloadTokenIssueProto\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\x03 \x01(\x05R\bdecimals\x12\x1e\n" +
+	"\v_max_supply\x18\x04 \x01(\fR\tMaxSupply\x12&\n" +
+	"\x0f_initial_supply\x18\x05 \x01(\fR\rInitialSupply\x12%\n" +
+	"\x0emint_authority\x18\x06 \x01(\fR\rmintAuthority\"K\n" +
+	"\x1aTrxPayloadTokenAmountProto\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\fR\x05token\x12\x17\n" +
+	"\a_amount\x18\x02 \x01(\fR\x06AmountB+Z)github.com/beatoz/beatoz-go/ctrlers/typesb\x06proto3"
 
 var (
 	file_trx_proto_rawDescOnce sync.Once
@@ -607,17 +892,22 @@ func file_trx_proto_rawDescGZIP() []byte {
 	return file_trx_proto_rawDescData
 }
 
-var file_trx_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_trx_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_trx_proto_goTypes = []any{
-	(*TrxProto)(nil),                     // 0: types.TrxProto
-	(*TrxPayloadAssetTransferProto)(nil), // 1: types.TrxPayloadAssetTransferProto
-	(*TrxPayloadStakingProto)(nil),       // 2: types.TrxPayloadStakingProto
-	(*TrxPayloadUnstakingProto)(nil),     // 3: types.TrxPayloadUnstakingProto
-	(*TrxPayloadWithdrawProto)(nil),      // 4: types.TrxPayloadWithdrawProto
-	(*TrxPayloadContractProto)(nil),      // 5: types.TrxPayloadContractProto
-	(*TrxPayloadProposalProto)(nil),      // 6: types.TrxPayloadProposalProto
-	(*TrxPayloadVotingProto)(nil),        // 7: types.TrxPayloadVotingProto
-	(*TrxPayloadSetDocProto)(nil),        // 8: types.TrxPayloadSetDocProto
+	(*TrxProto)(nil),                      // 0: types.TrxProto
+	(*TrxPayloadAssetTransferProto)(nil),  // 1: types.TrxPayloadAssetTransferProto
+	(*TrxPayloadStakingProto)(nil),        // 2: types.TrxPayloadStakingProto
+	(*TrxPayloadUnstakingProto)(nil),      // 3: types.TrxPayloadUnstakingProto
+	(*TrxPayloadWithdrawProto)(nil),       // 4: types.TrxPayloadWithdrawProto
+	(*TrxPayloadContractProto)(nil),       // 5: types.TrxPayloadContractProto
+	(*TrxPayloadProposalProto)(nil),       // 6: types.TrxPayloadProposalProto
+	(*TrxPayloadVotingProto)(nil),         // 7: types.TrxPayloadVotingProto
+	(*TrxPayloadSetDocProto)(nil),         // 8: types.TrxPayloadSetDocProto
+	(*TrxPayloadSetPubKeyProto)(nil),      // 9: types.TrxPayloadSetPubKeyProto
+	(*TrxPayloadDepositProto)(nil),        // 10: types.TrxPayloadDepositProto
+	(*TrxPayloadCancelProposalProto)(nil), // 11: types.TrxPayloadCancelProposalProto
+	(*TrxPayloadTokenIssueProto)(nil),     // 12: types.TrxPayloadTokenIssueProto
+	(*TrxPayloadTokenAmountProto)(nil),    // 13: types.TrxPayloadTokenAmountProto
 }
 var file_trx_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -638,7 +928,7 @@ func file_trx_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_trx_proto_rawDesc), len(file_trx_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
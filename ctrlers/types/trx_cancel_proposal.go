@@ -0,0 +1,66 @@
+package types
+
+import (
+	"io"
+
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/ethereum/go-ethereum/rlp"
+	"google.golang.org/protobuf/proto"
+)
+
+// TrxPayloadCancelProposal cancels the proposal made by the tx `TxHash`. Only the proposer can send it.
+type TrxPayloadCancelProposal struct {
+	TxHash bytes.HexBytes `json:"txHash"`
+}
+
+func (tx *TrxPayloadCancelProposal) Type() int32 {
+	return TRX_CANCEL_PROPOSAL
+}
+
+func (tx *TrxPayloadCancelProposal) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadCancelProposal)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(tx.TxHash, _tx0.TxHash)
+}
+
+func (tx *TrxPayloadCancelProposal) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadCancelProposalProto{
+		TxHash: tx.TxHash,
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadCancelProposal) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadCancelProposalProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.TxHash = pm.TxHash
+	return nil
+}
+
+func (tx *TrxPayloadCancelProposal) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{tx.TxHash})
+}
+
+func (tx *TrxPayloadCancelProposal) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		TxHash bytes.HexBytes
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.TxHash = item.TxHash
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadCancelProposal)(nil)
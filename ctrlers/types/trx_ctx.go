@@ -19,6 +19,8 @@ type TrxContext struct {
 	TxHash bytes2.HexBytes
 	Exec   bool
 
+	// SenderPubKey is the secp256k1 public key recovered from the sender's signature.
+	// It is nil if the tx is signed with the WebAuthn assertion.
 	SenderPubKey []byte
 	Sender       *Account
 	Receiver     *Account
@@ -63,28 +65,54 @@ func NewTrxContext(txbz []byte, bctx *BlockContext, exec bool) (*TrxContext, xer
 
 	//
 	// verify signature.
-	_, pubKeyBytes, xerr := VerifyTrxRLP(tx)
-	if xerr != nil {
-		return nil, xerr
+	if IsWebAuthnSig(tx.Sig) {
+		// the P-256 public key registered to the sender's account verifies the signature.
+		// `SenderPubKey` is left nil since it is not the secp256k1 key.
+		txctx.Sender = txctx.BlockContext.AcctHandler.FindAccount(tx.From, txctx.Exec)
+		if txctx.Sender == nil {
+			return nil, xerrors.ErrNotFoundAccount.Wrapf("sender address: %v", tx.From)
+		}
+		if xerr := VerifyTrxWebAuthn(tx, txctx.Sender.GetPubKeyP256()); xerr != nil {
+			return nil, xerr
+		}
+	} else {
+		_, pubKeyBytes, xerr := VerifyTrxRLP(tx)
+		if xerr != nil {
+			return nil, xerr
+		}
+		txctx.SenderPubKey = pubKeyBytes
 	}
-	txctx.SenderPubKey = pubKeyBytes
 
 	//
 	// verify payer's signature.
 	var payerAddr types.Address
 	if tx.PayerSig != nil {
-		payerAddr, _, xerr = VerifyPayerTrxRLP(tx)
-		if xerr != nil {
-			return nil, xerr.Wrap(errors.New("payer signature is invalid"))
+		if IsWebAuthnSig(tx.PayerSig) {
+			payer := txctx.BlockContext.AcctHandler.FindAccount(tx.Payer, txctx.Exec)
+			if payer == nil {
+				return nil, xerrors.ErrNotFoundAccount.Wrapf("payer address: %v", tx.Payer)
+			}
+			if xerr := VerifyPayerTrxWebAuthn(tx, payer.GetPubKeyP256()); xerr != nil {
+				return nil, xerr.Wrap(errors.New("payer signature is invalid"))
+			}
+			payerAddr = tx.Payer
+		} else {
+			_payerAddr, _, xerr := VerifyPayerTrxRLP(tx)
+			if xerr != nil {
+				return nil, xerr.Wrap(errors.New("payer signature is invalid"))
+			}
+			payerAddr = _payerAddr
 		}
 	}
 
 	//
 	//
 
-	txctx.Sender = txctx.BlockContext.AcctHandler.FindAccount(tx.From, txctx.Exec)
 	if txctx.Sender == nil {
-		return nil, xerrors.ErrNotFoundAccount.Wrapf("sender address: %v", tx.From)
+		txctx.Sender = txctx.BlockContext.AcctHandler.FindAccount(tx.From, txctx.Exec)
+		if txctx.Sender == nil {
+			return nil, xerrors.ErrNotFoundAccount.Wrapf("sender address: %v", tx.From)
+		}
 	}
 
 	// RG-91: Find the account object with the destination address 0x0.
@@ -1,9 +1,16 @@
 package types_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"testing"
 	"time"
 
+	bzweb3 "github.com/beatoz/beatoz-go/cmd/commands/web3"
 	"github.com/beatoz/beatoz-go/ctrlers/mocks/acct"
 	"github.com/beatoz/beatoz-go/ctrlers/mocks/gov"
 	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
@@ -202,3 +209,102 @@ func newTrxCtx(tx *ctrlertypes.Trx, height int64) (*ctrlertypes.TrxContext, xerr
 	bz, _ := tx.Encode()
 	return ctrlertypes.NewTrxContext(bz, bctx, true)
 }
+
+func Test_NewTrxContext_WebAuthn(t *testing.T) {
+	w0 := acctMock.RandWallet()
+	w1 := web3.NewWallet(nil)
+	passkey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	//
+	// no registered key
+	tx := web3.NewTrxTransfer(w0.Address(), w1.Address(), 0, govMock.MinTrxGas(), govMock.GasPrice(), uint256.NewInt(1000))
+	signWebAuthn(t, tx, passkey, ctrlertypes.WebAuthnTypeGet, 0x05, false)
+	_, xerr := newTrxCtx(tx, 1)
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxSig.Error())
+
+	w0.GetAccount().SetPubKeyP256(elliptic.MarshalCompressed(elliptic.P256(), passkey.X, passkey.Y))
+	defer w0.GetAccount().SetPubKeyP256(nil)
+
+	//
+	// success
+	txctx, xerr := newTrxCtx(tx, 1)
+	require.NoError(t, xerr)
+	require.Equal(t, w0.Address(), txctx.Sender.Address)
+	require.Nil(t, txctx.SenderPubKey)
+	require.Equal(t, w0.Address(), txctx.Payer.Address)
+
+	//
+	// tampered tx
+	tx.Amount = uint256.NewInt(1001)
+	_, xerr = newTrxCtx(tx, 1)
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxSig.Error())
+
+	//
+	// wrong client data type
+	signWebAuthn(t, tx, passkey, "webauthn.create", 0x05, false)
+	_, xerr = newTrxCtx(tx, 1)
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxSig.Error())
+
+	//
+	// no user presence
+	signWebAuthn(t, tx, passkey, ctrlertypes.WebAuthnTypeGet, 0x04, false)
+	_, xerr = newTrxCtx(tx, 1)
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxSig.Error())
+
+	//
+	// other key
+	otherkey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signWebAuthn(t, tx, otherkey, ctrlertypes.WebAuthnTypeGet, 0x05, false)
+	_, xerr = newTrxCtx(tx, 1)
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxSig.Error())
+
+	//
+	// payer signs with the passkey
+	payer := acctMock.RandWallet()
+	for bytes.Equal(payer.Address(), w0.Address()) {
+		payer = acctMock.RandWallet()
+	}
+	tx = web3.NewTrxTransfer(payer.Address(), w1.Address(), 0, govMock.MinTrxGas(), govMock.GasPrice(), uint256.NewInt(1000))
+	_, _, err = payer.SignTrxRLP(tx, chainId.Hex())
+	require.NoError(t, err)
+	tx.Payer = w0.Address()
+	signWebAuthn(t, tx, passkey, ctrlertypes.WebAuthnTypeGet, 0x05, true)
+	txctx, xerr = newTrxCtx(tx, 1)
+	require.NoError(t, xerr)
+	require.Equal(t, payer.Address(), txctx.Sender.Address)
+	require.NotNil(t, txctx.SenderPubKey)
+	require.Equal(t, w0.Address(), txctx.Payer.Address)
+}
+
+// signWebAuthn signs `tx` as an authenticator does with `navigator.credentials.get()`.
+func signWebAuthn(t *testing.T, tx *ctrlertypes.Trx, key *ecdsa.PrivateKey, typ string, flags byte, isPayer bool) {
+	signer := ctrlertypes.NewSignerWebAuthn(chainId)
+	challenge, xerr := signer.ChallengeSender(tx)
+	if isPayer {
+		challenge, xerr = signer.ChallengePayer(tx)
+	}
+	require.NoError(t, xerr)
+
+	clientDataJSON, err := json.Marshal(map[string]interface{}{
+		"type":        typ,
+		"challenge":   base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":      "https://wallet.beatoz.io",
+		"crossOrigin": false,
+	})
+	require.NoError(t, err)
+	rpIdHash := sha256.Sum256([]byte("wallet.beatoz.io"))
+	authData := append(rpIdHash[:], flags, 0, 0, 0, 1)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	msg := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, msg[:])
+	require.NoError(t, err)
+
+	if isPayer {
+		require.NoError(t, bzweb3.SetPayerTrxWebAuthnSig(tx, authData, clientDataJSON, sig))
+	} else {
+		require.NoError(t, bzweb3.SetTrxWebAuthnSig(tx, authData, clientDataJSON, sig))
+	}
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"io"
+
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/ethereum/go-ethereum/rlp"
+	"google.golang.org/protobuf/proto"
+)
+
+// TrxPayloadDeposit adds the tx amount to the deposit of the proposal made by the tx `TxHash`.
+type TrxPayloadDeposit struct {
+	TxHash bytes.HexBytes `json:"txHash"`
+}
+
+func (tx *TrxPayloadDeposit) Type() int32 {
+	return TRX_DEPOSIT
+}
+
+func (tx *TrxPayloadDeposit) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadDeposit)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(tx.TxHash, _tx0.TxHash)
+}
+
+func (tx *TrxPayloadDeposit) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadDepositProto{
+		TxHash: tx.TxHash,
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadDeposit) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadDepositProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.TxHash = pm.TxHash
+	return nil
+}
+
+func (tx *TrxPayloadDeposit) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{tx.TxHash})
+}
+
+func (tx *TrxPayloadDeposit) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		TxHash bytes.HexBytes
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.TxHash = item.TxHash
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadDeposit)(nil)
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"io"
+
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/ethereum/go-ethereum/rlp"
+	"google.golang.org/protobuf/proto"
+)
+
+// TrxPayloadSetPubKey registers the secp256r1(P-256) public key, like a passkey, to the sender's account.
+// `PubKey` is the compressed(33 bytes) or uncompressed(65 bytes) public key.
+// If `PubKey` is empty, the registered key is removed.
+type TrxPayloadSetPubKey struct {
+	PubKey bytes.HexBytes `json:"pubKey,omitempty"`
+}
+
+func (tx *TrxPayloadSetPubKey) Type() int32 {
+	return TRX_SETPUBKEY
+}
+
+func (tx *TrxPayloadSetPubKey) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadSetPubKey)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(tx.PubKey, _tx0.PubKey)
+}
+
+func (tx *TrxPayloadSetPubKey) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadSetPubKeyProto{
+		PubKey: tx.PubKey,
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadSetPubKey) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadSetPubKeyProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.PubKey = pm.PubKey
+	return nil
+}
+
+func (tx *TrxPayloadSetPubKey) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{tx.PubKey})
+}
+
+func (tx *TrxPayloadSetPubKey) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		PubKey bytes.HexBytes
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.PubKey = item.PubKey
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadSetPubKey)(nil)
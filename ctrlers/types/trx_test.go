@@ -1,6 +1,7 @@
 package types_test
 
 import (
+	"encoding/json"
 	"io"
 	"math/rand"
 	"testing"
@@ -160,6 +161,226 @@ func TestRLP_TrxPayloadSetDoc(t *testing.T) {
 	require.Equal(t, bz0, bz1)
 }
 
+func TestRLP_TrxPayloadSetPubKey(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	tx0 := &types2.Trx{
+		Version:  1,
+		Time:     time.Now().UnixNano(),
+		Nonce:    rand.Int63(),
+		From:     w.Address(),
+		To:       types.ZeroAddress(),
+		Amount:   uint256.NewInt(0),
+		Gas:      rand.Int63(),
+		GasPrice: uint256.NewInt(rand.Uint64()),
+		Type:     types2.TRX_SETPUBKEY,
+		Payload: &types2.TrxPayloadSetPubKey{
+			PubKey: bytes.RandBytes(33),
+		},
+	}
+	_, _, err := w.SignTrxRLP(tx0, chainId.Hex())
+	require.NoError(t, err)
+
+	bz0, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+
+	tx1 := &types2.Trx{}
+	err = rlp.DecodeBytes(bz0, tx1)
+	require.NoError(t, err)
+	require.True(t, tx0.Payload.Equal(tx1.Payload))
+
+	bz1, err := rlp.EncodeToBytes(tx1)
+	require.NoError(t, err)
+	require.Equal(t, bz0, bz1)
+
+	// protobuf
+	bz0, xerr := tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz0))
+	require.True(t, tx0.Payload.Equal(tx2.Payload))
+}
+
+func TestRLP_TrxPayloadDeposit(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	tx0 := &types2.Trx{
+		Version:  1,
+		Time:     time.Now().UnixNano(),
+		Nonce:    rand.Int63(),
+		From:     w.Address(),
+		To:       types.ZeroAddress(),
+		Amount:   uint256.NewInt(rand.Uint64()),
+		Gas:      rand.Int63(),
+		GasPrice: uint256.NewInt(rand.Uint64()),
+		Type:     types2.TRX_DEPOSIT,
+		Payload: &types2.TrxPayloadDeposit{
+			TxHash: bytes.RandBytes(32),
+		},
+	}
+	_, _, err := w.SignTrxRLP(tx0, chainId.Hex())
+	require.NoError(t, err)
+
+	bz0, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+
+	tx1 := &types2.Trx{}
+	err = rlp.DecodeBytes(bz0, tx1)
+	require.NoError(t, err)
+	require.True(t, tx0.Payload.Equal(tx1.Payload))
+
+	bz1, err := rlp.EncodeToBytes(tx1)
+	require.NoError(t, err)
+	require.Equal(t, bz0, bz1)
+
+	// protobuf
+	bz0, xerr := tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz0))
+	require.True(t, tx0.Payload.Equal(tx2.Payload))
+	require.Equal(t, tx0.Amount, tx2.Amount)
+}
+
+func TestRLP_TrxPayloadCancelProposal(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	tx0 := &types2.Trx{
+		Version:  1,
+		Time:     time.Now().UnixNano(),
+		Nonce:    rand.Int63(),
+		From:     w.Address(),
+		To:       types.ZeroAddress(),
+		Amount:   uint256.NewInt(0),
+		Gas:      rand.Int63(),
+		GasPrice: uint256.NewInt(rand.Uint64()),
+		Type:     types2.TRX_CANCEL_PROPOSAL,
+		Payload: &types2.TrxPayloadCancelProposal{
+			TxHash: bytes.RandBytes(32),
+		},
+	}
+	_, _, err := w.SignTrxRLP(tx0, chainId.Hex())
+	require.NoError(t, err)
+
+	bz0, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+
+	tx1 := &types2.Trx{}
+	err = rlp.DecodeBytes(bz0, tx1)
+	require.NoError(t, err)
+	require.True(t, tx0.Payload.Equal(tx1.Payload))
+
+	bz1, err := rlp.EncodeToBytes(tx1)
+	require.NoError(t, err)
+	require.Equal(t, bz0, bz1)
+
+	// protobuf
+	bz0, xerr := tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz0))
+	require.True(t, tx0.Payload.Equal(tx2.Payload))
+	require.Equal(t, tx0.Amount, tx2.Amount)
+}
+
+func TestRLP_TrxPayloadTokenIssue(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	tx0 := &types2.Trx{
+		Version:  1,
+		Time:     time.Now().UnixNano(),
+		Nonce:    rand.Int63(),
+		From:     w.Address(),
+		To:       types.ZeroAddress(),
+		Amount:   uint256.NewInt(0),
+		Gas:      rand.Int63(),
+		GasPrice: uint256.NewInt(rand.Uint64()),
+		Type:     types2.TRX_TOKEN_ISSUE,
+		Payload: &types2.TrxPayloadTokenIssue{
+			Name:          "Test Token",
+			Symbol:        "TST",
+			Decimals:      18,
+			MaxSupply:     uint256.NewInt(rand.Uint64()),
+			InitialSupply: uint256.NewInt(rand.Uint64()),
+			MintAuthority: w.Address(),
+		},
+	}
+	_, _, err := w.SignTrxRLP(tx0, chainId.Hex())
+	require.NoError(t, err)
+
+	bz0, err := rlp.EncodeToBytes(tx0)
+	require.NoError(t, err)
+
+	tx1 := &types2.Trx{}
+	err = rlp.DecodeBytes(bz0, tx1)
+	require.NoError(t, err)
+	require.True(t, tx0.Payload.Equal(tx1.Payload))
+
+	bz1, err := rlp.EncodeToBytes(tx1)
+	require.NoError(t, err)
+	require.Equal(t, bz0, bz1)
+
+	// protobuf
+	bz0, xerr := tx0.Encode()
+	require.NoError(t, xerr)
+	tx2 := &types2.Trx{}
+	require.NoError(t, tx2.Decode(bz0))
+	require.True(t, tx0.Payload.Equal(tx2.Payload))
+}
+
+func TestRLP_TrxPayloadTokenTransfer(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	payloads := []types2.ITrxPayload{
+		&types2.TrxPayloadTokenMint{TokenAmount: types2.TokenAmount{Token: types.RandAddress(), Amount: uint256.NewInt(rand.Uint64())}},
+		&types2.TrxPayloadTokenBurn{TokenAmount: types2.TokenAmount{Token: types.RandAddress(), Amount: uint256.NewInt(rand.Uint64())}},
+		&types2.TrxPayloadTokenTransfer{TokenAmount: types2.TokenAmount{Token: types.RandAddress(), Amount: uint256.NewInt(rand.Uint64())}},
+	}
+	// the payloads sharing `TokenAmount` are not equal to each other
+	require.False(t, payloads[0].Equal(payloads[1]))
+
+	for _, payload := range payloads {
+		tx0 := &types2.Trx{
+			Version:  1,
+			Time:     time.Now().UnixNano(),
+			Nonce:    rand.Int63(),
+			From:     w.Address(),
+			To:       types.RandAddress(),
+			Amount:   uint256.NewInt(0),
+			Gas:      rand.Int63(),
+			GasPrice: uint256.NewInt(rand.Uint64()),
+			Type:     payload.Type(),
+			Payload:  payload,
+		}
+		_, _, err := w.SignTrxRLP(tx0, chainId.Hex())
+		require.NoError(t, err)
+
+		bz0, err := rlp.EncodeToBytes(tx0)
+		require.NoError(t, err)
+
+		tx1 := &types2.Trx{}
+		err = rlp.DecodeBytes(bz0, tx1)
+		require.NoError(t, err)
+		require.True(t, tx0.Payload.Equal(tx1.Payload))
+
+		bz1, err := rlp.EncodeToBytes(tx1)
+		require.NoError(t, err)
+		require.Equal(t, bz0, bz1)
+
+		// protobuf
+		bz0, xerr := tx0.Encode()
+		require.NoError(t, xerr)
+		tx2 := &types2.Trx{}
+		require.NoError(t, tx2.Decode(bz0))
+		require.True(t, tx0.Payload.Equal(tx2.Payload))
+	}
+}
+
 func TestRLP_TrxPayloadProposal(t *testing.T) {
 	w := web3.NewWallet([]byte("1"))
 	require.NoError(t, w.Unlock([]byte("1")))
@@ -286,3 +507,61 @@ func (tx *maliciousPayload) DecodeRLP(s *rlp.Stream) error {
 }
 
 var _ types2.ITrxPayload = (*maliciousPayload)(nil)
+
+func TestTrxJSON(t *testing.T) {
+	w := web3.NewWallet([]byte("1"))
+	require.NoError(t, w.Unlock([]byte("1")))
+
+	payloads := []types2.ITrxPayload{
+		&types2.TrxPayloadAssetTransfer{},
+		&types2.TrxPayloadStaking{},
+		&types2.TrxPayloadUnstaking{TxHash: bytes.RandBytes(32)},
+		&types2.TrxPayloadWithdraw{ReqAmt: bytes.RandU256Int()},
+		&types2.TrxPayloadProposal{
+			Message:            "proposal",
+			StartVotingHeight:  10,
+			VotingPeriodBlocks: 100,
+			ApplyingHeight:     200,
+			OptType:            1,
+			Options:            [][]byte{bytes.RandBytes(32)},
+		},
+		&types2.TrxPayloadVoting{TxHash: bytes.RandBytes(32), Choice: 1},
+		&types2.TrxPayloadContract{Data: bytes.RandBytes(100)},
+		&types2.TrxPayloadSetDoc{Name: "test account doc", URL: "https://test.account.doc/1"},
+		&types2.TrxPayloadSetPubKey{PubKey: bytes.RandBytes(33)},
+		&types2.TrxPayloadTokenIssue{Name: "test token", Symbol: "TST", Decimals: 18,
+			MaxSupply: bytes.RandU256Int(), InitialSupply: bytes.RandU256Int(), MintAuthority: types.RandAddress()},
+		&types2.TrxPayloadTokenTransfer{TokenAmount: types2.TokenAmount{Token: types.RandAddress(), Amount: bytes.RandU256Int()}},
+	}
+	for _, payload := range payloads {
+		tx0 := types2.NewTrx(1, w.Address(), types.RandAddress(), rand.Int63(), rand.Int63(), uint256.NewInt(rand.Uint64()), bytes.RandU256Int(), payload)
+		_, _, err := w.SignTrxRLP(tx0, chainId.Hex())
+		require.NoError(t, err)
+
+		jz, err := json.Marshal(tx0)
+		require.NoError(t, err)
+
+		tx1 := &types2.Trx{}
+		require.NoError(t, json.Unmarshal(jz, tx1))
+		require.True(t, tx0.Equal(tx1), string(jz))
+
+		_, _, xerr := types2.VerifyTrxRLP(tx1)
+		require.NoError(t, xerr)
+	}
+
+	// no payload
+	tx0 := types2.NewTrx(1, w.Address(), types.RandAddress(), 1, 1, uint256.NewInt(1), uint256.NewInt(1), &types2.TrxPayloadAssetTransfer{})
+	tx0.Payload = nil
+	jz, err := json.Marshal(tx0)
+	require.NoError(t, err)
+	tx1 := &types2.Trx{}
+	require.NoError(t, json.Unmarshal(jz, tx1))
+	require.Nil(t, tx1.Payload)
+
+	// unknown type
+	tx0.Type = 100
+	tx0.Payload = &types2.TrxPayloadAssetTransfer{}
+	jz, err = json.Marshal(tx0)
+	require.NoError(t, err)
+	require.Error(t, json.Unmarshal(jz, &types2.Trx{}))
+}
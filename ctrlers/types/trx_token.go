@@ -0,0 +1,216 @@
+package types
+
+import (
+	"io"
+
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	"google.golang.org/protobuf/proto"
+)
+
+// TrxPayloadTokenIssue issues a new native token whose address is `TokenAddress(tx.From, tx.Nonce)`.
+// `InitialSupply` is minted to the issuer.
+// If `MaxSupply` is 0, the total supply is not capped.
+type TrxPayloadTokenIssue struct {
+	Name          string        `json:"name,omitempty"`
+	Symbol        string        `json:"symbol"`
+	Decimals      int32         `json:"decimals"`
+	MaxSupply     *uint256.Int  `json:"maxSupply"`
+	InitialSupply *uint256.Int  `json:"initialSupply"`
+	MintAuthority types.Address `json:"mintAuthority"`
+}
+
+func (tx *TrxPayloadTokenIssue) Type() int32 {
+	return TRX_TOKEN_ISSUE
+}
+
+func (tx *TrxPayloadTokenIssue) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadTokenIssue)
+	if !ok {
+		return false
+	}
+	return tx.Name == _tx0.Name &&
+		tx.Symbol == _tx0.Symbol &&
+		tx.Decimals == _tx0.Decimals &&
+		tx.MaxSupply.Eq(_tx0.MaxSupply) &&
+		tx.InitialSupply.Eq(_tx0.InitialSupply) &&
+		bytes.Equal(tx.MintAuthority, _tx0.MintAuthority)
+}
+
+func (tx *TrxPayloadTokenIssue) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadTokenIssueProto{
+		Name:           tx.Name,
+		Symbol:         tx.Symbol,
+		Decimals:       tx.Decimals,
+		XMaxSupply:     tx.MaxSupply.Bytes(),
+		XInitialSupply: tx.InitialSupply.Bytes(),
+		MintAuthority:  tx.MintAuthority,
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (tx *TrxPayloadTokenIssue) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadTokenIssueProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	tx.Name = pm.Name
+	tx.Symbol = pm.Symbol
+	tx.Decimals = pm.Decimals
+	tx.MaxSupply = new(uint256.Int).SetBytes(pm.XMaxSupply)
+	tx.InitialSupply = new(uint256.Int).SetBytes(pm.XInitialSupply)
+	tx.MintAuthority = pm.MintAuthority
+	return nil
+}
+
+func (tx *TrxPayloadTokenIssue) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{
+		tx.Name, tx.Symbol, uint64(tx.Decimals),
+		tx.MaxSupply.Bytes(), tx.InitialSupply.Bytes(), tx.MintAuthority,
+	})
+}
+
+func (tx *TrxPayloadTokenIssue) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		Name, Symbol             string
+		Decimals                 uint64
+		MaxSupply, InitialSupply []byte
+		MintAuthority            types.Address
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	tx.Name = item.Name
+	tx.Symbol = item.Symbol
+	tx.Decimals = int32(item.Decimals)
+	tx.MaxSupply = new(uint256.Int).SetBytes(item.MaxSupply)
+	tx.InitialSupply = new(uint256.Int).SetBytes(item.InitialSupply)
+	tx.MintAuthority = item.MintAuthority
+	return nil
+}
+
+var _ ITrxPayload = (*TrxPayloadTokenIssue)(nil)
+
+// TokenAmount is the common payload of TRX_TOKEN_MINT, TRX_TOKEN_BURN and TRX_TOKEN_TRANSFER.
+// The receiver of the minted or transferred token is `tx.To`.
+type TokenAmount struct {
+	Token  types.Address `json:"token"`
+	Amount *uint256.Int  `json:"amount"`
+}
+
+func (ta *TokenAmount) equal(_ta *TokenAmount) bool {
+	return bytes.Equal(ta.Token, _ta.Token) && ta.Amount.Eq(_ta.Amount)
+}
+
+func (ta *TokenAmount) Encode() ([]byte, xerrors.XError) {
+	pm := &TrxPayloadTokenAmountProto{
+		Token:   ta.Token,
+		XAmount: ta.Amount.Bytes(),
+	}
+
+	bz, err := proto.Marshal(pm)
+	return bz, xerrors.From(err)
+}
+
+func (ta *TokenAmount) Decode(bz []byte) xerrors.XError {
+	pm := &TrxPayloadTokenAmountProto{}
+	if err := proto.Unmarshal(bz, pm); err != nil {
+		return xerrors.From(err)
+	}
+
+	ta.Token = pm.Token
+	ta.Amount = new(uint256.Int).SetBytes(pm.XAmount)
+	return nil
+}
+
+func (ta *TokenAmount) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{ta.Token, ta.Amount.Bytes()})
+}
+
+func (ta *TokenAmount) DecodeRLP(s *rlp.Stream) error {
+	var item struct {
+		Token  types.Address
+		Amount []byte
+	}
+	if err := s.Decode(&item); err != nil {
+		return err
+	}
+	ta.Token = item.Token
+	ta.Amount = new(uint256.Int).SetBytes(item.Amount)
+	return nil
+}
+
+// TrxPayloadTokenMint mints `Amount` of `Token` to `tx.To`. Only the mint authority of the token can send it.
+type TrxPayloadTokenMint struct {
+	TokenAmount
+}
+
+func (tx *TrxPayloadTokenMint) Type() int32 {
+	return TRX_TOKEN_MINT
+}
+
+func (tx *TrxPayloadTokenMint) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadTokenMint)
+	if !ok {
+		return false
+	}
+	return tx.equal(&_tx0.TokenAmount)
+}
+
+var _ ITrxPayload = (*TrxPayloadTokenMint)(nil)
+
+// TrxPayloadTokenBurn burns `Amount` of `Token` held by the sender.
+type TrxPayloadTokenBurn struct {
+	TokenAmount
+}
+
+func (tx *TrxPayloadTokenBurn) Type() int32 {
+	return TRX_TOKEN_BURN
+}
+
+func (tx *TrxPayloadTokenBurn) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadTokenBurn)
+	if !ok {
+		return false
+	}
+	return tx.equal(&_tx0.TokenAmount)
+}
+
+var _ ITrxPayload = (*TrxPayloadTokenBurn)(nil)
+
+// TrxPayloadTokenTransfer transfers `Amount` of `Token` from the sender to `tx.To`.
+type TrxPayloadTokenTransfer struct {
+	TokenAmount
+}
+
+func (tx *TrxPayloadTokenTransfer) Type() int32 {
+	return TRX_TOKEN_TRANSFER
+}
+
+func (tx *TrxPayloadTokenTransfer) Equal(_tx ITrxPayload) bool {
+	if _tx == nil {
+		return false
+	}
+	_tx0, ok := (_tx).(*TrxPayloadTokenTransfer)
+	if !ok {
+		return false
+	}
+	return tx.equal(&_tx0.TokenAmount)
+}
+
+var _ ITrxPayload = (*TrxPayloadTokenTransfer)(nil)
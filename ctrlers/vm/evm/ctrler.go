@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -65,13 +67,53 @@ var (
 	}
 )
 
+// evmChainConfigAt returns the copy of `base` whose time based forks are set by `forks` at `height`.
+// The fork activated at `height` has the activation time 0 and the others have nil,
+// so the EVM rules are decided by the block height regardless of the block time.
+func evmChainConfigAt(base *params.ChainConfig, forks ctrlertypes.EVMForks, height int64) *params.ChainConfig {
+	forkTime := func(activated bool) *uint64 {
+		if activated {
+			return new(uint64) // 0
+		}
+		return nil
+	}
+
+	ret := *base
+	ret.ShanghaiTime = forkTime(forks.IsShanghai(height))
+	ret.CancunTime = forkTime(forks.IsCancun(height))
+	return &ret
+}
+
 func blockKey(h int64) []byte {
 	return []byte(fmt.Sprintf("bn%v", h))
 }
 
+// RootHashAt returns the state root hash at `height` read from the database in `dbDir`.
+// It is used to inspect the data directory of a node that is not running.
+func RootHashAt(dbDir string, height int64) ([]byte, xerrors.XError) {
+	if _, err := os.Stat(filepath.Join(dbDir, "heightRootHash.db")); err != nil {
+		return nil, xerrors.From(err)
+	}
+	metadb, err := tmdb.NewDB("heightRootHash", "goleveldb", dbDir)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	defer metadb.Close()
+
+	hash, err := metadb.Get(blockKey(height))
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	if hash == nil {
+		return nil, xerrors.ErrNotFoundResult
+	}
+	return hash, nil
+}
+
 type EVMCtrler struct {
 	vmevm          *ethvm.EVM
 	ethChainConfig *params.ChainConfig
+	evmForks       ctrlertypes.EVMForks
 	ethDB          ethdb.Database
 	stateDBWrapper *StateDBWrapper
 	acctHandler    ctrlertypes.IAccountHandler
@@ -118,6 +160,7 @@ func NewEVMCtrler(config *cfg.Config, acctHandler ctrlertypes.IAccountHandler, l
 	defaultEVMChainConfig.ChainID = config.ChainId().ToBig()
 	return &EVMCtrler{
 		ethChainConfig:  defaultEVMChainConfig,
+		evmForks:        ctrlertypes.EVMForksOf(ctrlertypes.DefaultGovParams()),
 		ethDB:           db,
 		metadb:          metadb,
 		acctHandler:     acctHandler,
@@ -149,11 +192,15 @@ func (ctrler *EVMCtrler) BeginBlock(bctx *ctrlertypes.BlockContext) ([]abcitypes
 		return nil, xerrors.From(err)
 	}
 
+	// The EVM forks may be rescheduled by the governance.
+	ctrler.evmForks = ctrlertypes.EVMForksOf(bctx.GovHandler)
+	chainConfig := evmChainConfigAt(ctrler.ethChainConfig, ctrler.evmForks, bctx.Height())
+
 	beneficiary := bytes.HexBytes(bctx.BlockInfo().Header.ProposerAddress).Array20()
 	blockContext := evmBlockContext(beneficiary, bctx.GetBlockGasLimit(), bctx.Height(), bctx.TimeSeconds())
 	ctrler.vmevm = ethvm.NewEVM(blockContext, ethvm.TxContext{
 		GasPrice: bctx.GovHandler.GasPrice().ToBig(),
-	}, stdb, ctrler.ethChainConfig, ethvm.Config{NoBaseFee: true})
+	}, stdb, chainConfig, ethvm.Config{NoBaseFee: true})
 	ctrler.stateDBWrapper = stdb
 	ctrler.blockGasPool = bctx.GetBlockGasPool()
 
@@ -168,6 +215,15 @@ func (ctrler *EVMCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XError
 		return xerrors.ErrInvalidAccountType
 	}
 
+	// check the allow-lists managed by the governance
+	if bytes.Equal(ctx.Receiver.Address, types.ZeroAddress()) {
+		if !ctx.GovHandler.IsAllowedDeployer(ctx.Tx.From, ctx.Exec) {
+			return xerrors.ErrNoRight.Wrapf("%v is not allowed to deploy a contract", ctx.Tx.From)
+		}
+	} else if !ctx.GovHandler.IsAllowedCaller(ctx.Receiver.Address, ctx.Tx.From, ctx.Exec) {
+		return xerrors.ErrNoRight.Wrapf("%v is not allowed to call the contract %v", ctx.Tx.From, ctx.Receiver.Address)
+	}
+
 	inputData := []byte(nil)
 	payload, ok := ctx.Tx.Payload.(*ctrlertypes.TrxPayloadContract)
 	if ok {
@@ -328,6 +384,10 @@ func (ctrler *EVMCtrler) execVM(from, to types.Address, nonce, gas int64, gasPri
 	txContext := ethcore.NewEVMTxContext(vmmsg)
 	ctrler.vmevm.Reset(txContext, ctrler.stateDBWrapper)
 
+	height := ctrler.vmevm.Context.BlockNumber.Int64()
+	release := nativeTokenView.bind(ctrler.stateDBWrapper.acctHandler, exec, ctrler.evmForks.IsTokenView(height))
+	defer release()
+
 	result, err := NewVMStateTransition(ctrler.vmevm, vmmsg, ctrler.blockGasPool).TransitionDb()
 	if err != nil {
 		return nil, xerrors.From(err)
@@ -0,0 +1,104 @@
+package evm
+
+import (
+	"bytes"
+	"testing"
+
+	govmock "github.com/beatoz/beatoz-go/ctrlers/mocks/gov"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
+	bytes2 "github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/beatoz/beatoz-sdk-go/web3"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EVMChainConfigAt(t *testing.T) {
+	rulesAt := func(forks ctrlertypes.EVMForks, height int64) (bool, bool, bool) {
+		config := evmChainConfigAt(defaultEVMChainConfig, forks, height)
+		blockContext := evmBlockContext([20]byte{}, 1_000_000, height, 1_700_000_000)
+		rules := config.Rules(blockContext.BlockNumber, blockContext.Random != nil, blockContext.Time)
+		return rules.IsShanghai, rules.IsCancun, rules.IsPrague
+	}
+
+	// default: Shanghai and Cancun from the genesis, Prague is not supported.
+	forks := ctrlertypes.EVMForksOf(ctrlertypes.DefaultGovParams())
+	shanghai, cancun, prague := rulesAt(forks, 1)
+	require.True(t, shanghai)
+	require.True(t, cancun)
+	require.False(t, prague)
+
+	// Shanghai at 10 and Cancun at 20
+	forks = ctrlertypes.EVMForks{Shanghai: 10, Cancun: 20}
+	for _, c := range []struct {
+		height           int64
+		shanghai, cancun bool
+	}{
+		{1, false, false},
+		{9, false, false},
+		{10, true, false},
+		{19, true, false},
+		{20, true, true},
+	} {
+		shanghai, cancun, prague = rulesAt(forks, c.height)
+		require.Equal(t, c.shanghai, shanghai, c.height)
+		require.Equal(t, c.cancun, cancun, c.height)
+		require.False(t, prague, c.height)
+	}
+
+	// `base` is not changed.
+	require.NotNil(t, defaultEVMChainConfig.CancunTime)
+	require.Nil(t, defaultEVMChainConfig.PragueTime)
+}
+
+// allowListGovMock allows only `deployer` to deploy and only `caller` to call `contract`.
+type allowListGovMock struct {
+	*govmock.GovHandlerMock
+	deployer, contract, caller types.Address
+}
+
+func (mock *allowListGovMock) IsAllowedDeployer(addr types.Address, exec bool) bool {
+	return bytes.Equal(addr, mock.deployer)
+}
+
+func (mock *allowListGovMock) IsAllowedCaller(contract, caller types.Address, exec bool) bool {
+	return !bytes.Equal(contract, mock.contract) || bytes.Equal(caller, mock.caller)
+}
+
+func Test_ValidateTrx_AllowList(t *testing.T) {
+	govHandler := &allowListGovMock{
+		GovHandlerMock: govMock,
+		deployer:       types.RandAddress(),
+		contract:       types.RandAddress(),
+		caller:         types.RandAddress(),
+	}
+	ctrler := &EVMCtrler{}
+
+	newTrxCtx := func(from, to types.Address, data []byte) *ctrlertypes.TrxContext {
+		receiver := ctrlertypes.NewAccount(to)
+		if !types.IsZeroAddress(to) {
+			receiver.Code = []byte("not nil")
+		}
+		return &ctrlertypes.TrxContext{
+			BlockContext: &ctrlertypes.BlockContext{GovHandler: govHandler},
+			Tx: web3.NewTrxContract(from, to,
+				1, 300000, govMock.GasPrice(), uint256.NewInt(0), data),
+			Sender:   ctrlertypes.NewAccount(from),
+			Receiver: receiver,
+		}
+	}
+
+	// deploy
+	xerr := ctrler.ValidateTrx(newTrxCtx(types.RandAddress(), types.ZeroAddress(), bytes2.RandBytes(32)))
+	require.ErrorContains(t, xerr, xerrors.ErrNoRight.Error())
+	// the allowed deployer passes the allow-list and fails at the next check of the empty code.
+	xerr = ctrler.ValidateTrx(newTrxCtx(govHandler.deployer, types.ZeroAddress(), nil))
+	require.ErrorContains(t, xerr, xerrors.ErrInvalidTrxPayloadParams.Error())
+
+	// call
+	xerr = ctrler.ValidateTrx(newTrxCtx(govHandler.deployer, govHandler.contract, bytes2.RandBytes(4)))
+	require.ErrorContains(t, xerr, xerrors.ErrNoRight.Error())
+	xerr = ctrler.ValidateTrx(newTrxCtx(govHandler.caller, govHandler.contract, bytes2.RandBytes(4)))
+	require.False(t, xerr != nil && xerr.Contains(xerrors.ErrNoRight))
+}
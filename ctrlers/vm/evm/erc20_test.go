@@ -91,7 +91,7 @@ func Test_ValidateTrx(t *testing.T) {
 	// normal tx	: address != zero, code != nil
 	// fallback tx	: address != zero, code != nil
 
-	bctx := &ctrlertypes.BlockContext{}
+	bctx := &ctrlertypes.BlockContext{GovHandler: govMock}
 	fromAcct := ctrlertypes.NewAccount(types.RandAddress())
 
 	txctx := &ctrlertypes.TrxContext{
@@ -493,6 +493,14 @@ func (handler *acctHandlerMock) SetBalance(addr types.Address, amt *uint256.Int,
 	return nil
 }
 
+func (handler *acctHandlerMock) FindToken(addr types.Address, exec bool) *ctrlertypes.Token {
+	return nil
+}
+
+func (handler *acctHandlerMock) TokenBalanceOf(token, holder types.Address, exec bool) *uint256.Int {
+	return uint256.NewInt(0)
+}
+
 func (handler *acctHandlerMock) ImmutableAcctCtrlerAt(i int64) (ctrlertypes.IAccountHandler, xerrors.XError) {
 	return nil, nil
 }
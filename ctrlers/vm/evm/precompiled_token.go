@@ -0,0 +1,144 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+)
+
+func init() {
+	// read-only ERC-20 view of the native tokens.
+	// it is registered for all rules and activated by `bind` at `evmTokenViewHeight`.
+	vm.PrecompiledContractsHomestead[NativeTokenViewAddress] = nativeTokenView
+	vm.PrecompiledContractsByzantium[NativeTokenViewAddress] = nativeTokenView
+	vm.PrecompiledContractsIstanbul[NativeTokenViewAddress] = nativeTokenView
+	vm.PrecompiledContractsBerlin[NativeTokenViewAddress] = nativeTokenView
+	vm.PrecompiledContractsCancun[NativeTokenViewAddress] = nativeTokenView
+}
+
+const (
+	NativeTokenViewGas = 2600
+)
+
+var (
+	NativeTokenViewAddress = common.BytesToAddress([]byte{0xff, 0x01})
+
+	nativeTokenView = &beatoz_tokenView{}
+
+	erc20ViewABI = func() abi.ABI {
+		ret, err := abi.JSON(strings.NewReader(`[
+{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`))
+		if err != nil {
+			panic(err)
+		}
+		return ret
+	}()
+)
+
+// tokenReader is the part of IAccountHandler which the native token view reads.
+type tokenReader interface {
+	FindToken(types.Address, bool) *ctrlertypes.Token
+	TokenBalanceOf(types.Address, types.Address, bool) *uint256.Int
+}
+
+// beatoz_tokenView exposes the native tokens to contracts as read-only ERC-20 tokens.
+//
+// It is not an ERC-20 contract at each token address, so it can not be called through the IERC20 interface.
+// The calldata is the 32 bytes left-padded token address followed by the ERC-20 calldata, e.g. in Solidity:
+//
+//	address(0xff01).staticcall(abi.encodePacked(uint256(uint160(token)), abi.encodeWithSignature("balanceOf(address)", holder)))
+//
+// and the returned data is the same as of the ERC-20 method.
+// Only `name()`, `symbol()`, `decimals()`, `totalSupply()` and `balanceOf(address)` are supported.
+// The token is moved only by the token txs, so any other method (e.g. `transfer`) fails.
+//
+// A precompiled contract of geth can not access the state and is shared by all EVMs through the global tables,
+// so each EVM execution binds its account ledger by `bind` and holds the binding until the execution ends.
+// The executions binding the view are serialized; `bind` must be called after any other lock is taken.
+//
+// The view is activated at `evmTokenViewHeight` of the governance parameters.
+// Before that, it is bound as inactive and behaves like an empty account:
+// the call costs no gas and succeeds with no returned data.
+type beatoz_tokenView struct {
+	mtx    sync.Mutex // held by the execution which binds the view
+	reader tokenReader
+	exec   bool
+	active bool
+}
+
+// bind sets the account ledger which the view reads until the returned function is called.
+// It waits for the other binding to be released.
+func (c *beatoz_tokenView) bind(reader tokenReader, exec, active bool) func() {
+	c.mtx.Lock()
+	c.reader, c.exec, c.active = reader, exec, active
+	return func() {
+		c.reader, c.exec, c.active = nil, false, false
+		c.mtx.Unlock()
+	}
+}
+
+func (c *beatoz_tokenView) RequiredGas(input []byte) uint64 {
+	if !c.active {
+		return 0
+	}
+	return NativeTokenViewGas
+}
+
+func (c *beatoz_tokenView) Run(input []byte) ([]byte, error) {
+	if !c.active {
+		return nil, nil
+	}
+	if len(input) < 32+4 {
+		return nil, errors.New("token: invalid input length")
+	}
+
+	tokenAddr := common.BytesToAddress(input[:32])
+	token := c.reader.FindToken(tokenAddr[:], c.exec)
+	if token == nil {
+		return nil, fmt.Errorf("token: not found token %x", tokenAddr)
+	}
+
+	method, err := erc20ViewABI.MethodById(input[32:36])
+	if err != nil {
+		return nil, errors.New("token: unsupported method (the native token is read-only)")
+	}
+
+	switch method.Name {
+	case "name":
+		name := token.Name
+		if name == "" {
+			name = token.Symbol
+		}
+		return method.Outputs.Pack(name)
+	case "symbol":
+		return method.Outputs.Pack(token.Symbol)
+	case "decimals":
+		return method.Outputs.Pack(uint8(token.Decimals))
+	case "totalSupply":
+		return method.Outputs.Pack(token.TotalSupply.ToBig())
+	case "balanceOf":
+		args, err := method.Inputs.Unpack(input[36:])
+		if err != nil {
+			return nil, fmt.Errorf("token: invalid arguments: %v", err)
+		}
+		holder := args[0].(common.Address)
+		bal := c.reader.TokenBalanceOf(tokenAddr[:], holder[:], c.exec)
+		return method.Outputs.Pack(bal.ToBig())
+	}
+	return nil, errors.New("token: unsupported method")
+}
+
+var _ vm.PrecompiledContract = (*beatoz_tokenView)(nil)
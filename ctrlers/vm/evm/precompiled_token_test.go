@@ -0,0 +1,160 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	cfg "github.com/beatoz/beatoz-go/cmd/config"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+)
+
+type tokenReaderMock struct {
+	token    *ctrlertypes.Token
+	balances map[string]*uint256.Int
+}
+
+func (m *tokenReaderMock) FindToken(addr types.Address, exec bool) *ctrlertypes.Token {
+	if m.token != nil && bytes.Equal(m.token.Address, addr) {
+		return m.token
+	}
+	return nil
+}
+
+func (m *tokenReaderMock) TokenBalanceOf(token, addr types.Address, exec bool) *uint256.Int {
+	if bal, ok := m.balances[addr.String()]; ok {
+		return bal.Clone()
+	}
+	return uint256.NewInt(0)
+}
+
+func TestNativeTokenView(t *testing.T) {
+	issuer := types.RandAddress()
+	holder := types.RandAddress()
+	tokenAddr := ctrlertypes.TokenAddress(issuer, 1)
+	token := ctrlertypes.NewToken(tokenAddr, issuer, "", "TKN", 6, uint256.NewInt(0), issuer)
+	require.NoError(t, token.Mint(uint256.NewInt(1000)))
+
+	reader := &tokenReaderMock{
+		token:    token,
+		balances: map[string]*uint256.Int{holder.String(): uint256.NewInt(700)},
+	}
+
+	input := func(addr types.Address, method string, args ...interface{}) []byte {
+		data, err := erc20ViewABI.Pack(method, args...)
+		require.NoError(t, err)
+		return append(common.LeftPadBytes(addr, 32), data...)
+	}
+
+	// not bound, it behaves like an empty account.
+	require.Zero(t, nativeTokenView.RequiredGas(input(tokenAddr, "symbol")))
+	ret, err := nativeTokenView.Run(input(tokenAddr, "symbol"))
+	require.NoError(t, err)
+	require.Empty(t, ret)
+
+	// bound as inactive before `evmTokenViewHeight`.
+	release := nativeTokenView.bind(reader, false, false)
+	require.Zero(t, nativeTokenView.RequiredGas(input(tokenAddr, "symbol")))
+	ret, err = nativeTokenView.Run(input(tokenAddr, "symbol"))
+	require.NoError(t, err)
+	require.Empty(t, ret)
+	release()
+
+	release = nativeTokenView.bind(reader, false, true)
+	require.Equal(t, uint64(NativeTokenViewGas), nativeTokenView.RequiredGas(input(tokenAddr, "symbol")))
+
+	ret, err = nativeTokenView.Run(input(tokenAddr, "name"))
+	require.NoError(t, err)
+	outs, err := erc20ViewABI.Unpack("name", ret)
+	require.NoError(t, err)
+	require.Equal(t, "TKN", outs[0]) // falls back to the symbol
+
+	ret, err = nativeTokenView.Run(input(tokenAddr, "decimals"))
+	require.NoError(t, err)
+	outs, err = erc20ViewABI.Unpack("decimals", ret)
+	require.NoError(t, err)
+	require.Equal(t, uint8(6), outs[0])
+
+	ret, err = nativeTokenView.Run(input(tokenAddr, "totalSupply"))
+	require.NoError(t, err)
+	outs, err = erc20ViewABI.Unpack("totalSupply", ret)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), outs[0].(*big.Int).Int64())
+
+	ret, err = nativeTokenView.Run(input(tokenAddr, "balanceOf", holder.Array20()))
+	require.NoError(t, err)
+	outs, err = erc20ViewABI.Unpack("balanceOf", ret)
+	require.NoError(t, err)
+	require.Equal(t, int64(700), outs[0].(*big.Int).Int64())
+
+	ret, err = nativeTokenView.Run(input(tokenAddr, "balanceOf", issuer.Array20()))
+	require.NoError(t, err)
+	outs, err = erc20ViewABI.Unpack("balanceOf", ret)
+	require.NoError(t, err)
+	require.Zero(t, outs[0].(*big.Int).Sign())
+
+	// unknown token
+	_, err = nativeTokenView.Run(input(types.RandAddress(), "symbol"))
+	require.Error(t, err)
+
+	// `transfer(address,uint256)` is not supported
+	_, err = nativeTokenView.Run(append(common.LeftPadBytes(tokenAddr, 32), 0xa9, 0x05, 0x9c, 0xbb))
+	require.Error(t, err)
+
+	// the other execution waits until this binding is released.
+	otherAddr := ctrlertypes.TokenAddress(issuer, 2)
+	other := &tokenReaderMock{token: ctrlertypes.NewToken(otherAddr, issuer, "Other", "OTH", 18, uint256.NewInt(0), issuer)}
+
+	bound := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		release := nativeTokenView.bind(other, false, true)
+		defer release()
+		close(bound)
+		_, err := nativeTokenView.Run(input(otherAddr, "symbol"))
+		done <- err
+	}()
+
+	select {
+	case <-bound:
+		require.Fail(t, "the view is bound by the other execution")
+	case <-time.After(100 * time.Millisecond):
+	}
+	// still bound to `reader`
+	_, err = nativeTokenView.Run(input(tokenAddr, "symbol"))
+	require.NoError(t, err)
+
+	release()
+	require.NoError(t, <-done)
+}
+
+func TestNativeTokenViewHeight(t *testing.T) {
+	config := cfg.DefaultConfig()
+	config.SetChainId("0xDEA8D3")
+	config.RootDir = t.TempDir()
+	ctrler := NewEVMCtrler(config, &acctHandler, tmlog.NewNopLogger())
+	defer ctrler.Close()
+
+	// `acctHandler` has no token, so the active view fails to find the token.
+	input := append(common.LeftPadBytes(types.RandAddress(), 32), erc20ViewABI.Methods["symbol"].ID...)
+	from := types.RandAddress()
+
+	forks := ctrlertypes.EVMForks{TokenView: 10}
+	ret, xerr := ctrler.callVMWith(forks, from, NativeTokenViewAddress[:], input, 10, time.Now().Unix())
+	require.NoError(t, xerr)
+	require.ErrorContains(t, ret.Err, "not found token")
+
+	// before `evmTokenViewHeight` or not scheduled, it is an empty account.
+	for _, forks := range []ctrlertypes.EVMForks{{TokenView: 11}, {TokenView: 0}} {
+		ret, xerr = ctrler.callVMWith(forks, from, NativeTokenViewAddress[:], input, 10, time.Now().Unix())
+		require.NoError(t, xerr)
+		require.NoError(t, ret.Err)
+		require.Empty(t, ret.Return())
+	}
+}
@@ -26,7 +26,16 @@ func (ctrler *EVMCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertypes.O
 		height = ctrler.lastBlockHeight
 	}
 
-	execRet, xerr := ctrler.callVM(from, to, data, height, time.Now().Unix())
+	// The EVM forks activated at or below the last block can not be changed,
+	// so the forks of the current governance parameters are valid also for the past `height`.
+	forks := ctrler.lastEVMForks()
+	if len(opts) > 0 {
+		if govParams, ok := opts[0]().(ctrlertypes.IGovParams); ok {
+			forks = ctrlertypes.EVMForksOf(govParams)
+		}
+	}
+
+	execRet, xerr := ctrler.callVMWith(forks, from, to, data, height, time.Now().Unix())
 	if xerr != nil {
 		return nil, xerr
 	}
@@ -57,7 +66,18 @@ func (ctrler *EVMCtrler) GetCode(addr types.Address, height int64) ([]byte, xerr
 
 	return state.GetCode(addr.Array20()), nil
 }
+func (ctrler *EVMCtrler) lastEVMForks() ctrlertypes.EVMForks {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	return ctrler.evmForks
+}
+
 func (ctrler *EVMCtrler) callVM(from, to types.Address, data []byte, height, blockTime int64) (*core.ExecutionResult, xerrors.XError) {
+	return ctrler.callVMWith(ctrler.lastEVMForks(), from, to, data, height, blockTime)
+}
+
+func (ctrler *EVMCtrler) callVMWith(forks ctrlertypes.EVMForks, from, to types.Address, data []byte, height, blockTime int64) (*core.ExecutionResult, xerrors.XError) {
 
 	// Get the stateDB at block<height> and the `stateDBWrapper` that has account ledger(acctCtrler)
 	state, xerr := ctrler.MemStateAt(height)
@@ -93,9 +113,13 @@ func (ctrler *EVMCtrler) callVM(from, to types.Address, data []byte, height, blo
 	blockContext := evmBlockContext(sender, math.MaxInt64, height, blockTime)
 
 	txContext := core.NewEVMTxContext(vmmsg)
-	vmevm := vm.NewEVM(blockContext, txContext, state, ctrler.ethChainConfig, vm.Config{NoBaseFee: true})
+	chainConfig := evmChainConfigAt(ctrler.ethChainConfig, forks, height)
+	vmevm := vm.NewEVM(blockContext, txContext, state, chainConfig, vm.Config{NoBaseFee: true})
 
 	gp := new(core.GasPool).AddGas(blockContext.GasLimit)
+	release := nativeTokenView.bind(state.acctHandler, false, forks.IsTokenView(height))
+	defer release()
+
 	result, err := NewVMStateTransition(vmevm, vmmsg, gp).TransitionDb()
 	if err != nil {
 		return nil, xerrors.From(err)
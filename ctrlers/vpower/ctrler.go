@@ -162,6 +162,10 @@ func (ctrler *VPowerCtrler) ValidateTrx(ctx *ctrlertypes.TrxContext) xerrors.XEr
 			if selfPower < ctx.GovHandler.MinValidatorPower() {
 				return xerrors.ErrInvalidTrx.Wrapf("too small power to become validator: %v < %v(minimum)", txPower, ctx.GovHandler.MinValidatorPower())
 			}
+			if dgtee == nil && ctx.SenderPubKey == nil {
+				// the validator key should be the secp256k1 key recovered from the signature.
+				return xerrors.ErrInvalidTrx.Wrapf("the tx becoming validator should be signed with the secp256k1 key")
+			}
 		} else {
 			if dgtee == nil {
 				return xerrors.ErrNotFoundDelegatee.Wrapf("address(%v)", ctx.Tx.To)
@@ -454,6 +458,18 @@ func (ctrler *VPowerCtrler) SelfPowerOf(addr types.Address) int64 {
 	return 0
 }
 
+// VPowerOf returns the power delegated from `from` to `to`.
+func (ctrler *VPowerCtrler) VPowerOf(from, to types.Address) int64 {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	vpow, xerr := ctrler.readVPower(from, to, true)
+	if xerr != nil {
+		return 0
+	}
+	return vpow.SumPower
+}
+
 // DEPRECATED
 func (ctrler *VPowerCtrler) TotalPowerOf(addr types.Address) int64 {
 	return ctrler.SumPowerOf(addr)
@@ -1,6 +1,8 @@
 package vpower
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"sort"
 
@@ -27,6 +29,12 @@ func (ctrler *VPowerCtrler) Query(req abcitypes.RequestQuery, opts ...ctrlertype
 		return ctrler.queryTotalPower(req.Height)
 	case "stakes/voting_power":
 		return ctrler.queryVotingPower(req.Height, opts[0], opts[1])
+	case "delegatees":
+		return ctrler.queryDelegatees(req.Height, req.Data, opts[0], opts[1])
+	case "delegators":
+		return ctrler.queryDelegators(req.Height, req.Data)
+	case "frozen_powers":
+		return ctrler.queryFrozenPowers(req.Height, req.Data)
 	default:
 		return nil, xerrors.ErrQuery.Wrapf("unknown query path")
 	}
@@ -204,3 +212,195 @@ func (ctrler *VPowerCtrler) queryVotingPower(height int64, getMaxValCnt, getMinV
 	}
 	return []byte(fmt.Sprintf("\"%v\"", retPower)), nil
 }
+
+func parseListQuery(data []byte) (*ctrlertypes.ListQuery, xerrors.XError) {
+	qry := &ctrlertypes.ListQuery{}
+	if len(data) > 0 {
+		if err := jsonx.Unmarshal(data, qry); err != nil {
+			return nil, xerrors.ErrInvalidQueryParams.Wrap(err)
+		}
+	}
+	qry.Page, qry.PerPage = ctrlertypes.NormalizePage(qry.Page, qry.PerPage)
+	return qry, nil
+}
+
+// queryDelegatees returns the delegatees in the page, in descending order of power.
+// The status of a delegatee is `validator` if it is in the validator set at `height`, otherwise `inactive`.
+func (ctrler *VPowerCtrler) queryDelegatees(height int64, data []byte, getMaxValCnt, getMinValPower ctrlertypes.Option) ([]byte, xerrors.XError) {
+	type respDelegatee struct {
+		Addr           types.Address     `json:"address"`
+		PubKey         btztypes.HexBytes `json:"pubKey"`
+		SelfPower      int64             `json:"selfPower,string"`
+		TotalPower     int64             `json:"totalPower,string"`
+		DelegatorCount int               `json:"delegatorCount,string"`
+		Status         string            `json:"status"`
+	}
+
+	qry, xerr := parseListQuery(data)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	atledger, xerr := ctrler.vpowerState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	maxValCnt := getMaxValCnt().(int32)
+	minValPower := getMinValPower().(int64)
+
+	var delegatees OrderByPowerDelegatees
+	xerr = atledger.Seek(v1.KeyPrefixDelegatee, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		d, _ := item.(*Delegatee)
+		delegatees = append(delegatees, d)
+		return nil
+	})
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	sort.Sort(delegatees)
+
+	// the validators are selected as `queryVotingPower` does.
+	validators := make(map[string]bool)
+	for _, d := range delegatees {
+		if len(validators) >= int(maxValCnt) {
+			break
+		}
+		if d.SelfPower >= minValPower {
+			validators[d.addr.String()] = true
+		}
+	}
+
+	start, end := ctrlertypes.PageRange(len(delegatees), qry.Page, qry.PerPage)
+	items := make([]*respDelegatee, 0, end-start)
+	for _, d := range delegatees[start:end] {
+		status := "inactive"
+		if validators[d.addr.String()] {
+			status = "validator"
+		}
+		items = append(items, &respDelegatee{
+			Addr:           d.addr,
+			PubKey:         d.PubKey,
+			SelfPower:      d.SelfPower,
+			TotalPower:     d.SumPower,
+			DelegatorCount: len(d.Delegators),
+			Status:         status,
+		})
+	}
+
+	bz, err := jsonx.Marshal(&ctrlertypes.PageResult{
+		Total:   len(delegatees),
+		Page:    qry.Page,
+		PerPage: qry.PerPage,
+		Items:   items,
+	})
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return bz, nil
+}
+
+// queryDelegators returns the delegators of the delegatee `qry.Address` in the page.
+func (ctrler *VPowerCtrler) queryDelegators(height int64, data []byte) ([]byte, xerrors.XError) {
+	type respDelegator struct {
+		Addr       types.Address `json:"address"`
+		Power      int64         `json:"power,string"`
+		ChunkCount int           `json:"chunkCount,string"`
+	}
+
+	qry, xerr := parseListQuery(data)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	atledger, xerr := ctrler.vpowerState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	item, xerr := atledger.Get(v1.LedgerKeyDelegatee(qry.Address))
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+	dgtee, _ := item.(*Delegatee)
+
+	start, end := ctrlertypes.PageRange(len(dgtee.Delegators), qry.Page, qry.PerPage)
+	items := make([]*respDelegator, 0, end-start)
+	for _, _addr := range dgtee.Delegators[start:end] {
+		item, xerr = atledger.Get(v1.LedgerKeyVPower(_addr, dgtee.addr))
+		if xerr != nil {
+			return nil, xerrors.ErrQuery.Wrap(xerr)
+		}
+		vpow, _ := item.(*VPower)
+		items = append(items, &respDelegator{
+			Addr:       _addr,
+			Power:      vpow.SumPower,
+			ChunkCount: len(vpow.PowerChunks),
+		})
+	}
+
+	bz, err := jsonx.Marshal(&ctrlertypes.PageResult{
+		Total:   len(dgtee.Delegators),
+		Page:    qry.Page,
+		PerPage: qry.PerPage,
+		Items:   items,
+	})
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return bz, nil
+}
+
+// queryFrozenPowers returns the frozen (unbonding) powers in the page, in ascending order of refund height.
+// If `qry.Address` is not empty, only the powers to be refunded to it are returned.
+func (ctrler *VPowerCtrler) queryFrozenPowers(height int64, data []byte) ([]byte, xerrors.XError) {
+	type respFrozenPower struct {
+		From         types.Address `json:"from"`
+		RefundHeight int64         `json:"refundHeight,string"`
+		RefundPower  int64         `json:"refundPower,string"`
+		ChunkCount   int           `json:"chunkCount,string"`
+	}
+
+	qry, xerr := parseListQuery(data)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	atledger, xerr := ctrler.vpowerState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	var items []*respFrozenPower
+	xerr = atledger.Seek(v1.KeyPrefixFrozenVPower, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		// key is `prefix + refund_height(8 bytes) + from_address`
+		_key := v1.UnwrapKeyPrefix(key)
+		from := types.Address(_key[8:])
+		if len(qry.Address) > 0 && !bytes.Equal(qry.Address, from) {
+			return nil
+		}
+		frozen, _ := item.(*FrozenVPower)
+		items = append(items, &respFrozenPower{
+			From:         from,
+			RefundHeight: int64(binary.BigEndian.Uint64(_key[:8])),
+			RefundPower:  frozen.RefundPower,
+			ChunkCount:   len(frozen.PowerChunks),
+		})
+		return nil
+	})
+	if xerr != nil {
+		return nil, xerrors.ErrQuery.Wrap(xerr)
+	}
+
+	start, end := ctrlertypes.PageRange(len(items), qry.Page, qry.PerPage)
+	bz, err := jsonx.Marshal(&ctrlertypes.PageResult{
+		Total:   len(items),
+		Page:    qry.Page,
+		PerPage: qry.PerPage,
+		Items:   items[start:end],
+	})
+	if err != nil {
+		return nil, xerrors.ErrQuery.Wrap(err)
+	}
+	return bz, nil
+}
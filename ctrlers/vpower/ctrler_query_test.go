@@ -0,0 +1,118 @@
+package vpower
+
+import (
+	"os"
+	"testing"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func Test_QueryList(t *testing.T) {
+	require.NoError(t, os.RemoveAll(config.RootDir))
+
+	ctrler, lastValUps, valWallets, xerr := initLedger(config)
+	require.NoError(t, xerr)
+
+	_, lastHeight, xerr := ctrler.Commit()
+	require.NoError(t, xerr)
+
+	// 3 delegators delegate to `valWal`
+	valWal := valWallets[0]
+	var txhashes [][]byte
+	for i := 0; i < 3; i++ {
+		txctx, xerr := doDelegate(ctrler, acctMock.GetWallet(i), valWal.Address(), int64(4000+i), lastHeight+1)
+		require.NoError(t, xerr)
+		txhashes = append(txhashes, txctx.TxHash)
+	}
+	_, lastHeight, xerr = ctrler.Commit()
+	require.NoError(t, xerr)
+
+	query := func(path string, qry *ctrlertypes.ListQuery, items interface{}) *ctrlertypes.PageResult {
+		bz, err := jsonx.Marshal(qry)
+		require.NoError(t, err)
+		bz, xerr := ctrler.Query(abcitypes.RequestQuery{Path: path, Data: bz, Height: lastHeight},
+			func() interface{} { return govMock.MaxValidatorCnt() },
+			func() interface{} { return govMock.MinValidatorPower() },
+		)
+		require.NoError(t, xerr)
+		ret := &ctrlertypes.PageResult{Items: items}
+		require.NoError(t, jsonx.Unmarshal(bz, ret))
+		return ret
+	}
+
+	// delegatees
+	type delegatee struct {
+		Addr           types.Address `json:"address"`
+		TotalPower     int64         `json:"totalPower,string"`
+		DelegatorCount int           `json:"delegatorCount,string"`
+		Status         string        `json:"status"`
+	}
+	var dgtees []*delegatee
+	ret := query("delegatees", &ctrlertypes.ListQuery{PerPage: 10}, &dgtees)
+	require.Equal(t, len(lastValUps), ret.Total)
+	require.Equal(t, 10, ret.PerPage)
+	require.Len(t, dgtees, 10)
+	// `valWal` has the largest power.
+	require.EqualValues(t, valWal.Address(), dgtees[0].Addr)
+	// the delegators include the validator itself.
+	require.Equal(t, 4, dgtees[0].DelegatorCount)
+	for i, d := range dgtees {
+		if i > 0 {
+			require.LessOrEqual(t, d.TotalPower, dgtees[i-1].TotalPower)
+		}
+		require.Equal(t, "validator", d.Status)
+	}
+
+	dgtees = nil
+	ret = query("delegatees", &ctrlertypes.ListQuery{Page: 3, PerPage: 10}, &dgtees)
+	require.Equal(t, 3, ret.Page)
+	require.Len(t, dgtees, len(lastValUps)-20)
+
+	// delegators
+	type delegator struct {
+		Addr  types.Address `json:"address"`
+		Power int64         `json:"power,string"`
+	}
+	var dgtors []*delegator
+	ret = query("delegators", &ctrlertypes.ListQuery{Address: valWal.Address(), PerPage: 2}, &dgtors)
+	require.Equal(t, 4, ret.Total)
+	require.Len(t, dgtors, 2)
+	for _, d := range dgtors {
+		require.GreaterOrEqual(t, d.Power, int64(4000))
+	}
+
+	// frozen powers: each delegator unbonds at a different height.
+	for i, txhash := range txhashes {
+		_, xerr = doUndelegate(ctrler, acctMock.GetWallet(i), valWal.Address(), lastHeight+1, txhash)
+		require.NoError(t, xerr)
+		_, lastHeight, xerr = ctrler.Commit()
+		require.NoError(t, xerr)
+	}
+
+	type frozenPower struct {
+		From         types.Address `json:"from"`
+		RefundHeight int64         `json:"refundHeight,string"`
+		RefundPower  int64         `json:"refundPower,string"`
+	}
+	var frozens []*frozenPower
+	ret = query("frozen_powers", &ctrlertypes.ListQuery{}, &frozens)
+	require.Equal(t, 3, ret.Total)
+	require.Len(t, frozens, 3)
+	for i, f := range frozens {
+		require.EqualValues(t, acctMock.GetWallet(i).Address(), f.From)
+		require.Equal(t, int64(4000+i), f.RefundPower)
+		require.Equal(t, int64(i+3)+govMock.LazyUnbondingBlocks(), f.RefundHeight)
+	}
+
+	frozens = nil
+	ret = query("frozen_powers", &ctrlertypes.ListQuery{Address: acctMock.GetWallet(1).Address()}, &frozens)
+	require.Equal(t, 1, ret.Total)
+	require.EqualValues(t, acctMock.GetWallet(1).Address(), frozens[0].From)
+
+	require.NoError(t, ctrler.Close())
+	require.NoError(t, os.RemoveAll(config.DBDir()))
+}
@@ -100,7 +100,7 @@ func (ctrler *VPowerCtrler) ComputeWeight(
 					weightInfo.Add(addr, benefWeight, benefPowChunks.signW, benefPowChunks.val)
 				} else {
 					benefScaledPower := fxnumScaledPowerChunks(benefPowChunks.pcs, height, ripeningBlocks, tau)
-					benefWeight := allWeight.Mul(benefScaledPower).Div(allScaledPower)
+					benefWeight := fxnumBenefWeight(allWeight, benefScaledPower, allScaledPower)
 					weightInfo.Add(addr, benefWeight, benefPowChunks.signW, benefPowChunks.val)
 				}
 			}
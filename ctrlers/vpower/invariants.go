@@ -0,0 +1,153 @@
+package vpower
+
+import (
+	"fmt"
+	"sort"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	v1 "github.com/beatoz/beatoz-go/ledger/v1"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+)
+
+// SumPowerAt returns the sum of the bonded powers and the sum of the frozen powers committed at `height`.
+func (ctrler *VPowerCtrler) SumPowerAt(height int64) (int64, int64, xerrors.XError) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	atledger, xerr := ctrler.vpowerState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return 0, 0, xerr
+	}
+
+	bonded, frozen := int64(0), int64(0)
+	xerr = atledger.Seek(v1.KeyPrefixVPower, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		vpow, _ := item.(*VPower)
+		bonded += vpow.SumPower
+		return nil
+	})
+	if xerr != nil {
+		return 0, 0, xerr
+	}
+	xerr = atledger.Seek(v1.KeyPrefixFrozenVPower, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		frozenPow, _ := item.(*FrozenVPower)
+		frozen += frozenPow.RefundPower
+		return nil
+	})
+	if xerr != nil {
+		return 0, 0, xerr
+	}
+	return bonded, frozen, nil
+}
+
+func (ctrler *VPowerCtrler) RegisterInvariants(reg ctrlertypes.IInvariantRegistry) {
+	reg.RegisterRoute("vpower", "power-chunks", ctrler.powerChunksInvariant)
+	reg.RegisterRoute("vpower", "delegatee-power", ctrler.delegateePowerInvariant)
+}
+
+// powerChunksInvariant checks that the power of each VPower and FrozenVPower is the sum of its power chunks.
+func (ctrler *VPowerCtrler) powerChunksInvariant(height int64) (string, bool) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	atledger, xerr := ctrler.vpowerState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return fmt.Sprintf("vpower: power-chunks invariant: %v", xerr), true
+	}
+
+	var broken []string
+	xerr = atledger.Seek(v1.KeyPrefixVPower, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		vpow, _ := item.(*VPower)
+		if sum := vpow.sumPowerChunk(); sum != vpow.SumPower {
+			broken = append(broken, fmt.Sprintf("vpower(from:%v, to:%v) has power %v, but the sum of chunks is %v", vpow.from, vpow.to, vpow.SumPower, sum))
+		}
+		return nil
+	})
+	if xerr != nil {
+		return fmt.Sprintf("vpower: power-chunks invariant: %v", xerr), true
+	}
+	xerr = atledger.Seek(v1.KeyPrefixFrozenVPower, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		frozen, _ := item.(*FrozenVPower)
+		sum := int64(0)
+		for _, pc := range frozen.PowerChunks {
+			sum += pc.Power
+		}
+		if sum != frozen.RefundPower {
+			broken = append(broken, fmt.Sprintf("frozen vpower(%X) has power %v, but the sum of chunks is %v", key, frozen.RefundPower, sum))
+		}
+		return nil
+	})
+	if xerr != nil {
+		return fmt.Sprintf("vpower: power-chunks invariant: %v", xerr), true
+	}
+
+	return invariantMsg("power-chunks", fmt.Sprintf("%d broken power(s)", len(broken)), broken), len(broken) > 0
+}
+
+// delegateePowerInvariant checks that the power of each delegatee is the sum of the powers delegated to it.
+func (ctrler *VPowerCtrler) delegateePowerInvariant(height int64) (string, bool) {
+	ctrler.mtx.RLock()
+	defer ctrler.mtx.RUnlock()
+
+	atledger, xerr := ctrler.vpowerState.ImitableLedgerAt(height)
+	if xerr != nil {
+		return fmt.Sprintf("vpower: delegatee-power invariant: %v", xerr), true
+	}
+
+	type powers struct {
+		sum, self int64
+	}
+	delegated := make(map[string]*powers)
+	xerr = atledger.Seek(v1.KeyPrefixVPower, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		vpow, _ := item.(*VPower)
+		p, ok := delegated[vpow.to.String()]
+		if !ok {
+			p = &powers{}
+			delegated[vpow.to.String()] = p
+		}
+		p.sum += vpow.SumPower
+		if vpow.IsSelfPower() {
+			p.self += vpow.SumPower
+		}
+		return nil
+	})
+	if xerr != nil {
+		return fmt.Sprintf("vpower: delegatee-power invariant: %v", xerr), true
+	}
+
+	var broken []string
+	xerr = atledger.Seek(v1.KeyPrefixDelegatee, true, func(key v1.LedgerKey, item v1.ILedgerItem) xerrors.XError {
+		dgtee, _ := item.(*Delegatee)
+		p, ok := delegated[dgtee.addr.String()]
+		if !ok {
+			p = &powers{}
+		}
+		delete(delegated, dgtee.addr.String())
+
+		if p.sum != dgtee.SumPower || p.self != dgtee.SelfPower {
+			broken = append(broken, fmt.Sprintf("delegatee(%v) has power %v(self:%v), but the delegated power is %v(self:%v)",
+				dgtee.addr, dgtee.SumPower, dgtee.SelfPower, p.sum, p.self))
+		}
+		return nil
+	})
+	if xerr != nil {
+		return fmt.Sprintf("vpower: delegatee-power invariant: %v", xerr), true
+	}
+	var unknowns []string
+	for addr, p := range delegated {
+		unknowns = append(unknowns, fmt.Sprintf("the power %v is delegated to the unknown delegatee(%v)", p.sum, addr))
+	}
+	sort.Strings(unknowns) // for deterministic message
+	broken = append(broken, unknowns...)
+
+	return invariantMsg("delegatee-power", fmt.Sprintf("%d broken delegatee(s)", len(broken)), broken), len(broken) > 0
+}
+
+func invariantMsg(route, summary string, details []string) string {
+	msg := fmt.Sprintf("vpower: %s invariant: %s", route, summary)
+	for _, d := range details {
+		msg += "\n\t" + d
+	}
+	return msg
+}
+
+var _ ctrlertypes.IInvariantHandler = (*VPowerCtrler)(nil)
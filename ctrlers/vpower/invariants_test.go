@@ -0,0 +1,60 @@
+package vpower
+
+import (
+	"os"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/ctrlers/crisis"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Invariants(t *testing.T) {
+	require.NoError(t, os.RemoveAll(config.RootDir))
+
+	ctrler, lastValUps, valWallets, xerr := initLedger(config)
+	require.NoError(t, xerr)
+	require.Equal(t, len(lastValUps), len(valWallets))
+
+	_, lastHeight, xerr := ctrler.Commit()
+	require.NoError(t, xerr)
+
+	_, _, powers, _ := testRandDelegate(t, 1000, ctrler, valWallets, lastHeight+1)
+	_, lastHeight, xerr = ctrler.Commit()
+	require.NoError(t, xerr)
+
+	reg := crisis.NewInvariantRegistry()
+	ctrler.RegisterInvariants(reg)
+	require.Len(t, reg.AssertBroken(lastHeight), 0)
+
+	// the bonded power is the sum of the initial powers of validators and the delegated powers.
+	expectedBonded := int64(0)
+	for _, vup := range lastValUps {
+		expectedBonded += vup.Power
+	}
+	for _, p := range powers {
+		expectedBonded += p
+	}
+	bonded, frozen, xerr := ctrler.SumPowerAt(lastHeight)
+	require.NoError(t, xerr)
+	require.Equal(t, expectedBonded, bonded)
+	require.Equal(t, int64(0), frozen)
+
+	// break the power of a delegatee
+	dgtee, xerr := ctrler.readDelegatee(valWallets[0].Address(), true)
+	require.NoError(t, xerr)
+	dgtee.SumPower++
+	require.NoError(t, ctrler.writeDelegatee(dgtee, true))
+	_, lastHeight, xerr = ctrler.Commit()
+	require.NoError(t, xerr)
+
+	broken := reg.AssertBroken(lastHeight)
+	require.Len(t, broken, 1)
+	require.Equal(t, "vpower", broken[0].Module)
+	require.Equal(t, "delegatee-power", broken[0].Route)
+
+	// the state at the previous height is still valid.
+	require.Len(t, reg.AssertBroken(lastHeight-1), 0)
+
+	require.NoError(t, ctrler.Close())
+	require.NoError(t, os.RemoveAll(config.DBDir()))
+}
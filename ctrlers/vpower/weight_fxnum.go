@@ -15,6 +15,14 @@ func FxNumWeightOfPowerChunks(powerChunks []*PowerChunkProto, currHeight, ripeni
 	return fxnumWeightOfPowerChunks(powerChunks, currHeight, ripeningCycle, tau, baseSupply)
 }
 
+func FxNumScaledPowerChunks(powerChunks []*PowerChunkProto, currHeight, ripeningCycle int64, tau int32) fxnum.FxNum {
+	return fxnumScaledPowerChunks(powerChunks, currHeight, ripeningCycle, tau)
+}
+
+func FxNumBenefWeight(allWeight, benefScaledPower, allScaledPower fxnum.FxNum) fxnum.FxNum {
+	return fxnumBenefWeight(allWeight, benefScaledPower, allScaledPower)
+}
+
 // fxnumWeightOfPowerChunks calculates the voting power weight.
 // `result = (tau * min({bonding_duration}/ripeningCycle, 1) + keppa) * {sum_of_voting_power} / totalSupply`
 func fxnumWeightOfPowerChunks(powerChunks []*PowerChunkProto, currHeight, ripeningCycle int64, tau int32, baseSupply *uint256.Int) fxnum.FxNum {
@@ -64,3 +72,9 @@ func fxnumScaledPowerChunk(pc *PowerChunkProto, currHeight, ripeningCycle int64,
 	}
 	return fxnum.ZERO
 }
+
+// fxnumBenefWeight calculates the weight of a beneficiary as the share of `allWeight`.
+// `result = allWeight * {beneficiary's scaled power} / {all scaled power}`
+func fxnumBenefWeight(allWeight, benefScaledPower, allScaledPower fxnum.FxNum) fxnum.FxNum {
+	return allWeight.Mul(benefScaledPower).Div(allScaledPower)
+}
@@ -2,12 +2,18 @@ package genesis
 
 import (
 	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types"
 	"github.com/beatoz/beatoz-go/types/crypto"
 )
 
 type GenesisAppState struct {
 	AssetHolders []*GenesisAssetHolder  `json:"assetHolders"`
 	GovParams    *ctrlertypes.GovParams `json:"govParams"`
+
+	// If PermissionedDeploy is true, only AllowedDeployers can deploy contracts.
+	// The deployers can be changed by the governance proposals after the genesis.
+	PermissionedDeploy bool            `json:"permissionedDeploy,omitempty"`
+	AllowedDeployers   []types.Address `json:"allowedDeployers,omitempty"`
 }
 
 func (ga *GenesisAppState) Hash() ([]byte, error) {
@@ -22,6 +28,16 @@ func (ga *GenesisAppState) Hash() ([]byte, error) {
 				return nil, err
 			}
 		}
+		if ga.PermissionedDeploy {
+			if _, err := hasher.Write([]byte("permissionedDeploy")); err != nil {
+				return nil, err
+			}
+			for _, addr := range ga.AllowedDeployers {
+				if _, err := hasher.Write(addr); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 	return hasher.Sum(nil), nil
 }
@@ -8,11 +8,15 @@ require (
 	github.com/beatoz/beatoz-sdk-go v0.2.9
 	github.com/beatoz/sfeeder v0.0.1
 	github.com/containerd/continuity v0.3.0
+	github.com/cosmos/go-bip39 v0.0.0-20180819234021-555e2067c45d
 	github.com/cosmos/iavl v1.3.0
 	github.com/ethereum/go-ethereum v1.13.15
+	github.com/go-kit/kit v0.12.0
 	github.com/gogo/protobuf v1.3.2
+	github.com/google/uuid v1.6.0
 	github.com/holiman/uint256 v1.3.1
 	github.com/json-iterator/go v1.1.12
+	github.com/prometheus/client_golang v1.14.0
 	github.com/robaho/fixed v0.0.0-20250130054609-fd0e46fcd988
 	github.com/shirou/gopsutil v3.21.4-0.20210419000835-c7a38de76ee5+incompatible
 	github.com/shopspring/decimal v1.4.0
@@ -65,7 +69,6 @@ require (
 	github.com/fsnotify/fsnotify v1.6.0 // indirect
 	github.com/gballet/go-verkle v0.1.1-0.20231031103413-a67434b50f46 // indirect
 	github.com/getsentry/sentry-go v0.18.0 // indirect
-	github.com/go-kit/kit v0.12.0 // indirect
 	github.com/go-kit/log v0.2.1 // indirect
 	github.com/go-logfmt/logfmt v0.5.1 // indirect
 	github.com/go-ole/go-ole v1.3.0 // indirect
@@ -104,7 +107,6 @@ require (
 	github.com/petermattis/goid v0.0.0-20180202154549-b0b1615b78e5 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/prometheus/client_golang v1.14.0 // indirect
 	github.com/prometheus/client_model v0.3.0 // indirect
 	github.com/prometheus/common v0.39.0 // indirect
 	github.com/prometheus/procfs v0.9.0 // indirect
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"bytes"
+
+	"github.com/beatoz/beatoz-go/types/xerrors"
+)
+
+// FuncDiff is called for the key whose value is different in two ledgers.
+// `v0` or `v1` is nil when the key exists in only one of them.
+// Returning a non-nil error stops the walking.
+type FuncDiff func(key LedgerKey, v0, v1 []byte) xerrors.XError
+
+// DiffAt walks the two ledgers at the version `ver` in key order
+// and calls `cb` for each key whose value differs between them.
+func DiffAt(ver int64, l0, l1 IMutable, cb FuncDiff) xerrors.XError {
+	tree0, xerr := l0.GetReadOnlyTree(ver)
+	if xerr != nil {
+		return xerr
+	}
+	tree1, xerr := l1.GetReadOnlyTree(ver)
+	if xerr != nil {
+		return xerr
+	}
+	if bytes.Equal(tree0.Hash(), tree1.Hash()) {
+		return nil
+	}
+
+	iter0, err := tree0.Iterator(nil, nil, true)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	defer iter0.Close()
+
+	iter1, err := tree1.Iterator(nil, nil, true)
+	if err != nil {
+		return xerrors.From(err)
+	}
+	defer iter1.Close()
+
+	for iter0.Valid() || iter1.Valid() {
+		var key LedgerKey
+		var v0, v1 []byte
+
+		cmp := 0
+		if !iter0.Valid() {
+			cmp = 1
+		} else if !iter1.Valid() {
+			cmp = -1
+		} else {
+			cmp = bytes.Compare(iter0.Key(), iter1.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			key, v0 = iter0.Key(), iter0.Value()
+			iter0.Next()
+		case cmp > 0:
+			key, v1 = iter1.Key(), iter1.Value()
+			iter1.Next()
+		default:
+			key, v0, v1 = iter0.Key(), iter0.Value(), iter1.Value()
+			iter0.Next()
+			iter1.Next()
+			if bytes.Equal(v0, v1) {
+				continue
+			}
+		}
+
+		if xerr := cb(key, v0, v1); xerr != nil {
+			return xerr
+		}
+	}
+
+	if err := iter0.Error(); err != nil {
+		return xerrors.From(err)
+	}
+	if err := iter1.Error(); err != nil {
+		return xerrors.From(err)
+	}
+	return nil
+}
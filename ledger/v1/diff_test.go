@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestDiffAt(t *testing.T) {
+	dbDir, err := os.MkdirTemp("", "ledger_diff_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dbDir)
+
+	newItemFor := func(key LedgerKey) ILedgerItem { return &Item{} }
+	ledger0, xerr := NewMutableLedger("ledger0", dbDir, 1000, newItemFor, log.NewNopLogger())
+	require.NoError(t, xerr)
+	defer ledger0.Close()
+	ledger1, xerr := NewMutableLedger("ledger1", dbDir, 1000, newItemFor, log.NewNopLogger())
+	require.NoError(t, xerr)
+	defer ledger1.Close()
+
+	for i := 0; i < 100; i++ {
+		item := newItem(i, fmt.Sprintf("value:%d", i))
+		require.NoError(t, ledger0.Set(item.Key(), item))
+		require.NoError(t, ledger1.Set(item.Key(), item))
+	}
+	_, ver0, xerr := ledger0.Commit()
+	require.NoError(t, xerr)
+	_, ver1, xerr := ledger1.Commit()
+	require.NoError(t, xerr)
+	require.Equal(t, ver0, ver1)
+
+	// no difference
+	cnt := 0
+	require.NoError(t, DiffAt(ver0, ledger0, ledger1, func(key LedgerKey, v0, v1 []byte) xerrors.XError {
+		cnt++
+		return nil
+	}))
+	require.Equal(t, 0, cnt)
+
+	// item 10 is changed, item 20 exists only in ledger0 and item 100 exists only in ledger1.
+	item := newItem(10, "changed")
+	require.NoError(t, ledger1.Set(item.Key(), item))
+	item = newItem(20, "")
+	require.NoError(t, ledger1.Del(item.Key()))
+	item = newItem(100, "value:100")
+	require.NoError(t, ledger1.Set(item.Key(), item))
+
+	_, ver0, xerr = ledger0.Commit()
+	require.NoError(t, xerr)
+	_, ver1, xerr = ledger1.Commit()
+	require.NoError(t, xerr)
+	require.Equal(t, ver0, ver1)
+
+	var diffKeys []int
+	require.NoError(t, DiffAt(ver0, ledger0, ledger1, func(key LedgerKey, v0, v1 []byte) xerrors.XError {
+		k := int(binary.BigEndian.Uint32(key))
+		switch k {
+		case 10:
+			require.NotNil(t, v0)
+			require.NotNil(t, v1)
+		case 20:
+			require.NotNil(t, v0)
+			require.Nil(t, v1)
+		case 100:
+			require.Nil(t, v0)
+			require.NotNil(t, v1)
+		}
+		diffKeys = append(diffKeys, k)
+		return nil
+	}))
+	require.Equal(t, []int{10, 20, 100}, diffKeys)
+
+	// the previous version has no difference.
+	cnt = 0
+	require.NoError(t, DiffAt(ver0-1, ledger0, ledger1, func(key LedgerKey, v0, v1 []byte) xerrors.XError {
+		cnt++
+		return nil
+	}))
+	require.Equal(t, 0, cnt)
+}
+
+func TestKeyPrefixName(t *testing.T) {
+	addr := types.RandAddress()
+	txhash := bytes.RandBytes(32)
+	for expected, key := range map[string]LedgerKey{
+		"account":            LedgerKeyAccount(addr),
+		"token":              LedgerKeyToken(addr),
+		"token_balance":      LedgerKeyTokenBalance(addr, types.RandAddress()),
+		"gov_params":         LedgerKeyGovParams(),
+		"proposal":           LedgerKeyProposal(txhash),
+		"frozen_proposal":    LedgerKeyFrozenProp(txhash),
+		"treasury_payout":    LedgerKeyTreasuryPayout(txhash),
+		"allow_list":         LedgerKeyAllowList(addr),
+		"delegatee":          LedgerKeyDelegatee(addr),
+		"vpower":             LedgerKeyVPower(addr, addr),
+		"frozen_vpower":      LedgerKeyFrozenVPower(1, addr),
+		"missed_block_count": LedgerKeyMissedBlockCount(addr),
+		"total_supply":       LedgerKeyTotalSupply(),
+		"reward":             LedgerKeyReward(addr),
+		"unknown":            {0xff},
+		"":                   nil,
+	} {
+		require.Equal(t, expected, KeyPrefixName(key), "key: %X", key)
+	}
+}
@@ -7,9 +7,13 @@ import (
 
 var (
 	KeyPrefixAccount          = []byte{0x00}
+	KeyPrefixToken            = []byte{0x01}
+	KeyPrefixTokenBalance     = []byte{0x02}
 	KeyPrefixGovParams        = []byte{0x10}
 	KeyPrefixProposal         = []byte{0x11}
 	KeyPrefixFrozenProp       = []byte{0x12}
+	KeyPrefixTreasuryPayout   = []byte{0x13}
+	KeyPrefixAllowList        = []byte{0x14}
 	KeyPrefixDelegatee        = []byte{0x20}
 	KeyPrefixVPower           = []byte{0x21}
 	KeyPrefixFrozenVPower     = []byte{0x22}
@@ -30,12 +34,42 @@ func LedgerKeyFrozenProp(txhash []byte) LedgerKey {
 	return _key
 }
 
+func LedgerKeyTreasuryPayout(txhash []byte) LedgerKey {
+	_key := make([]byte, len(KeyPrefixTreasuryPayout)+len(txhash))
+	copy(_key, append(KeyPrefixTreasuryPayout, txhash...))
+	return _key
+}
+
+// LedgerKeyAllowList returns the key of the allow-list of `target`.
+// `target` is the zero address for the list of the deployers, or the contract address for the list of its callers.
+func LedgerKeyAllowList(target types.Address) LedgerKey {
+	_key := make([]byte, len(KeyPrefixAllowList)+len(target))
+	copy(_key, append(KeyPrefixAllowList, target...))
+	return _key
+}
+
 func LedgerKeyAccount(addr types.Address) LedgerKey {
 	key := make([]byte, len(KeyPrefixAccount)+len(addr))
 	copy(key, append(KeyPrefixAccount, addr...))
 	return key
 }
 
+func LedgerKeyToken(addr types.Address) LedgerKey {
+	key := make([]byte, len(KeyPrefixToken)+len(addr))
+	copy(key, append(KeyPrefixToken, addr...))
+	return key
+}
+
+// LedgerKeyTokenBalance returns the key of the balance of `token` held by `holder`.
+// The balances of a token share the prefix of `KeyPrefixTokenBalance` + `token`.
+func LedgerKeyTokenBalance(token, holder types.Address) LedgerKey {
+	k := make([]byte, len(KeyPrefixTokenBalance)+len(token)+len(holder))
+	copy(k, KeyPrefixTokenBalance)
+	copy(k[len(KeyPrefixTokenBalance):], token)
+	copy(k[len(KeyPrefixTokenBalance)+len(token):], holder)
+	return k
+}
+
 func LedgerKeyGovParams() LedgerKey {
 	_key := make([]byte, len(KeyPrefixGovParams))
 	copy(_key, KeyPrefixGovParams)
@@ -82,3 +116,42 @@ func LedgerKeyReward(owner types.Address) LedgerKey {
 func UnwrapKeyPrefix(key LedgerKey) []byte {
 	return key[1:]
 }
+
+// KeyPrefixName returns the name of the prefix of `key`.
+// It is used to show the ledger key in a human-readable form.
+func KeyPrefixName(key LedgerKey) string {
+	if len(key) == 0 {
+		return ""
+	}
+	switch key[0] {
+	case KeyPrefixAccount[0]:
+		return "account"
+	case KeyPrefixToken[0]:
+		return "token"
+	case KeyPrefixTokenBalance[0]:
+		return "token_balance"
+	case KeyPrefixGovParams[0]:
+		return "gov_params"
+	case KeyPrefixProposal[0]:
+		return "proposal"
+	case KeyPrefixFrozenProp[0]:
+		return "frozen_proposal"
+	case KeyPrefixTreasuryPayout[0]:
+		return "treasury_payout"
+	case KeyPrefixAllowList[0]:
+		return "allow_list"
+	case KeyPrefixDelegatee[0]:
+		return "delegatee"
+	case KeyPrefixVPower[0]:
+		return "vpower"
+	case KeyPrefixFrozenVPower[0]:
+		return "frozen_vpower"
+	case KeyPrefixMissedBlockCount[0]:
+		return "missed_block_count"
+	case KeyPrefixTotalSupply[0]:
+		return "total_supply"
+	case KeyPrefixReward[0]:
+		return "reward"
+	}
+	return "unknown"
+}
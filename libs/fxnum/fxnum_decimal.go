@@ -7,6 +7,9 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Backend is the name of the arithmetic package used by this build (`shopspring/decimal`).
+const Backend = "decimal"
+
 var (
 	ZERO          = New(0, 0)
 	ONE           = New(1, 0)
@@ -17,12 +20,12 @@ var (
 )
 
 func init() {
-	SetDivisionPrecision(16)
+	decimal.DivisionPrecision = 16
 }
 
 func SetWantPrecision(precision int32) {
 	wantPrecision = precision
-	decimal.DivisionPrecision = min(precision*3, 16)
+	decimal.DivisionPrecision = int(min(precision*3, 16))
 }
 
 func GetWantPrecision() int32 {
@@ -50,7 +53,8 @@ func FromFloat(val float64) FxNum {
 }
 
 func FromString(val string) FxNum {
-	return FxNum{decimal.NewFromString(val)}
+	d, _ := decimal.NewFromString(val)
+	return FxNum{d}
 }
 
 func (x FxNum) Add(o FxNum) FxNum {
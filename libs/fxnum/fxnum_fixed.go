@@ -8,6 +8,9 @@ import (
 	"math"
 )
 
+// Backend is the name of the arithmetic package used by this build (`robaho/fixed`).
+const Backend = "fixed"
+
 var (
 	ZERO          = New(0, 0)
 	ONE           = New(1, 0)
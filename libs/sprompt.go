@@ -15,9 +15,24 @@ func ClearCredential(c []byte) {
 }
 
 func ReadCredential(prompt string) []byte {
+	return readSecret("BEATOZ_WALKEY_SECRET", prompt)
+}
+
+// ReadMnemonic reads the mnemonic words from the environment variable `BEATOZ_MNEMONIC` or the terminal.
+func ReadMnemonic(prompt string) []byte {
+	return readSecret("BEATOZ_MNEMONIC", prompt)
+}
+
+// ReadKeystoreCredential reads the passphrase of a keystore file
+// from the environment variable `BEATOZ_KEYSTORE_SECRET` or the terminal.
+func ReadKeystoreCredential(prompt string) []byte {
+	return readSecret("BEATOZ_KEYSTORE_SECRET", prompt)
+}
+
+func readSecret(env, prompt string) []byte {
 	// check environment variable
-	if envPassword := os.Getenv("BEATOZ_WALKEY_SECRET"); envPassword != "" {
-		return []byte(envPassword)
+	if envSecret := os.Getenv(env); envSecret != "" {
+		return []byte(envSecret)
 	}
 
 	var ret []byte
@@ -11,6 +11,7 @@ import (
 	cfg "github.com/beatoz/beatoz-go/cmd/config"
 	"github.com/beatoz/beatoz-go/cmd/version"
 	"github.com/beatoz/beatoz-go/ctrlers/account"
+	"github.com/beatoz/beatoz-go/ctrlers/crisis"
 	"github.com/beatoz/beatoz-go/ctrlers/gov"
 	"github.com/beatoz/beatoz-go/ctrlers/supply"
 	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
@@ -40,12 +41,15 @@ type BeatozApp struct {
 	currBlockCtx *ctrlertypes.BlockContext
 
 	metaDB       *MetaDB
+	txIndexer    *TxIndexer
 	acctCtrler   *account.AcctCtrler
 	govCtrler    *gov.GovCtrler
 	vpowCtrler   *vpower.VPowerCtrler
 	supplyCtrler *supply.SupplyCtrler
 	vmCtrler     *evm.EVMCtrler
 	txExecutor   *TrxExecutor
+	invRegistry  *crisis.InvariantRegistry
+	metrics      *Metrics
 
 	localClient abcicli.Client
 	rootConfig  *cfg.Config
@@ -61,6 +65,14 @@ func NewBeatozApp(config *cfg.Config, logger log.Logger) *BeatozApp {
 		panic(err)
 	}
 
+	// the app-level indexer is disabled along with the tendermint's indexer.
+	var txIndexer *TxIndexer
+	if config.TxIndex == nil || config.TxIndex.Indexer != "null" {
+		if txIndexer, err = OpenTxIndexer("beatoz_index", config.DBDir()); err != nil {
+			panic(err)
+		}
+	}
+
 	govCtrler, err := gov.NewGovCtrler(config, logger)
 	if err != nil {
 		panic(err)
@@ -85,14 +97,24 @@ func NewBeatozApp(config *cfg.Config, logger log.Logger) *BeatozApp {
 
 	txExecutor := NewTrxExecutor(logger)
 
+	supplyCtrler.SetInvariantReaders(acctCtrler, vpowCtrler)
+
+	invRegistry := crisis.NewInvariantRegistry()
+	for _, h := range []ctrlertypes.IInvariantHandler{supplyCtrler, acctCtrler, vpowCtrler} {
+		h.RegisterInvariants(invRegistry)
+	}
+
 	return &BeatozApp{
 		metaDB:       metaDB,
+		txIndexer:    txIndexer,
 		acctCtrler:   acctCtrler,
 		govCtrler:    govCtrler,
 		vpowCtrler:   vpowCtrler,
 		supplyCtrler: supplyCtrler,
 		vmCtrler:     vmCtrler,
 		txExecutor:   txExecutor,
+		invRegistry:  invRegistry,
+		metrics:      NopMetrics(),
 		rootConfig:   config,
 		logger:       logger,
 	}
@@ -127,9 +149,24 @@ func (ctrler *BeatozApp) Stop() error {
 	if err := ctrler.metaDB.Close(); err != nil {
 		return err
 	}
+	if ctrler.txIndexer != nil {
+		if err := ctrler.txIndexer.Close(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// SetMetrics sets the metrics of the app and the controllers.
+// It should be called before the app is started.
+func (ctrler *BeatozApp) SetMetrics(metrics *Metrics, supplyMetrics *supply.Metrics) {
+	ctrler.mtx.Lock()
+	defer ctrler.mtx.Unlock()
+
+	ctrler.metrics = metrics
+	ctrler.supplyCtrler.SetMetrics(supplyMetrics)
+}
+
 func (ctrler *BeatozApp) SetLocalClient(client abcicli.Client) {
 	ctrler.mtx.Lock()
 	defer ctrler.mtx.Unlock()
@@ -270,6 +307,8 @@ func (ctrler *BeatozApp) CheckTx(req abcitypes.RequestCheckTx) abcitypes.Respons
 
 	switch req.Type {
 	case abcitypes.CheckTxType_New:
+		start := time.Now()
+
 		_bctx := ctrlertypes.ExpectNextBlockContext(
 			ctrler.lastBlockCtx,
 			time.Duration(ctrler.govCtrler.EmptyBlockIntervalSecs())*time.Second,
@@ -280,6 +319,8 @@ func (ctrler *BeatozApp) CheckTx(req abcitypes.RequestCheckTx) abcitypes.Respons
 			false,
 		)
 		if xerr != nil {
+			ctrler.metrics.CheckTxFailures.With("type", "unknown", "code", strconv.Itoa(int(xerr.Code()))).Add(1)
+
 			xerr = xerrors.ErrCheckTx.Wrap(xerr)
 			ctrler.logger.Error("CheckTx", "error", xerr)
 			return abcitypes.ResponseCheckTx{
@@ -289,7 +330,10 @@ func (ctrler *BeatozApp) CheckTx(req abcitypes.RequestCheckTx) abcitypes.Respons
 		}
 
 		xerr = ctrler.txExecutor.ExecuteSync(txctx)
+		ctrler.metrics.CheckTxDuration.With("type", txctx.Tx.TypeString()).Observe(time.Since(start).Seconds())
 		if xerr != nil {
+			ctrler.metrics.CheckTxFailures.With("type", txctx.Tx.TypeString(), "code", strconv.Itoa(int(xerr.Code()))).Add(1)
+
 			xerr = xerrors.ErrCheckTx.Wrap(xerr)
 			ctrler.logger.Error("CheckTx", "error", xerr)
 			return abcitypes.ResponseCheckTx{
@@ -477,6 +521,10 @@ func (ctrler *BeatozApp) BeginBlock(req abcitypes.RequestBeginBlock) abcitypes.R
 	}
 	beginBlockEvents = append(beginBlockEvents, evs...)
 
+	if ctrler.txIndexer != nil {
+		ctrler.txIndexer.AddBlockEvents(req.Header.Height, beginBlockEvents)
+	}
+
 	return abcitypes.ResponseBeginBlock{
 		Events: beginBlockEvents,
 	}
@@ -510,6 +558,7 @@ func (ctrler *BeatozApp) DeliverTx(req abcitypes.RequestDeliverTx) abcitypes.Res
 			return txctx, nil
 		},
 	)
+	ctrler.metrics.TrxPreparerQueueDepth.Set(float64(ctrler.txExecutor.TrxPreparer.queueDepth()))
 
 	// this return value has no meaning.
 	return abcitypes.ResponseDeliverTx{}
@@ -517,8 +566,18 @@ func (ctrler *BeatozApp) DeliverTx(req abcitypes.RequestDeliverTx) abcitypes.Res
 
 // asyncExecTrxContext is called in parallel tx processing
 func (ctrler *BeatozApp) asyncExecTrxContext(txctx *ctrlertypes.TrxContext) *abcitypes.ResponseDeliverTx {
+	start := time.Now()
 	xerr := ctrler.txExecutor.ExecuteSync(txctx)
+
+	elapsed := time.Since(start).Seconds()
+	ctrler.metrics.DeliverTxDuration.With("type", txctx.Tx.TypeString()).Observe(elapsed)
+	if txctx.Tx.GetType() == ctrlertypes.TRX_CONTRACT || txctx.IsHandledByEVM() {
+		ctrler.metrics.EVMExecDuration.Observe(elapsed)
+	}
+
 	if xerr != nil {
+		ctrler.metrics.DeliverTxFailures.With("type", txctx.Tx.TypeString(), "code", strconv.Itoa(int(xerr.Code()))).Add(1)
+
 		xerr = xerrors.ErrDeliverTx.Wrap(xerr)
 		ctrler.logger.Error("asyncExecTrxContext", "error", xerr)
 
@@ -603,6 +662,9 @@ func (ctrler *BeatozApp) EndBlock(req abcitypes.RequestEndBlock) abcitypes.Respo
 			// already exists in `param.resDeliverTx` and it is written to blockchain as invalid tx.
 			if ret.txctx != nil {
 				ret.resDeliverTx = ctrler.asyncExecTrxContext(ret.txctx)
+				if ctrler.txIndexer != nil {
+					ctrler.txIndexer.AddTx(idx, ret.txctx, ret.resDeliverTx)
+				}
 			}
 
 			// the `client.Callback` will be called.
@@ -614,6 +676,7 @@ func (ctrler *BeatozApp) EndBlock(req abcitypes.RequestEndBlock) abcitypes.Respo
 			)
 		}
 		ctrler.txExecutor.TrxPreparer.reset()
+		ctrler.metrics.TrxPreparerQueueDepth.Set(0)
 	}
 
 	var beginBlockEvents []abcitypes.Event
@@ -659,6 +722,20 @@ func (ctrler *BeatozApp) EndBlock(req abcitypes.RequestEndBlock) abcitypes.Respo
 	}
 	beginBlockEvents = append(beginBlockEvents, evts...)
 
+	//
+	// NOTE:
+	// The invariants are checked against the state committed at the previous block,
+	// because the state changed in the current block is not committed yet.
+	if period := ctrler.rootConfig.InvCheckPeriod; period > 0 && req.Height > 1 && (req.Height-1)%period == 0 {
+		beginBlockEvents = append(beginBlockEvents, ctrler.assertInvariants(req.Height-1)...)
+	}
+
+	if ctrler.txIndexer != nil {
+		ctrler.txIndexer.AddBlockEvents(req.Height, beginBlockEvents)
+	}
+
+	ctrler.metrics.BlockGasUsed.Set(float64(ctrler.currBlockCtx.GetBlockGasUsed()))
+
 	var consensusParams *abcitypes.ConsensusParams
 	nxBlockSizeLimit := ctrler.govCtrler.BlockSizeLimit()
 	nxBlockGasLimit := ctrler.govCtrler.BlockGasLimit()
@@ -707,8 +784,11 @@ func (ctrler *BeatozApp) Commit() abcitypes.ResponseCommit {
 		ctrler.vmCtrler,
 	}
 
-	for _, ctr := range ctrlers {
+	moduleHashes := ctrlertypes.NewModuleHashes(height)
+	for i, ctr := range ctrlers {
+		start := time.Now()
 		hash, ver, xerr := ctr.Commit()
+		ctrler.metrics.LedgerCommitDuration.With("ledger", ctrlertypes.ModuleNames[i]).Observe(time.Since(start).Seconds())
 		if xerr != nil {
 			panic(xerr)
 		}
@@ -717,9 +797,11 @@ func (ctrler *BeatozApp) Commit() abcitypes.ResponseCommit {
 				height, ver, reflect.TypeOf(ctr).Elem().Name()))
 		}
 		_, _ = hasher.Write(hash)
+		moduleHashes.Add(ctrlertypes.ModuleNames[i], hash)
 	}
 
 	appHash := hasher.Sum(nil)
+	moduleHashes.AppHash = appHash
 
 	ctrler.currBlockCtx.SetAppHash(appHash)
 	ctrler.logger.Debug("Finish BeatozApp::Commit",
@@ -727,6 +809,13 @@ func (ctrler *BeatozApp) Commit() abcitypes.ResponseCommit {
 		"txs", ctrler.currBlockCtx.TxsCnt(),
 		"appHash", ctrler.currBlockCtx.AppHash())
 	_ = ctrler.metaDB.PutLastBlockContext(ctrler.currBlockCtx)
+	_ = ctrler.metaDB.PutModuleHashes(moduleHashes)
+	if ctrler.txIndexer != nil {
+		if err := ctrler.txIndexer.Commit(height); err != nil {
+			// the index is not a part of the state, so the failure of indexing does not stop the node.
+			ctrler.logger.Error("failed to commit the tx index", "height", height, "error", err)
+		}
+	}
 	ctrler.lastBlockCtx = ctrler.currBlockCtx
 	ctrler.currBlockCtx = nil
 
@@ -0,0 +1,33 @@
+package node
+
+import (
+	"fmt"
+	"strconv"
+
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// assertInvariants checks all invariants against the state committed at `height`.
+// It returns the events for the broken invariants or panics if `InvCheckHalt` is set.
+func (ctrler *BeatozApp) assertInvariants(height int64) []abcitypes.Event {
+	var evts []abcitypes.Event
+
+	broken := ctrler.invRegistry.AssertBroken(height)
+	for _, r := range broken {
+		ctrler.logger.Error("invariant broken", "height", height, "module", r.Module, "route", r.Route, "msg", r.Msg)
+		evts = append(evts, abcitypes.Event{
+			Type: "crisis.invariant",
+			Attributes: []abcitypes.EventAttribute{
+				{Key: []byte("height"), Value: []byte(strconv.FormatInt(height, 10)), Index: false},
+				{Key: []byte("module"), Value: []byte(r.Module), Index: true},
+				{Key: []byte("route"), Value: []byte(r.Route), Index: true},
+				{Key: []byte("msg"), Value: []byte(r.Msg), Index: false},
+			},
+		})
+	}
+
+	if len(broken) > 0 && ctrler.rootConfig.InvCheckHalt {
+		panic(fmt.Errorf("%d invariant(s) broken at height %d", len(broken), height))
+	}
+	return evts
+}
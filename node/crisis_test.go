@@ -0,0 +1,109 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/beatoz/beatoz-go/cmd/config"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/genesis"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	types2 "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-sdk-go/web3"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func Test_Invariants(t *testing.T) {
+	wallets := make([]*web3.Wallet, 10)
+	appState := genesis.GenesisAppState{
+		AssetHolders: make([]*genesis.GenesisAssetHolder, len(wallets)),
+		GovParams:    ctrlertypes.NewGovParams(int(ctrlertypes.WeekSeconds / 5)), // minting every 5 blocks
+	}
+	for i := 0; i < len(wallets); i++ {
+		wallets[i] = web3.NewWallet(nil)
+		appState.AssetHolders[i] = &genesis.GenesisAssetHolder{
+			Address: wallets[i].Address(),
+			Balance: types2.ToGrans(1_000),
+		}
+	}
+
+	btzcfg := config.DefaultConfig(chainId.Hex())
+	btzcfg.SetRoot(filepath.Join(t.TempDir(), "invariants-beatoz-app"))
+	btzcfg.InvCheckPeriod = 1
+	btzcfg.InvCheckHalt = true
+
+	btzApp, err := startTestBeatozApp(btzcfg, time.Time{}, &appState,
+		abcitypes.ValidatorUpdates{abcitypes.UpdateValidator(wallets[0].GetPubKey(), 1_000_000, "secp256k1")},
+		nil, log.NewNopLogger())
+	require.NoError(t, err)
+	defer func() {
+		_ = btzApp.Stop()
+		_ = os.RemoveAll(btzcfg.RootDir)
+	}()
+
+	for h := int64(1); h <= 12; h++ {
+		_ = btzApp.BeginBlock(abcitypes.RequestBeginBlock{
+			Header: tmproto.Header{Height: h, ChainID: btzcfg.ChainIdHex(), ProposerAddress: wallets[0].Address()},
+		})
+
+		// no transaction at height 1, because the genesis state is not committed yet.
+		for _, from := range wallets[1:] {
+			if h == 1 {
+				break
+			}
+			tx := web3.NewTrxTransfer(from.Address(), types2.RandAddress(), from.GetNonce(), btzApp.govCtrler.MinTrxGas(), btzApp.govCtrler.GasPrice(), uint256.NewInt(1))
+			_, _, err := from.SignTrxRLP(tx, btzcfg.ChainIdHex())
+			require.NoError(t, err)
+			bztx, err := tx.Encode()
+			require.NoError(t, err)
+
+			checkTxResp := btzApp.CheckTx(abcitypes.RequestCheckTx{Tx: bztx})
+			require.Equal(t, abcitypes.CodeTypeOK, checkTxResp.Code, checkTxResp.Log)
+			from.AddNonce()
+
+			_ = btzApp.DeliverTx(abcitypes.RequestDeliverTx{Tx: bztx})
+		}
+
+		// `InvCheckHalt` is set, so it panics when any invariant is broken.
+		respEndBlock := btzApp.EndBlock(abcitypes.RequestEndBlock{Height: h})
+		for _, evt := range respEndBlock.Events {
+			require.NotEqual(t, "crisis.invariant", evt.Type)
+		}
+		_ = btzApp.Commit()
+	}
+
+	// on demand
+	resp := btzApp.Query(abcitypes.RequestQuery{Path: "invariants"})
+	require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+
+	var rets []*ctrlertypes.InvariantResult
+	require.NoError(t, jsonx.Unmarshal(resp.Value, &rets))
+	require.Len(t, rets, len(btzApp.invRegistry.Routes()))
+	for _, r := range rets {
+		require.False(t, r.Broken, r.Msg)
+	}
+
+	resp = btzApp.Query(abcitypes.RequestQuery{Path: "invariants", Height: 100})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+
+	// break the total supply
+	_ = btzApp.BeginBlock(abcitypes.RequestBeginBlock{
+		Header: tmproto.Header{Height: 13, ChainID: btzcfg.ChainIdHex(), ProposerAddress: wallets[0].Address()},
+	})
+	require.NoError(t, btzApp.acctCtrler.AddBalance(wallets[1].Address(), uint256.NewInt(1), true))
+	_ = btzApp.EndBlock(abcitypes.RequestEndBlock{Height: 13})
+	_ = btzApp.Commit()
+
+	_ = btzApp.BeginBlock(abcitypes.RequestBeginBlock{
+		Header: tmproto.Header{Height: 14, ChainID: btzcfg.ChainIdHex(), ProposerAddress: wallets[0].Address()},
+	})
+	require.Panics(t, func() {
+		_ = btzApp.EndBlock(abcitypes.RequestEndBlock{Height: 14})
+	})
+}
@@ -0,0 +1,56 @@
+package node
+
+import (
+	"time"
+
+	cfg "github.com/beatoz/beatoz-go/cmd/config"
+	"github.com/beatoz/beatoz-go/genesis"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// startTestBeatozApp creates the BeatozApp rooted at `config.RootDir`,
+// initializes the chain with `appState` and `validators` at `genTime`, and starts it.
+// `respCb` is called with the result of each transaction executed at EndBlock. If it is nil, the results are dropped.
+func startTestBeatozApp(
+	config *cfg.Config,
+	genTime time.Time,
+	appState *genesis.GenesisAppState,
+	validators abcitypes.ValidatorUpdates,
+	respCb abcicli.Callback,
+	logger log.Logger,
+) (*BeatozApp, error) {
+	jz, err := jsonx.Marshal(appState)
+	if err != nil {
+		return nil, err
+	}
+	if respCb == nil {
+		respCb = func(*abcitypes.Request, *abcitypes.Response) {}
+	}
+
+	btzApp := NewBeatozApp(config, logger)
+	btzClient := NewBeatozLocalClient(&tmsync.Mutex{}, btzApp)
+	btzClient.SetResponseCallback(respCb)
+	btzApp.SetLocalClient(btzClient)
+	btzApp.Info(abcitypes.RequestInfo{})
+	btzApp.InitChain(abcitypes.RequestInitChain{
+		Time:    genTime,
+		ChainId: config.ChainIdHex(),
+		ConsensusParams: &abcitypes.ConsensusParams{
+			Block: &abcitypes.BlockParams{
+				MaxBytes: 22020096,
+				MaxGas:   36000000,
+			},
+		},
+		Validators:    validators,
+		AppStateBytes: jz,
+		InitialHeight: 1,
+	})
+	if err := btzApp.Start(); err != nil {
+		return nil, err
+	}
+	return btzApp, nil
+}
@@ -13,6 +13,7 @@ const (
 	keyBlockContext = "bc"
 	keyTxn          = "xn"
 	keyTxFee        = "xf"
+	keyModuleHashes = "mh"
 )
 
 type MetaDB struct {
@@ -82,6 +83,38 @@ func (stdb *MetaDB) PutLastBlockContext(ctx *ctrlertypes.BlockContext) error {
 	return stdb.put(keyBlockContext, bz)
 }
 
+func (stdb *MetaDB) ModuleHashes(height int64) *ctrlertypes.ModuleHashes {
+	stdb.mtx.RLock()
+	defer stdb.mtx.RUnlock()
+
+	bz := stdb.get(moduleHashesKey(height))
+	if bz == nil {
+		return nil
+	}
+	ret := &ctrlertypes.ModuleHashes{}
+	if err := jsonx.Unmarshal(bz, ret); err != nil {
+		return nil
+	}
+	return ret
+}
+
+func (stdb *MetaDB) PutModuleHashes(mh *ctrlertypes.ModuleHashes) error {
+	stdb.mtx.Lock()
+	defer stdb.mtx.Unlock()
+
+	bz, err := jsonx.Marshal(mh)
+	if err != nil {
+		return err
+	}
+	return stdb.put(moduleHashesKey(mh.Height), bz)
+}
+
+func moduleHashesKey(height int64) string {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return keyModuleHashes + string(bz)
+}
+
 func (stdb *MetaDB) Txn() uint64 {
 	stdb.mtx.RLock()
 	defer stdb.mtx.RUnlock()
@@ -0,0 +1,40 @@
+package node
+
+import (
+	"testing"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ModuleHashes(t *testing.T) {
+	metaDB, err := OpenMetaDB("meta_db_test", t.TempDir())
+	require.NoError(t, err)
+	defer metaDB.Close()
+
+	require.Nil(t, metaDB.ModuleHashes(1))
+
+	var mhs []*ctrlertypes.ModuleHashes
+	for h := int64(1); h <= 3; h++ {
+		mh := ctrlertypes.NewModuleHashes(h)
+		for _, name := range ctrlertypes.ModuleNames {
+			mh.Add(name, bytes.RandBytes(32))
+		}
+		mh.AppHash = bytes.RandBytes(32)
+		require.NoError(t, metaDB.PutModuleHashes(mh))
+		mhs = append(mhs, mh)
+	}
+
+	for _, mh := range mhs {
+		_mh := metaDB.ModuleHashes(mh.Height)
+		require.NotNil(t, _mh)
+		require.Equal(t, mh, _mh)
+		require.Len(t, mh.Diff(_mh), 0)
+	}
+
+	_mh := metaDB.ModuleHashes(mhs[0].Height)
+	_mh.Find("supply").Hash = bytes.RandBytes(32)
+	require.Equal(t, []string{"supply"}, mhs[0].Diff(_mh))
+	require.Len(t, mhs[0].Diff(mhs[1]), len(ctrlertypes.ModuleNames))
+}
@@ -0,0 +1,136 @@
+package node
+
+import (
+	"github.com/beatoz/beatoz-go/ctrlers/supply"
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	tmcfg "github.com/tendermint/tendermint/config"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this package.
+	MetricsSubsystem = "beatoz"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Histogram of CheckTx durations in seconds, labeled by tx type.
+	CheckTxDuration metrics.Histogram
+	// Number of failed CheckTx, labeled by tx type and error code.
+	CheckTxFailures metrics.Counter
+	// Histogram of the tx execution durations in seconds at EndBlock, labeled by tx type.
+	DeliverTxDuration metrics.Histogram
+	// Number of failed DeliverTx, labeled by tx type and error code.
+	DeliverTxFailures metrics.Counter
+	// Histogram of the durations in seconds of the txs executed by EVM.
+	EVMExecDuration metrics.Histogram
+	// Gas used in the last block.
+	BlockGasUsed metrics.Gauge
+	// Number of the DeliverTx requests waiting to be prepared by TrxPreparer.
+	TrxPreparerQueueDepth metrics.Gauge
+	// Histogram of the commit durations in seconds, labeled by ledger.
+	LedgerCommitDuration metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo", "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		CheckTxDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "check_tx_duration_seconds",
+			Help:      "CheckTx durations in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 2, 16),
+		}, withLabels(labels, "type")).With(labelsAndValues...),
+		CheckTxFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "check_tx_failures",
+			Help:      "Number of failed CheckTx.",
+		}, withLabels(labels, "type", "code")).With(labelsAndValues...),
+		DeliverTxDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "deliver_tx_duration_seconds",
+			Help:      "Transaction execution durations in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 2, 16),
+		}, withLabels(labels, "type")).With(labelsAndValues...),
+		DeliverTxFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "deliver_tx_failures",
+			Help:      "Number of failed DeliverTx.",
+		}, withLabels(labels, "type", "code")).With(labelsAndValues...),
+		EVMExecDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "evm_exec_duration_seconds",
+			Help:      "Durations in seconds of the transactions executed by EVM.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 2, 16),
+		}, labels).With(labelsAndValues...),
+		BlockGasUsed: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_gas_used",
+			Help:      "Gas used in the last block.",
+		}, labels).With(labelsAndValues...),
+		TrxPreparerQueueDepth: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "trx_preparer_queue_depth",
+			Help:      "Number of the DeliverTx requests waiting to be prepared.",
+		}, labels).With(labelsAndValues...),
+		LedgerCommitDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "ledger_commit_duration_seconds",
+			Help:      "Ledger commit durations in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 14),
+		}, withLabels(labels, "ledger")).With(labelsAndValues...),
+	}
+}
+
+// withLabels returns a new slice of `labels` followed by `extra`
+// so that the metrics do not share the underlying array of `labels`.
+func withLabels(labels []string, extra ...string) []string {
+	ret := make([]string, 0, len(labels)+len(extra))
+	ret = append(ret, labels...)
+	return append(ret, extra...)
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		CheckTxDuration:       discard.NewHistogram(),
+		CheckTxFailures:       discard.NewCounter(),
+		DeliverTxDuration:     discard.NewHistogram(),
+		DeliverTxFailures:     discard.NewCounter(),
+		EVMExecDuration:       discard.NewHistogram(),
+		BlockGasUsed:          discard.NewGauge(),
+		TrxPreparerQueueDepth: discard.NewGauge(),
+		LedgerCommitDuration:  discard.NewHistogram(),
+	}
+}
+
+// MetricsProvider returns the metrics of the app and the controllers for the given chain.
+type MetricsProvider func(chainID string) (*Metrics, *supply.Metrics)
+
+// DefaultMetricsProvider returns Metrics build using Prometheus client library
+// if Prometheus is enabled. Otherwise, it returns no-op Metrics.
+// The metrics are served on the same instrumentation endpoint as Tendermint's metrics.
+func DefaultMetricsProvider(config *tmcfg.InstrumentationConfig) MetricsProvider {
+	return func(chainID string) (*Metrics, *supply.Metrics) {
+		if config.Prometheus {
+			return PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				supply.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+		}
+		return NopMetrics(), supply.NopMetrics()
+	}
+}
@@ -0,0 +1,60 @@
+package node
+
+import (
+	"testing"
+
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	tmcfg "github.com/tendermint/tendermint/config"
+)
+
+func Test_DefaultMetricsProvider(t *testing.T) {
+	conf := tmcfg.TestInstrumentationConfig()
+
+	conf.Prometheus = false
+	metrics, supplyMetrics := DefaultMetricsProvider(conf)("test-chain")
+	require.NotNil(t, metrics)
+	require.NotNil(t, supplyMetrics)
+
+	conf.Prometheus = true
+	conf.Namespace = "test_metrics"
+	metrics, supplyMetrics = DefaultMetricsProvider(conf)("test-chain")
+
+	// all labels should be provided without panic.
+	metrics.CheckTxDuration.With("type", "transfer").Observe(0.01)
+	metrics.CheckTxFailures.With("type", "transfer", "code", "10").Add(1)
+	metrics.DeliverTxDuration.With("type", "contract").Observe(0.01)
+	metrics.DeliverTxFailures.With("type", "contract", "code", "10").Add(1)
+	metrics.EVMExecDuration.Observe(0.01)
+	metrics.BlockGasUsed.Set(21000)
+	metrics.TrxPreparerQueueDepth.Set(1)
+	metrics.LedgerCommitDuration.With("ledger", "acct").Observe(0.01)
+	supplyMetrics.MintedSupply.Set(1)
+	supplyMetrics.TotalSupply.Set(1)
+	supplyMetrics.RewardRecipients.Set(1)
+	supplyMetrics.RewardAmount.Observe(1)
+
+	mfs, err := stdprometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	names := make(map[string]bool)
+	for _, mf := range mfs {
+		names[mf.GetName()] = true
+	}
+	for _, name := range []string{
+		"test_metrics_beatoz_check_tx_duration_seconds",
+		"test_metrics_beatoz_check_tx_failures",
+		"test_metrics_beatoz_deliver_tx_duration_seconds",
+		"test_metrics_beatoz_deliver_tx_failures",
+		"test_metrics_beatoz_evm_exec_duration_seconds",
+		"test_metrics_beatoz_block_gas_used",
+		"test_metrics_beatoz_trx_preparer_queue_depth",
+		"test_metrics_beatoz_ledger_commit_duration_seconds",
+		"test_metrics_beatoz_supply_minted",
+		"test_metrics_beatoz_supply_total",
+		"test_metrics_beatoz_supply_reward_recipients",
+		"test_metrics_beatoz_supply_reward_amount",
+	} {
+		require.True(t, names[name], name)
+	}
+}
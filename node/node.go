@@ -8,6 +8,7 @@ import (
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	tmnode "github.com/tendermint/tendermint/node"
 	tmp2p "github.com/tendermint/tendermint/p2p"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 type Provider func(*cfg.Config, []byte, tmlog.Logger) (*tmnode.Node, error)
@@ -21,6 +22,15 @@ func NewBeatozNode(config *cfg.Config, s []byte, logger tmlog.Logger) (*tmnode.N
 		return nil, fmt.Errorf("failed to load or gen beatoz key %s: %w", config.NodeKeyFile(), err)
 	}
 
+	genDocProvider := tmnode.DefaultGenesisDocProviderFunc(config.Config)
+	genDoc, err := genDocProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	app := NewBeatozApp(config, logger.With("module", "beatoz"))
+	app.SetMetrics(DefaultMetricsProvider(config.Instrumentation)(genDoc.ChainID))
+
 	rpcOption := func(node *tmnode.Node) {
 		rpc.AddRoutes()
 
@@ -51,13 +61,24 @@ func NewBeatozNode(config *cfg.Config, s []byte, logger tmlog.Logger) (*tmnode.N
 	}
 
 	return tmnode.NewNode(config.Config,
-		crypto.LoadOrGenSFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), s),
+		newPrivValidator(config, s),
 		nodeKey,
-		NewBeatozLocalClientCreator(NewBeatozApp(config, logger.With("module", "beatoz"))), //proxy.NewLocalClientCreator(node.NewBeatozApp(config.DBDir(), logger)), //proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
-		tmnode.DefaultGenesisDocProviderFunc(config.Config),
+		NewBeatozLocalClientCreator(app), //proxy.NewLocalClientCreator(node.NewBeatozApp(config.DBDir(), logger)), //proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		genDocProvider,
 		tmnode.DefaultDBProvider,
 		tmnode.DefaultMetricsProvider(config.Instrumentation),
 		logger,
 		rpcOption,
 	)
 }
+
+// newPrivValidator returns the SFilePV of the validator key file.
+// If `PrivValidatorListenAddr` is set, it returns nil
+// because `tmnode.NewNode` connects to the external signer process (e.g. `beatoz signer` or tmkms) listening on the address,
+// which has the validator key and protects it from double signing.
+func newPrivValidator(config *cfg.Config, s []byte) tmtypes.PrivValidator {
+	if config.PrivValidatorListenAddr != "" {
+		return nil
+	}
+	return crypto.LoadOrGenSFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), s)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
 	"github.com/beatoz/beatoz-go/libs/jsonx"
 	rtypes "github.com/beatoz/beatoz-go/types"
 	"github.com/beatoz/beatoz-go/types/bytes"
@@ -73,7 +74,7 @@ func (ctrler *BeatozApp) Query(req abcitypes.RequestQuery) abcitypes.ResponseQue
 			}
 		}
 
-	case "stakes", "stakes/total_power", "stakes/voting_power", "delegatee":
+	case "stakes", "stakes/total_power", "stakes/voting_power", "delegatee", "delegatees", "delegators", "frozen_powers":
 		response.Value, xerr = ctrler.vpowCtrler.Query(
 			req,
 			func() interface{} {
@@ -83,18 +84,71 @@ func (ctrler *BeatozApp) Query(req abcitypes.RequestQuery) abcitypes.ResponseQue
 				return ctrler.govCtrler.MinValidatorPower()
 			},
 		)
-	case "reward", "total_supply":
+	case "token", "token_balance":
+		response.Value, xerr = ctrler.acctCtrler.Query(req)
+	case "reward", "total_supply", "rewards":
 		response.Value, xerr = ctrler.supplyCtrler.Query(req)
-	case "proposal", "gov_params":
+	case "proposal", "proposals", "proposal_diff", "allow_list", "gov_params":
 		response.Value, xerr = ctrler.govCtrler.Query(req)
 	case "vm_call", "vm_estimate_gas":
-		response.Value, xerr = ctrler.vmCtrler.Query(req)
+		response.Value, xerr = ctrler.vmCtrler.Query(
+			req,
+			func() interface{} {
+				return ctrler.govCtrler
+			},
+		)
 	case "txn":
 		txn := ctrler.metaDB.Txn()
 		response.Value, xerr = []byte(fmt.Sprintf("\"%d\"", txn)), nil
 	case "total_txfee":
 		totalFee := ctrler.metaDB.TotalTxFee()
 		response.Value, xerr = []byte(fmt.Sprintf("\"%d\"", totalFee)), nil
+	case "module_hashes":
+		mh := ctrler.metaDB.ModuleHashes(req.Height)
+		if mh == nil {
+			xerr = xerrors.ErrQuery.Wrap(xerrors.ErrNotFoundResult)
+			break
+		}
+		if val, err := jsonx.Marshal(mh); err != nil {
+			xerr = xerrors.ErrQuery.Wrap(err)
+		} else {
+			response.Value = val
+		}
+	case "invariants":
+		if req.Height < 1 || req.Height > ctrler.lastBlockCtx.Height() {
+			xerr = xerrors.ErrQuery.Wrapf("invalid height: %v", req.Height)
+			break
+		}
+		if val, err := jsonx.Marshal(ctrler.invRegistry.AssertAll(req.Height)); err != nil {
+			xerr = xerrors.ErrQuery.Wrap(err)
+		} else {
+			response.Value = val
+		}
+	case "account_history", "staking_history":
+		if ctrler.txIndexer == nil {
+			xerr = xerrors.ErrQuery.Wrapf("the indexer is disabled")
+			break
+		}
+		filter := &ctrlertypes.HistoryFilter{}
+		if err := jsonx.Unmarshal(req.Data, filter); err != nil {
+			xerr = xerrors.ErrInvalidQueryParams.Wrap(err)
+			break
+		}
+
+		var ret *ctrlertypes.HistoryResult
+		if req.Path == "account_history" {
+			ret, xerr = ctrler.txIndexer.AccountHistory(filter)
+		} else {
+			ret, xerr = ctrler.txIndexer.StakingHistory(filter)
+		}
+		if xerr != nil {
+			break
+		}
+		if val, err := jsonx.Marshal(ret); err != nil {
+			xerr = xerrors.ErrQuery.Wrap(err)
+		} else {
+			response.Value = val
+		}
 	default:
 		response.Value, xerr = nil, xerrors.ErrInvalidQueryPath
 	}
@@ -0,0 +1,571 @@
+package node
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	cfg "github.com/beatoz/beatoz-go/cmd/config"
+	"github.com/beatoz/beatoz-go/ctrlers/gov/proposal"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/genesis"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-sdk-go/web3"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	SimOpTransfer = "transfer"
+	SimOpStaking  = "staking"
+	SimOpUnstake  = "unstaking"
+	SimOpProposal = "proposal"
+	SimOpVoting   = "voting"
+	SimOpWithdraw = "withdraw"
+	SimOpContract = "contract"
+
+	simContractGas = 1_000_000
+)
+
+// simContractCode is the init code of the contract which stores the first word of the call data at slot 0.
+// runtime code: PUSH1 0 CALLDATALOAD PUSH1 0 SSTORE STOP
+var simContractCode = bytes.HexBytes{
+	0x60, 0x07, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x07, 0x60, 0x00, 0xf3,
+	0x60, 0x00, 0x35, 0x60, 0x00, 0x55, 0x00,
+}
+
+// SimParams is the configuration of a simulation.
+// All random choices in a simulation are made by the generator seeded with `Seed`,
+// so the simulation with the same parameters always produces the same blocks.
+type SimParams struct {
+	Seed           int64
+	ChainID        string
+	Blocks         int64
+	ValidatorCnt   int
+	AccountCnt     int
+	MaxTxsPerBlock int
+	// OpWeights is the relative frequency of each operation.
+	// The operation not in this map is never generated.
+	OpWeights map[string]int
+	// EvidenceRate is the probability that a byzantine evidence is injected into a block.
+	EvidenceRate float64
+	// MissedVoteRate is the probability that a validator misses the vote for a block.
+	MissedVoteRate float64
+}
+
+func DefaultSimParams(seed int64) *SimParams {
+	return &SimParams{
+		Seed:           seed,
+		ChainID:        "0xbea7f0",
+		Blocks:         50,
+		ValidatorCnt:   4,
+		AccountCnt:     20,
+		MaxTxsPerBlock: 10,
+		OpWeights: map[string]int{
+			SimOpTransfer: 40,
+			SimOpStaking:  15,
+			SimOpUnstake:  5,
+			SimOpProposal: 2,
+			SimOpVoting:   10,
+			SimOpWithdraw: 5,
+			SimOpContract: 10,
+		},
+		EvidenceRate:   0.02,
+		MissedVoteRate: 0.05,
+	}
+}
+
+// SimResult is the summary of a simulation.
+type SimResult struct {
+	Seed     int64
+	Height   int64
+	AppHash  bytes.HexBytes
+	Ops      map[string]int
+	Rejected int
+	Failed   int
+	Slashed  int
+}
+
+func (ret *SimResult) String() string {
+	var ops []string
+	for name, cnt := range ret.Ops {
+		ops = append(ops, fmt.Sprintf("%s:%d", name, cnt))
+	}
+	sort.Strings(ops)
+	return fmt.Sprintf("seed:%d, height:%d, appHash:%v, ops:[%s], rejected:%d, failed:%d, slashed:%d",
+		ret.Seed, ret.Height, ret.AppHash, strings.Join(ops, " "), ret.Rejected, ret.Failed, ret.Slashed)
+}
+
+type simStake struct {
+	to     types.Address
+	txHash bytes.HexBytes
+}
+
+type simProposal struct {
+	txHash   bytes.HexBytes
+	startHgt int64
+	endHgt   int64
+}
+
+type simOp struct {
+	name   string
+	weight int
+	gen    func(*simulation, int64) (*web3.Wallet, *ctrlertypes.Trx)
+}
+
+type simulation struct {
+	params *SimParams
+	rand   *rand.Rand
+
+	app     *BeatozApp
+	config  *cfg.Config
+	genTime time.Time
+
+	validators []*web3.Wallet
+	accounts   []*web3.Wallet
+	valPowers  map[string]int64
+
+	stakes    map[string][]*simStake
+	contracts []types.Address
+	proposals []*simProposal
+
+	ops     []*simOp
+	opSum   int
+	pending map[string]*simPending
+
+	result *SimResult
+}
+
+// simPending is the transaction delivered in the current block, whose result is not known yet.
+type simPending struct {
+	op     string
+	sender *web3.Wallet
+	tx     *ctrlertypes.Trx
+}
+
+// RunSimulation runs `params.Blocks` blocks with the random transactions on a new `BeatozApp` rooted at `rootDir`.
+// The invariants are checked after every block.
+// The returned error contains the seed and the height, so the failure can be reproduced with the same seed.
+func RunSimulation(rootDir string, params *SimParams, logger log.Logger) (*SimResult, error) {
+	sim := &simulation{
+		params:    params,
+		rand:      rand.New(rand.NewSource(params.Seed)),
+		genTime:   time.Unix(1_700_000_000, 0).UTC(),
+		valPowers: make(map[string]int64),
+		stakes:    make(map[string][]*simStake),
+		pending:   make(map[string]*simPending),
+		result: &SimResult{
+			Seed: params.Seed,
+			Ops:  make(map[string]int),
+		},
+	}
+	for _, op := range simOperations() {
+		if w := params.OpWeights[op.name]; w > 0 {
+			op.weight = w
+			sim.ops = append(sim.ops, op)
+			sim.opSum += w
+		}
+	}
+
+	if err := sim.initChain(rootDir, logger); err != nil {
+		return sim.result, fmt.Errorf("simulation(seed:%d) fails to init chain: %w", params.Seed, err)
+	}
+	defer func() {
+		_ = sim.app.Stop()
+	}()
+
+	for h := int64(1); h <= params.Blocks; h++ {
+		if err := sim.runBlock(h); err != nil {
+			return sim.result, fmt.Errorf("simulation(seed:%d) fails at height %d: %w", params.Seed, h, err)
+		}
+	}
+	return sim.result, nil
+}
+
+func (sim *simulation) newWallet() *web3.Wallet {
+	for {
+		prvKey := make([]byte, 32)
+		_, _ = sim.rand.Read(prvKey)
+		if _, err := ethcrypto.ToECDSA(prvKey); err == nil {
+			return web3.ImportKey(prvKey, nil)
+		}
+	}
+}
+
+func (sim *simulation) initChain(rootDir string, logger log.Logger) error {
+	params := sim.params
+	if params.ValidatorCnt < 1 || params.AccountCnt < 1 {
+		return fmt.Errorf("at least one validator and one account are required")
+	}
+
+	govParams := ctrlertypes.DefaultGovParams()
+	govParams.SetValue(func(v *ctrlertypes.GovParamsProto) {
+		// shorten the periods so that they are passed in the simulation.
+		v.MinVotingPeriodBlocks = 5
+		v.MaxVotingPeriodBlocks = 20
+		v.LazyApplyingBlocks = 5
+		v.LazyUnbondingBlocks = 10
+		v.InflationCycleBlocks = 20
+		v.MinSignedBlocks = 10
+		v.MinBondingBlocks = 5
+	})
+
+	appState := genesis.GenesisAppState{GovParams: govParams}
+	var valUpdates abcitypes.ValidatorUpdates
+	for i := 0; i < params.ValidatorCnt; i++ {
+		w := sim.newWallet()
+		sim.validators = append(sim.validators, w)
+		valUpdates = append(valUpdates, abcitypes.UpdateValidator(w.GetPubKey(), 1_000_000, "secp256k1"))
+		appState.AssetHolders = append(appState.AssetHolders, &genesis.GenesisAssetHolder{
+			Address: w.Address(),
+			Balance: types.ToGrans(100_000),
+		})
+		sim.valPowers[w.Address().String()] = 1_000_000
+	}
+	for i := 0; i < params.AccountCnt; i++ {
+		w := sim.newWallet()
+		sim.accounts = append(sim.accounts, w)
+		appState.AssetHolders = append(appState.AssetHolders, &genesis.GenesisAssetHolder{
+			Address: w.Address(),
+			Balance: types.ToGrans(1_000_000),
+		})
+	}
+	sim.config = cfg.DefaultConfig(params.ChainID)
+	sim.config.SetRoot(rootDir)
+	// the invariants are checked by the simulation itself after every block.
+	sim.config.InvCheckPeriod = 0
+
+	app, err := startTestBeatozApp(sim.config, sim.genTime, &appState, valUpdates, sim.onResponse, logger)
+	if err != nil {
+		return err
+	}
+	sim.app = app
+	return nil
+}
+
+func (sim *simulation) runBlock(height int64) error {
+	blockTime := sim.genTime.Add(time.Duration(height) * time.Second)
+	valAddrs := sim.validatorAddrs()
+	if len(valAddrs) == 0 {
+		return fmt.Errorf("no validator")
+	}
+
+	req := abcitypes.RequestBeginBlock{
+		Header: tmproto.Header{
+			Height:          height,
+			ChainID:         sim.config.ChainIdHex(),
+			Time:            blockTime,
+			ProposerAddress: valAddrs[sim.rand.Intn(len(valAddrs))],
+		},
+	}
+	if height > 1 {
+		for _, addr := range valAddrs {
+			req.LastCommitInfo.Votes = append(req.LastCommitInfo.Votes, abcitypes.VoteInfo{
+				Validator:       abcitypes.Validator{Address: addr, Power: sim.valPowers[addr.String()]},
+				SignedLastBlock: sim.rand.Float64() >= sim.params.MissedVoteRate,
+			})
+		}
+		if len(valAddrs) > 1 && sim.rand.Float64() < sim.params.EvidenceRate {
+			byzAddr := valAddrs[sim.rand.Intn(len(valAddrs))]
+			req.ByzantineValidators = append(req.ByzantineValidators, abcitypes.Evidence{
+				Type:             abcitypes.EvidenceType_DUPLICATE_VOTE,
+				Validator:        abcitypes.Validator{Address: byzAddr, Power: sim.valPowers[byzAddr.String()]},
+				Height:           height - 1,
+				Time:             blockTime.Add(-time.Second),
+				TotalVotingPower: sim.totalPower(),
+			})
+		}
+	}
+
+	respBegin := sim.app.BeginBlock(req)
+	for _, evt := range respBegin.Events {
+		if evt.Type == "vpower.slashing" {
+			sim.result.Slashed++
+		}
+	}
+
+	// no transaction at height 1, because the genesis state is not committed yet.
+	if height > 1 {
+		txCnt := sim.rand.Intn(sim.params.MaxTxsPerBlock + 1)
+		for i := 0; i < txCnt; i++ {
+			if err := sim.runTx(height, blockTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	respEnd := sim.app.EndBlock(abcitypes.RequestEndBlock{Height: height})
+	for _, vu := range respEnd.ValidatorUpdates {
+		pubKey, err := encoding.PubKeyFromProto(vu.PubKey)
+		if err != nil {
+			return err
+		}
+		addr := types.Address(pubKey.Address())
+		if vu.Power == 0 {
+			delete(sim.valPowers, addr.String())
+		} else {
+			sim.valPowers[addr.String()] = vu.Power
+		}
+	}
+	respCommit := sim.app.Commit()
+	sim.pending = make(map[string]*simPending)
+
+	sim.result.Height = height
+	sim.result.AppHash = respCommit.Data
+
+	if broken := sim.app.invRegistry.AssertBroken(height); len(broken) > 0 {
+		var msgs []string
+		for _, r := range broken {
+			msgs = append(msgs, fmt.Sprintf("%s/%s: %s", r.Module, r.Route, r.Msg))
+		}
+		return fmt.Errorf("invariant broken: %s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+func (sim *simulation) runTx(height int64, blockTime time.Time) error {
+	op := sim.pickOp()
+	sender, tx := op.gen(sim, height)
+	if tx == nil {
+		// nothing to do for this operation in the current state.
+		return nil
+	}
+
+	// the time of tx is fixed to make the tx hash deterministic.
+	tx.Time = blockTime.UnixNano()
+	if _, _, err := sender.SignTrxRLP(tx, sim.config.ChainIdHex()); err != nil {
+		return err
+	}
+	bztx, err := tx.Encode()
+	if err != nil {
+		return err
+	}
+
+	sim.result.Ops[op.name]++
+	if resp := sim.app.CheckTx(abcitypes.RequestCheckTx{Tx: bztx}); resp.Code != abcitypes.CodeTypeOK {
+		sim.result.Rejected++
+		return nil
+	}
+	sim.pending[string(tmtypes.Tx(bztx).Hash())] = &simPending{op: op.name, sender: sender, tx: tx}
+	_ = sim.app.DeliverTx(abcitypes.RequestDeliverTx{Tx: bztx})
+	return nil
+}
+
+// onResponse is called with the result of each transaction executed at EndBlock.
+func (sim *simulation) onResponse(req *abcitypes.Request, resp *abcitypes.Response) {
+	reqDeliverTx, ok := req.Value.(*abcitypes.Request_DeliverTx)
+	if !ok {
+		return
+	}
+	txHash := tmtypes.Tx(reqDeliverTx.DeliverTx.Tx).Hash()
+	p := sim.pending[string(txHash)]
+	if p == nil {
+		return
+	}
+	if resp.GetDeliverTx().Code != abcitypes.CodeTypeOK {
+		sim.result.Failed++
+		return
+	}
+
+	sender := p.sender.Address().String()
+	switch p.op {
+	case SimOpStaking:
+		sim.stakes[sender] = append(sim.stakes[sender], &simStake{to: p.tx.To, txHash: txHash})
+	case SimOpUnstake:
+		unstaked := p.tx.Payload.(*ctrlertypes.TrxPayloadUnstaking).TxHash
+		stakes := sim.stakes[sender]
+		for i, s := range stakes {
+			if bytes.Equal(s.txHash, unstaked) {
+				sim.stakes[sender] = append(stakes[:i], stakes[i+1:]...)
+				break
+			}
+		}
+	case SimOpProposal:
+		payload := p.tx.Payload.(*ctrlertypes.TrxPayloadProposal)
+		sim.proposals = append(sim.proposals, &simProposal{
+			txHash:   txHash,
+			startHgt: payload.StartVotingHeight,
+			endHgt:   payload.StartVotingHeight + payload.VotingPeriodBlocks,
+		})
+	case SimOpContract:
+		if types.IsZeroAddress(p.tx.To) {
+			sim.contracts = append(sim.contracts, ethcrypto.CreateAddress(p.tx.From.Array20(), uint64(p.tx.Nonce)).Bytes())
+		}
+	}
+}
+
+func (sim *simulation) pickOp() *simOp {
+	n := sim.rand.Intn(sim.opSum)
+	for _, op := range sim.ops {
+		if n < op.weight {
+			return op
+		}
+		n -= op.weight
+	}
+	return sim.ops[len(sim.ops)-1]
+}
+
+// validatorAddrs returns the addresses of the current validators in a deterministic order.
+func (sim *simulation) validatorAddrs() []types.Address {
+	var keys []string
+	for k := range sim.valPowers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ret := make([]types.Address, len(keys))
+	for i, k := range keys {
+		ret[i], _ = types.HexToAddress(k)
+	}
+	return ret
+}
+
+func (sim *simulation) totalPower() int64 {
+	sum := int64(0)
+	for _, p := range sim.valPowers {
+		sum += p
+	}
+	return sum
+}
+
+func (sim *simulation) nonceOf(w *web3.Wallet) int64 {
+	// the account of the check state has the nonce increased by the txs already checked in this block.
+	acct := sim.app.acctCtrler.FindAccount(w.Address(), false)
+	if acct == nil {
+		return 0
+	}
+	return acct.Nonce
+}
+
+func (sim *simulation) randAccount() *web3.Wallet {
+	return sim.accounts[sim.rand.Intn(len(sim.accounts))]
+}
+
+func (sim *simulation) randValidator() *web3.Wallet {
+	var vals []*web3.Wallet
+	for _, w := range sim.validators {
+		if _, ok := sim.valPowers[w.Address().String()]; ok {
+			vals = append(vals, w)
+		}
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	return vals[sim.rand.Intn(len(vals))]
+}
+
+func simOperations() []*simOp {
+	return []*simOp{
+		{name: SimOpTransfer, gen: genTransfer},
+		{name: SimOpStaking, gen: genStaking},
+		{name: SimOpUnstake, gen: genUnstaking},
+		{name: SimOpProposal, gen: genProposal},
+		{name: SimOpVoting, gen: genVoting},
+		{name: SimOpWithdraw, gen: genWithdraw},
+		{name: SimOpContract, gen: genContract},
+	}
+}
+
+func genTransfer(sim *simulation, _ int64) (*web3.Wallet, *ctrlertypes.Trx) {
+	from := sim.randAccount()
+	to := sim.randAccount().Address()
+	if sim.rand.Intn(4) == 0 {
+		// to a new account
+		to = sim.newWallet().Address()
+	}
+	// up to 1 BEATOZ
+	amt := new(uint256.Int).Mul(uint256.NewInt(uint64(sim.rand.Int63n(1_000_000)+1)), uint256.NewInt(1_000_000_000_000))
+	return from, web3.NewTrxTransfer(from.Address(), to, sim.nonceOf(from),
+		sim.app.govCtrler.MinTrxGas(), sim.app.govCtrler.GasPrice(), amt)
+}
+
+func genStaking(sim *simulation, _ int64) (*web3.Wallet, *ctrlertypes.Trx) {
+	from := sim.randAccount()
+	// a delegator can delegate to only one validator.
+	var to types.Address
+	if stakes := sim.stakes[from.Address().String()]; len(stakes) > 0 {
+		to = stakes[0].to
+	} else if val := sim.randValidator(); val != nil {
+		to = val.Address()
+	} else {
+		return nil, nil
+	}
+	power := int64(sim.rand.Intn(1_000) + 100)
+	return from, web3.NewTrxStaking(from.Address(), to, sim.nonceOf(from),
+		sim.app.govCtrler.MinTrxGas(), sim.app.govCtrler.GasPrice(), types.PowerToAmount(power))
+}
+
+func genUnstaking(sim *simulation, _ int64) (*web3.Wallet, *ctrlertypes.Trx) {
+	from := sim.randAccount()
+	stakes := sim.stakes[from.Address().String()]
+	if len(stakes) == 0 {
+		return nil, nil
+	}
+	s := stakes[sim.rand.Intn(len(stakes))]
+	return from, web3.NewTrxUnstaking(from.Address(), s.to, sim.nonceOf(from),
+		sim.app.govCtrler.MinTrxGas(), sim.app.govCtrler.GasPrice(), s.txHash)
+}
+
+func genProposal(sim *simulation, height int64) (*web3.Wallet, *ctrlertypes.Trx) {
+	from := sim.randValidator()
+	if from == nil {
+		return nil, nil
+	}
+	govCtrler := sim.app.govCtrler
+	start := height + 1
+	period := govCtrler.MinVotingPeriodBlocks()
+	return from, web3.NewTrxProposal(from.Address(), types.ZeroAddress(), sim.nonceOf(from),
+		govCtrler.MinTrxGas(), govCtrler.GasPrice(),
+		fmt.Sprintf("simulation proposal at %d", height), start, period,
+		start+period+govCtrler.LazyApplyingBlocks(), proposal.PROPOSAL_COMMON, []byte("yes"), []byte("no"))
+}
+
+func genVoting(sim *simulation, height int64) (*web3.Wallet, *ctrlertypes.Trx) {
+	var props []*simProposal
+	for _, p := range sim.proposals {
+		if p.startHgt <= height && height <= p.endHgt {
+			props = append(props, p)
+		}
+	}
+	from := sim.randValidator()
+	if len(props) == 0 || from == nil {
+		return nil, nil
+	}
+	p := props[sim.rand.Intn(len(props))]
+	return from, web3.NewTrxVoting(from.Address(), types.ZeroAddress(), sim.nonceOf(from),
+		sim.app.govCtrler.MinTrxGas(), sim.app.govCtrler.GasPrice(), p.txHash, int32(sim.rand.Intn(2)))
+}
+
+func genWithdraw(sim *simulation, _ int64) (*web3.Wallet, *ctrlertypes.Trx) {
+	from := sim.randAccount()
+	if sim.rand.Intn(2) == 0 {
+		if val := sim.randValidator(); val != nil {
+			from = val
+		}
+	}
+	req := uint256.NewInt(uint64(sim.rand.Int63n(1_000_000_000_000) + 1))
+	return from, web3.NewTrxWithdraw(from.Address(), from.Address(), sim.nonceOf(from),
+		sim.app.govCtrler.MinTrxGas(), sim.app.govCtrler.GasPrice(), req)
+}
+
+func genContract(sim *simulation, _ int64) (*web3.Wallet, *ctrlertypes.Trx) {
+	from := sim.randAccount()
+	if len(sim.contracts) == 0 || sim.rand.Intn(10) == 0 {
+		// deploy
+		return from, web3.NewTrxContract(from.Address(), types.ZeroAddress(), sim.nonceOf(from),
+			simContractGas, sim.app.govCtrler.GasPrice(), uint256.NewInt(0), simContractCode)
+	}
+	data := make([]byte, 32)
+	_, _ = sim.rand.Read(data)
+	to := sim.contracts[sim.rand.Intn(len(sim.contracts))]
+	return from, web3.NewTrxContract(from.Address(), to, sim.nonceOf(from),
+		simContractGas, sim.app.govCtrler.GasPrice(), uint256.NewInt(0), data)
+}
@@ -0,0 +1,34 @@
+package node
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+var (
+	simSeed   = flag.Int64("sim.seed", 42, "the seed of the simulation")
+	simBlocks = flag.Int64("sim.blocks", 50, "the number of blocks to be simulated")
+)
+
+// Test_Simulation runs the random workload and checks the invariants after every block.
+// A failing simulation can be reproduced by `go test ./node -run Test_Simulation -sim.seed=<seed>`.
+func Test_Simulation(t *testing.T) {
+	params := DefaultSimParams(*simSeed)
+	params.ChainID = chainId.Hex()
+	params.Blocks = *simBlocks
+
+	ret, err := RunSimulation(filepath.Join(t.TempDir(), "sim0"), params, log.NewNopLogger())
+	require.NoError(t, err, "rerun with -sim.seed=%d", params.Seed)
+	require.Equal(t, params.Blocks, ret.Height)
+	t.Log(ret)
+
+	// the same seed produces the same state.
+	ret1, err := RunSimulation(filepath.Join(t.TempDir(), "sim1"), params, log.NewNopLogger())
+	require.NoError(t, err, "rerun with -sim.seed=%d", params.Seed)
+	require.Equal(t, ret.AppHash, ret1.AppHash)
+	require.Equal(t, ret.Ops, ret1.Ops)
+}
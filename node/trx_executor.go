@@ -83,14 +83,19 @@ func validateTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
 	}
 
 	switch ctx.Tx.GetType() {
-	case ctrlertypes.TRX_PROPOSAL, ctrlertypes.TRX_VOTING:
+	case ctrlertypes.TRX_PROPOSAL, ctrlertypes.TRX_VOTING, ctrlertypes.TRX_DEPOSIT, ctrlertypes.TRX_CANCEL_PROPOSAL:
 		if xerr := ctx.GovHandler.ValidateTrx(ctx); xerr != nil {
 			return xerr
 		}
-	case ctrlertypes.TRX_TRANSFER, ctrlertypes.TRX_SETDOC:
+	case ctrlertypes.TRX_TRANSFER, ctrlertypes.TRX_SETDOC, ctrlertypes.TRX_SETPUBKEY,
+		ctrlertypes.TRX_TOKEN_ISSUE, ctrlertypes.TRX_TOKEN_MINT, ctrlertypes.TRX_TOKEN_BURN, ctrlertypes.TRX_TOKEN_TRANSFER:
 		if xerr := ctx.AcctHandler.ValidateTrx(ctx); xerr != nil {
 			return xerr
 		}
+		// the transfer to a contract calls its fallback function.
+		if ctx.IsHandledByEVM() && !ctx.GovHandler.IsAllowedCaller(ctx.Receiver.Address, ctx.Tx.From, ctx.Exec) {
+			return xerrors.ErrNoRight.Wrapf("%v is not allowed to call the contract %v", ctx.Tx.From, ctx.Receiver.Address)
+		}
 	case ctrlertypes.TRX_WITHDRAW:
 		if xerr := ctx.SupplyHandler.ValidateTrx(ctx); xerr != nil {
 			return xerr
@@ -128,11 +133,12 @@ func runTrx(ctx *ctrlertypes.TrxContext) xerrors.XError {
 		if xerr = ctx.EVMHandler.ExecuteTrx(ctx); xerr != nil {
 			return xerr
 		}
-	case ctrlertypes.TRX_PROPOSAL, ctrlertypes.TRX_VOTING:
+	case ctrlertypes.TRX_PROPOSAL, ctrlertypes.TRX_VOTING, ctrlertypes.TRX_DEPOSIT, ctrlertypes.TRX_CANCEL_PROPOSAL:
 		if xerr = ctx.GovHandler.ExecuteTrx(ctx); xerr != nil {
 			return xerr
 		}
-	case ctrlertypes.TRX_TRANSFER, ctrlertypes.TRX_SETDOC:
+	case ctrlertypes.TRX_TRANSFER, ctrlertypes.TRX_SETDOC, ctrlertypes.TRX_SETPUBKEY,
+		ctrlertypes.TRX_TOKEN_ISSUE, ctrlertypes.TRX_TOKEN_MINT, ctrlertypes.TRX_TOKEN_BURN, ctrlertypes.TRX_TOKEN_TRANSFER:
 		if ctx.IsHandledByEVM() {
 			if xerr = ctx.EVMHandler.ExecuteTrx(ctx); xerr != nil {
 				return xerr
@@ -106,6 +106,15 @@ func (tp *TrxPreparer) resultCount() int {
 	return len(tp.resultValues)
 }
 
+// queueDepth returns the number of requests waiting to be prepared.
+func (tp *TrxPreparer) queueDepth() int {
+	ret := 0
+	for _, ch := range tp.chReqParams {
+		ret += len(ch)
+	}
+	return ret
+}
+
 func (tp *TrxPreparer) resultList() []*resultValue {
 	tp.mtx.RLock()
 	defer tp.mtx.RUnlock()
@@ -0,0 +1,397 @@
+package node
+
+import (
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"sync"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-go/types/bytes"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/holiman/uint256"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+const (
+	keyHistoryRecord  = "ir"
+	keyAccountHistory = "ia"
+	keyStakingHistory = "is"
+	keyIndexedHeight  = "il"
+)
+
+// TxIndexer indexes the transactions and the block events related to accounts into the local database.
+// Unlike the tendermint's kv indexer, it can answer the queries
+// filtered by an account, tx types and a height range and sorted by height or amount.
+// The records of a block are kept in memory until `Commit` is called.
+type TxIndexer struct {
+	db tmdb.DB
+
+	height     int64
+	pending    []*ctrlertypes.HistoryRecord
+	lastHeight int64
+
+	mtx sync.RWMutex
+}
+
+func OpenTxIndexer(name, dir string) (*TxIndexer, error) {
+	db, err := tmdb.NewDB(name, "goleveldb", dir)
+	if err != nil {
+		return nil, err
+	}
+
+	lastHeight := int64(0)
+	if v, err := db.Get([]byte(keyIndexedHeight)); v != nil && err == nil {
+		lastHeight = int64(binary.BigEndian.Uint64(v))
+	}
+
+	return &TxIndexer{
+		db:         db,
+		lastHeight: lastHeight,
+	}, nil
+}
+
+func (ix *TxIndexer) Close() error {
+	ix.mtx.Lock()
+	defer ix.mtx.Unlock()
+
+	return ix.db.Close()
+}
+
+func (ix *TxIndexer) LastHeight() int64 {
+	ix.mtx.RLock()
+	defer ix.mtx.RUnlock()
+
+	return ix.lastHeight
+}
+
+// AddTx adds the record of the transaction executed at `idx` in the block.
+// The failed transaction is also recorded with its error code.
+func (ix *TxIndexer) AddTx(idx int, txctx *ctrlertypes.TrxContext, resp *abcitypes.ResponseDeliverTx) {
+	ix.mtx.Lock()
+	defer ix.mtx.Unlock()
+
+	tx := txctx.Tx
+	rec := &ctrlertypes.HistoryRecord{
+		Height:  txctx.Height(),
+		Index:   idx,
+		TxHash:  txctx.TxHash,
+		Type:    tx.TypeString(),
+		From:    tx.From,
+		To:      tx.To,
+		Payer:   tx.Payer,
+		Amount:  "0",
+		Code:    resp.Code,
+		GasUsed: resp.GasUsed,
+	}
+	if tx.Amount != nil {
+		rec.Amount = tx.Amount.Dec()
+	}
+
+	switch payload := tx.Payload.(type) {
+	case *ctrlertypes.TrxPayloadUnstaking:
+		rec.Attrs = map[string]string{"stake": payload.TxHash.String()}
+	case *ctrlertypes.TrxPayloadWithdraw:
+		if payload.ReqAmt != nil {
+			rec.Attrs = map[string]string{"reqAmt": payload.ReqAmt.Dec()}
+		}
+	case *ctrlertypes.TrxPayloadVoting:
+		rec.Attrs = map[string]string{
+			"proposal": payload.TxHash.String(),
+			"choice":   strconv.Itoa(int(payload.Choice)),
+		}
+	case *ctrlertypes.TrxPayloadDeposit:
+		rec.Attrs = map[string]string{"proposal": payload.TxHash.String()}
+	case *ctrlertypes.TrxPayloadCancelProposal:
+		rec.Attrs = map[string]string{"proposal": payload.TxHash.String()}
+	case *ctrlertypes.TrxPayloadTokenIssue:
+		rec.Attrs = tokenAmountAttrs(&ctrlertypes.TokenAmount{
+			Token:  ctrlertypes.TokenAddress(tx.From, tx.Nonce),
+			Amount: payload.InitialSupply,
+		})
+	case *ctrlertypes.TrxPayloadTokenMint:
+		rec.Attrs = tokenAmountAttrs(&payload.TokenAmount)
+	case *ctrlertypes.TrxPayloadTokenBurn:
+		rec.Attrs = tokenAmountAttrs(&payload.TokenAmount)
+	case *ctrlertypes.TrxPayloadTokenTransfer:
+		rec.Attrs = tokenAmountAttrs(&payload.TokenAmount)
+	}
+
+	ix.add(rec)
+}
+
+func tokenAmountAttrs(ta *ctrlertypes.TokenAmount) map[string]string {
+	attrs := map[string]string{"token": ta.Token.String()}
+	if ta.Amount != nil {
+		attrs["amount"] = ta.Amount.Dec()
+	}
+	return attrs
+}
+
+// AddBlockEvents adds the records of the block events related to accounts.
+// The events not related to accounts are ignored.
+func (ix *TxIndexer) AddBlockEvents(height int64, evts []abcitypes.Event) {
+	ix.mtx.Lock()
+	defer ix.mtx.Unlock()
+
+	for _, evt := range evts {
+		if evt.Type != "vpower.slashing" {
+			continue
+		}
+
+		attrs := make(map[string]string)
+		for _, attr := range evt.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		addr, err := types.HexToAddress(attrs["byzantine"])
+		if err != nil {
+			continue
+		}
+		slashed, err := strconv.ParseInt(attrs["slashed"], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		ix.add(&ctrlertypes.HistoryRecord{
+			Height: height,
+			Index:  -1,
+			Type:   evt.Type,
+			To:     addr,
+			Amount: types.PowerToAmount(slashed).Dec(),
+			Attrs:  attrs,
+		})
+	}
+}
+
+func (ix *TxIndexer) add(rec *ctrlertypes.HistoryRecord) {
+	if rec.Height != ix.height {
+		// the records of the block, which was not committed, are discarded.
+		ix.height = rec.Height
+		ix.pending = nil
+	}
+	ix.pending = append(ix.pending, rec)
+}
+
+// Commit writes the records of the block at `height` into the database atomically.
+func (ix *TxIndexer) Commit(height int64) error {
+	ix.mtx.Lock()
+	defer ix.mtx.Unlock()
+
+	batch := ix.db.NewBatch()
+	defer batch.Close()
+
+	if ix.height == height {
+		for seq, rec := range ix.pending {
+			suffix := recordSuffix(height, uint32(seq))
+			rkey := append([]byte(keyHistoryRecord), suffix...)
+			bz, err := jsonx.Marshal(rec)
+			if err != nil {
+				return err
+			}
+			if err := batch.Set(rkey, bz); err != nil {
+				return err
+			}
+
+			for _, addr := range recordAccounts(rec) {
+				if err := batch.Set(historyKey(keyAccountHistory, addr, suffix), rkey); err != nil {
+					return err
+				}
+			}
+			if addr := recordValidator(rec); addr != nil {
+				if err := batch.Set(historyKey(keyStakingHistory, addr, suffix), rkey); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	if err := batch.Set([]byte(keyIndexedHeight), bz); err != nil {
+		return err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+
+	ix.pending = nil
+	ix.lastHeight = height
+	return nil
+}
+
+// AccountHistory returns the records sent, received or paid by `filter.Address`.
+func (ix *TxIndexer) AccountHistory(filter *ctrlertypes.HistoryFilter) (*ctrlertypes.HistoryResult, xerrors.XError) {
+	return ix.search(keyAccountHistory, filter)
+}
+
+// StakingHistory returns the staking and unstaking records to the validator `filter.Address`
+// and the slashing records of it.
+func (ix *TxIndexer) StakingHistory(filter *ctrlertypes.HistoryFilter) (*ctrlertypes.HistoryResult, xerrors.XError) {
+	return ix.search(keyStakingHistory, filter)
+}
+
+// search reads the index entries of `filter.Address` in the order of height and stops as soon as the page is filled.
+// Only the sort by amount reads all entries in the height range, up to MaxHistoryScan.
+func (ix *TxIndexer) search(prefix string, filter *ctrlertypes.HistoryFilter) (*ctrlertypes.HistoryResult, xerrors.XError) {
+	if xerr := filter.Validate(); xerr != nil {
+		return nil, xerr
+	}
+
+	page, perPage := ctrlertypes.NormalizePage(filter.Page, filter.PerPage)
+	offset := (page - 1) * perPage
+	byAmount := filter.SortBy == ctrlertypes.HistorySortByAmount
+
+	txTypes := make(map[string]bool)
+	for _, t := range filter.Types {
+		txTypes[t] = true
+	}
+
+	ix.mtx.RLock()
+	defer ix.mtx.RUnlock()
+
+	start := historyKey(prefix, filter.Address, heightBytes(filter.FromHeight))
+	end := historyKey(prefix, filter.Address, heightBytes(filter.ToHeight+1))
+	if filter.ToHeight == 0 {
+		end = prefixEnd(historyKey(prefix, filter.Address, nil))
+	}
+	var iter tmdb.Iterator
+	var err error
+	if filter.Desc && !byAmount {
+		iter, err = ix.db.ReverseIterator(start, end)
+	} else {
+		iter, err = ix.db.Iterator(start, end)
+	}
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	defer iter.Close()
+
+	var recs []*ctrlertypes.HistoryRecord
+	matched, scanned := 0, 0
+	for ; iter.Valid(); iter.Next() {
+		if !byAmount && len(recs) > perPage {
+			// one more record than the page is read to know whether there are more records.
+			break
+		}
+		if scanned++; scanned > ctrlertypes.MaxHistoryScan {
+			return nil, xerrors.ErrInvalidQueryParams.Wrapf("too many records to scan, narrow the height range")
+		}
+
+		bz, err := ix.db.Get(iter.Value())
+		if err != nil {
+			return nil, xerrors.From(err)
+		}
+		if bz == nil {
+			continue
+		}
+		rec := &ctrlertypes.HistoryRecord{}
+		if err := jsonx.Unmarshal(bz, rec); err != nil {
+			return nil, xerrors.From(err)
+		}
+		if len(txTypes) > 0 && !txTypes[rec.Type] {
+			continue
+		}
+		if matched++; !byAmount && matched <= offset {
+			continue
+		}
+		recs = append(recs, rec)
+	}
+
+	if byAmount {
+		amts := make(map[*ctrlertypes.HistoryRecord]*uint256.Int, len(recs))
+		for _, rec := range recs {
+			amt, err := uint256.FromDecimal(rec.Amount)
+			if err != nil {
+				amt = uint256.NewInt(0)
+			}
+			amts[rec] = amt
+		}
+		sort.SliceStable(recs, func(i, j int) bool {
+			return amts[recs[i]].Lt(amts[recs[j]])
+		})
+		if filter.Desc {
+			for i, j := 0, len(recs)-1; i < j; i, j = i+1, j-1 {
+				recs[i], recs[j] = recs[j], recs[i]
+			}
+		}
+		start, _ := ctrlertypes.PageRange(len(recs), page, perPage)
+		recs = recs[start:]
+	}
+
+	ret := &ctrlertypes.HistoryResult{
+		Page:    page,
+		PerPage: perPage,
+		HasMore: len(recs) > perPage,
+		Records: recs,
+	}
+	if ret.HasMore {
+		ret.Records = recs[:perPage]
+	}
+	return ret, nil
+}
+
+// recordAccounts returns the accounts whose history includes `rec`.
+func recordAccounts(rec *ctrlertypes.HistoryRecord) []types.Address {
+	var ret []types.Address
+	for _, addr := range []types.Address{rec.From, rec.To, rec.Payer} {
+		if len(addr) != types.AddrSize || types.IsZeroAddress(addr) {
+			continue
+		}
+		dup := false
+		for _, a := range ret {
+			if bytes.Equal(a, addr) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			ret = append(ret, addr)
+		}
+	}
+	return ret
+}
+
+// recordValidator returns the validator whose staking history includes `rec`.
+func recordValidator(rec *ctrlertypes.HistoryRecord) types.Address {
+	switch rec.Type {
+	case "staking", "unstaking", "vpower.slashing":
+		if len(rec.To) == types.AddrSize {
+			return rec.To
+		}
+	}
+	return nil
+}
+
+func heightBytes(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return bz
+}
+
+// recordSuffix returns `height(8) | seq(4)`, which makes the records ordered by height.
+func recordSuffix(height int64, seq uint32) []byte {
+	bz := make([]byte, 12)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	binary.BigEndian.PutUint32(bz[8:], seq)
+	return bz
+}
+
+func historyKey(prefix string, addr types.Address, suffix []byte) []byte {
+	key := append([]byte(prefix), addr...)
+	return append(key, suffix...)
+}
+
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
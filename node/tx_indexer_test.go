@@ -0,0 +1,172 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/beatoz/beatoz-go/cmd/config"
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/genesis"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	types2 "github.com/beatoz/beatoz-go/types"
+	"github.com/beatoz/beatoz-sdk-go/web3"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func Test_TxIndexer(t *testing.T) {
+	wallets := make([]*web3.Wallet, 3)
+	appState := genesis.GenesisAppState{
+		AssetHolders: make([]*genesis.GenesisAssetHolder, len(wallets)),
+		GovParams:    ctrlertypes.DefaultGovParams(),
+	}
+	for i := 0; i < len(wallets); i++ {
+		wallets[i] = web3.NewWallet(nil)
+		appState.AssetHolders[i] = &genesis.GenesisAssetHolder{
+			Address: wallets[i].Address(),
+			Balance: types2.ToGrans(1_000),
+		}
+	}
+
+	btzcfg := config.DefaultConfig(chainId.Hex())
+	btzcfg.SetRoot(filepath.Join(t.TempDir(), "indexer-beatoz-app"))
+
+	btzApp, err := startTestBeatozApp(btzcfg, time.Time{}, &appState,
+		abcitypes.ValidatorUpdates{abcitypes.UpdateValidator(wallets[0].GetPubKey(), 1_000_000, "secp256k1")},
+		nil, log.NewNopLogger())
+	require.NoError(t, err)
+	defer func() {
+		_ = btzApp.Stop()
+		_ = os.RemoveAll(btzcfg.RootDir)
+	}()
+
+	sender, staker, recver := wallets[1], wallets[2], types2.RandAddress()
+	amounts := []int64{3, 1, 5, 2, 4}
+	for h := int64(1); h <= 7; h++ {
+		_ = btzApp.BeginBlock(abcitypes.RequestBeginBlock{
+			Header: tmproto.Header{Height: h, ChainID: btzcfg.ChainIdHex(), ProposerAddress: wallets[0].Address()},
+		})
+
+		var txs []*ctrlertypes.Trx
+		// no transaction at height 1, because the genesis state is not committed yet.
+		if h >= 2 && h < 2+int64(len(amounts)) {
+			txs = append(txs, web3.NewTrxTransfer(sender.Address(), recver, sender.GetNonce(),
+				btzApp.govCtrler.MinTrxGas(), btzApp.govCtrler.GasPrice(), types2.ToGrans(amounts[h-2])))
+			require.NoError(t, signTrx(sender, txs[len(txs)-1], btzcfg.ChainIdHex()))
+		}
+		if h == 3 {
+			txs = append(txs, web3.NewTrxStaking(staker.Address(), wallets[0].Address(), staker.GetNonce(),
+				btzApp.govCtrler.MinTrxGas(), btzApp.govCtrler.GasPrice(), types2.ToGrans(100)))
+			require.NoError(t, signTrx(staker, txs[len(txs)-1], btzcfg.ChainIdHex()))
+		}
+		for _, tx := range txs {
+			bztx, err := tx.Encode()
+			require.NoError(t, err)
+			checkTxResp := btzApp.CheckTx(abcitypes.RequestCheckTx{Tx: bztx})
+			require.Equal(t, abcitypes.CodeTypeOK, checkTxResp.Code, checkTxResp.Log)
+			_ = btzApp.DeliverTx(abcitypes.RequestDeliverTx{Tx: bztx})
+		}
+
+		_ = btzApp.EndBlock(abcitypes.RequestEndBlock{Height: h})
+		_ = btzApp.Commit()
+	}
+	require.Equal(t, int64(7), btzApp.txIndexer.LastHeight())
+
+	query := func(path string, filter *ctrlertypes.HistoryFilter) *ctrlertypes.HistoryResult {
+		bz, err := jsonx.Marshal(filter)
+		require.NoError(t, err)
+		resp := btzApp.Query(abcitypes.RequestQuery{Path: path, Data: bz})
+		require.Equal(t, abcitypes.CodeTypeOK, resp.Code, resp.Log)
+		ret := &ctrlertypes.HistoryResult{}
+		require.NoError(t, jsonx.Unmarshal(resp.Value, ret))
+		return ret
+	}
+
+	// the history of the sender
+	ret := query("account_history", &ctrlertypes.HistoryFilter{Address: sender.Address()})
+	require.False(t, ret.HasMore)
+	require.Len(t, ret.Records, len(amounts))
+	for i, rec := range ret.Records {
+		require.Equal(t, int64(i+2), rec.Height)
+		require.Equal(t, "transfer", rec.Type)
+		require.Equal(t, abcitypes.CodeTypeOK, rec.Code)
+		require.EqualValues(t, sender.Address(), rec.From)
+		require.EqualValues(t, recver, rec.To)
+		require.Equal(t, types2.ToGrans(amounts[i]).Dec(), rec.Amount)
+	}
+
+	// the history of the receiver between heights, sorted by amount
+	ret = query("account_history", &ctrlertypes.HistoryFilter{
+		Address:    recver,
+		Types:      []string{"transfer"},
+		FromHeight: 3,
+		ToHeight:   5,
+		SortBy:     ctrlertypes.HistorySortByAmount,
+		Desc:       true,
+	})
+	require.Len(t, ret.Records, 3)
+	require.False(t, ret.HasMore)
+	require.Equal(t, types2.ToGrans(5).Dec(), ret.Records[0].Amount)
+	require.Equal(t, types2.ToGrans(2).Dec(), ret.Records[1].Amount)
+	require.Equal(t, types2.ToGrans(1).Dec(), ret.Records[2].Amount)
+
+	// pagination
+	ret = query("account_history", &ctrlertypes.HistoryFilter{Address: recver, Page: 2, PerPage: 2})
+	require.True(t, ret.HasMore)
+	require.Len(t, ret.Records, 2)
+	require.Equal(t, int64(4), ret.Records[0].Height)
+	require.Equal(t, int64(5), ret.Records[1].Height)
+	ret = query("account_history", &ctrlertypes.HistoryFilter{Address: recver, Page: 3, PerPage: 2})
+	require.False(t, ret.HasMore)
+	require.Len(t, ret.Records, 1)
+	require.Equal(t, int64(6), ret.Records[0].Height)
+	ret = query("account_history", &ctrlertypes.HistoryFilter{Address: recver, Page: 1, PerPage: 2, Desc: true})
+	require.True(t, ret.HasMore)
+	require.Equal(t, int64(6), ret.Records[0].Height)
+	require.Equal(t, int64(5), ret.Records[1].Height)
+	ret = query("account_history", &ctrlertypes.HistoryFilter{
+		Address: recver, Page: 2, PerPage: 2, SortBy: ctrlertypes.HistorySortByAmount})
+	require.True(t, ret.HasMore)
+	require.Equal(t, types2.ToGrans(3).Dec(), ret.Records[0].Amount)
+	require.Equal(t, types2.ToGrans(4).Dec(), ret.Records[1].Amount)
+
+	// filtered by types
+	ret = query("account_history", &ctrlertypes.HistoryFilter{Address: sender.Address(), Types: []string{"staking"}})
+	require.Empty(t, ret.Records)
+
+	// the staking history of the validator
+	ret = query("staking_history", &ctrlertypes.HistoryFilter{Address: wallets[0].Address()})
+	require.Len(t, ret.Records, 1)
+	require.Equal(t, "staking", ret.Records[0].Type)
+	require.Equal(t, int64(3), ret.Records[0].Height)
+	require.Equal(t, abcitypes.CodeTypeOK, ret.Records[0].Code)
+	require.EqualValues(t, staker.Address(), ret.Records[0].From)
+
+	// invalid filter
+	bz, err := jsonx.Marshal(&ctrlertypes.HistoryFilter{Address: sender.Address(), SortBy: "nonce"})
+	require.NoError(t, err)
+	resp := btzApp.Query(abcitypes.RequestQuery{Path: "account_history", Data: bz})
+	require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+	// the page size and the depth of the page are capped.
+	for _, filter := range []*ctrlertypes.HistoryFilter{
+		{Address: sender.Address(), PerPage: ctrlertypes.MaxPerPage + 1},
+		{Address: sender.Address(), Page: ctrlertypes.MaxHistoryScan/ctrlertypes.MaxPerPage + 1, PerPage: ctrlertypes.MaxPerPage},
+	} {
+		bz, err = jsonx.Marshal(filter)
+		require.NoError(t, err)
+		resp = btzApp.Query(abcitypes.RequestQuery{Path: "account_history", Data: bz})
+		require.NotEqual(t, abcitypes.CodeTypeOK, resp.Code)
+	}
+}
+
+func signTrx(w *web3.Wallet, tx *ctrlertypes.Trx, chainId string) error {
+	if _, _, err := w.SignTrxRLP(tx, chainId); err != nil {
+		return err
+	}
+	w.AddNonce()
+	return nil
+}
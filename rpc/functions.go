@@ -1,6 +1,11 @@
 package rpc
 
 import (
+	"strings"
+
+	ctrlertypes "github.com/beatoz/beatoz-go/ctrlers/types"
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	rtypes "github.com/beatoz/beatoz-go/types"
 	abytes "github.com/beatoz/beatoz-go/types/bytes"
 	"github.com/beatoz/beatoz-go/types/xerrors"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
@@ -98,6 +103,40 @@ func QueryProposal(ctx *tmrpctypes.Context, txhash abytes.HexBytes, heightPtr *i
 	}
 }
 
+func QueryAllowList(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
+	height := parseHeight(heightPtr)
+	path := parsePath(ctx)
+	if resp, err := tmrpccore.ABCIQuery(ctx, path, tmbytes.HexBytes(addr), height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryToken(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
+	height := parseHeight(heightPtr)
+	path := parsePath(ctx)
+	if resp, err := tmrpccore.ABCIQuery(ctx, path, tmbytes.HexBytes(addr), height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryTokenBalance(ctx *tmrpctypes.Context, token, addr abytes.HexBytes, heightPtr *int64) (*QueryResult, error) {
+	params := make([]byte, len(token)+len(addr))
+	copy(params, token)
+	copy(params[len(token):], addr)
+
+	height := parseHeight(heightPtr)
+	path := parsePath(ctx)
+	if resp, err := tmrpccore.ABCIQuery(ctx, path, params, height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
 func QueryGovParams(ctx *tmrpctypes.Context, heightPtr *int64) (*QueryResult, error) {
 	height := parseHeight(heightPtr)
 	// one of rule and gov_params
@@ -164,6 +203,142 @@ func QueryTxn(ctx *tmrpctypes.Context) (*QueryResult, error) {
 	}
 }
 
+func QueryModuleHashes(ctx *tmrpctypes.Context, heightPtr *int64) (*QueryResult, error) {
+	height := parseHeight(heightPtr)
+	path := parsePath(ctx)
+	if resp, err := tmrpccore.ABCIQuery(ctx, path, nil, height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryInvariants(ctx *tmrpctypes.Context, heightPtr *int64) (*QueryResult, error) {
+	height := parseHeight(heightPtr)
+	path := parsePath(ctx)
+	if resp, err := tmrpccore.ABCIQuery(ctx, path, nil, height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryProposals(ctx *tmrpctypes.Context, status string, heightPtr *int64, pagePtr, perPagePtr *int) (*QueryResult, error) {
+	return queryList(ctx, nil, status, heightPtr, pagePtr, perPagePtr)
+}
+
+func QueryDelegatees(ctx *tmrpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *int) (*QueryResult, error) {
+	return queryList(ctx, nil, "", heightPtr, pagePtr, perPagePtr)
+}
+
+func QueryDelegators(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64, pagePtr, perPagePtr *int) (*QueryResult, error) {
+	return queryList(ctx, addr, "", heightPtr, pagePtr, perPagePtr)
+}
+
+func QueryFrozenPowers(ctx *tmrpctypes.Context, addr abytes.HexBytes, heightPtr *int64, pagePtr, perPagePtr *int) (*QueryResult, error) {
+	return queryList(ctx, addr, "", heightPtr, pagePtr, perPagePtr)
+}
+
+func QueryRewards(ctx *tmrpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *int) (*QueryResult, error) {
+	return queryList(ctx, nil, "", heightPtr, pagePtr, perPagePtr)
+}
+
+func queryList(
+	ctx *tmrpctypes.Context,
+	addr abytes.HexBytes,
+	status string,
+	heightPtr *int64,
+	pagePtr, perPagePtr *int,
+) (*QueryResult, error) {
+	qry := &ctrlertypes.ListQuery{
+		Address: rtypes.Address(addr),
+		Status:  status,
+	}
+	if pagePtr != nil {
+		qry.Page = *pagePtr
+	}
+	if perPagePtr != nil {
+		qry.PerPage = *perPagePtr
+	}
+
+	data, err := jsonx.Marshal(qry)
+	if err != nil {
+		return nil, err
+	}
+	height := parseHeight(heightPtr)
+	path := parsePath(ctx)
+	if resp, err := tmrpccore.ABCIQuery(ctx, path, data, height, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
+func QueryAccountHistory(
+	ctx *tmrpctypes.Context,
+	addr abytes.HexBytes,
+	txTypes string,
+	fromHeightPtr, toHeightPtr *int64,
+	sortBy, orderBy string,
+	pagePtr, perPagePtr *int,
+) (*QueryResult, error) {
+	return queryHistory(ctx, addr, txTypes, fromHeightPtr, toHeightPtr, sortBy, orderBy, pagePtr, perPagePtr)
+}
+
+func QueryStakingHistory(
+	ctx *tmrpctypes.Context,
+	addr abytes.HexBytes,
+	txTypes string,
+	fromHeightPtr, toHeightPtr *int64,
+	sortBy, orderBy string,
+	pagePtr, perPagePtr *int,
+) (*QueryResult, error) {
+	return queryHistory(ctx, addr, txTypes, fromHeightPtr, toHeightPtr, sortBy, orderBy, pagePtr, perPagePtr)
+}
+
+func queryHistory(
+	ctx *tmrpctypes.Context,
+	addr abytes.HexBytes,
+	txTypes string,
+	fromHeightPtr, toHeightPtr *int64,
+	sortBy, orderBy string,
+	pagePtr, perPagePtr *int,
+) (*QueryResult, error) {
+	filter := &ctrlertypes.HistoryFilter{
+		Address:    rtypes.Address(addr),
+		FromHeight: parseHeight(fromHeightPtr),
+		ToHeight:   parseHeight(toHeightPtr),
+		SortBy:     sortBy,
+	}
+	if txTypes != "" {
+		filter.Types = strings.Split(txTypes, ",")
+	}
+	switch orderBy {
+	case "desc":
+		filter.Desc = true
+	case "asc", "":
+	default:
+		return nil, xerrors.NewOrdinary("expected order_by to be either `asc` or `desc` or empty")
+	}
+	if pagePtr != nil {
+		filter.Page = *pagePtr
+	}
+	if perPagePtr != nil {
+		filter.PerPage = *perPagePtr
+	}
+
+	data, err := jsonx.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	path := parsePath(ctx)
+	if resp, err := tmrpccore.ABCIQuery(ctx, path, data, 0, false); err != nil {
+		return nil, err
+	} else {
+		return &QueryResult{resp.Response}, nil
+	}
+}
+
 func Subscribe(ctx *tmrpctypes.Context, query string) (*tmrpccoretypes.ResultSubscribe, error) {
 	// return error when the event subscription request is received over http session.
 	// related to: #103
@@ -7,6 +7,8 @@ import (
 
 func AddRoutes() {
 	tmrpccore.Routes["account"] = tmrpccore_server.NewRPCFunc(QueryAccount, "addr,height")
+	tmrpccore.Routes["token"] = tmrpccore_server.NewRPCFunc(QueryToken, "addr,height")
+	tmrpccore.Routes["token_balance"] = tmrpccore_server.NewRPCFunc(QueryTokenBalance, "token,addr,height")
 	tmrpccore.Routes["delegatee"] = tmrpccore_server.NewRPCFunc(QueryDelegatee, "addr,height")
 	tmrpccore.Routes["stakes"] = tmrpccore_server.NewRPCFunc(QueryStakes, "addr,height")
 	tmrpccore.Routes["stakes/total_power"] = tmrpccore_server.NewRPCFunc(QueryStakes1, "height")
@@ -14,8 +16,10 @@ func AddRoutes() {
 	tmrpccore.Routes["reward"] = tmrpccore_server.NewRPCFunc(QueryReward, "addr,height")
 	tmrpccore.Routes["total_supply"] = tmrpccore_server.NewRPCFunc(QueryTotalSupply, "height")
 	tmrpccore.Routes["total_txfee"] = tmrpccore_server.NewRPCFunc(QueryTotalTxFee, "")
-	tmrpccore.Routes["proposals"] = tmrpccore_server.NewRPCFunc(QueryProposal, "txhash,height")
+	tmrpccore.Routes["proposals"] = tmrpccore_server.NewRPCFunc(QueryProposals, "status,height,page,per_page")
 	tmrpccore.Routes["proposal"] = tmrpccore_server.NewRPCFunc(QueryProposal, "txhash,height")
+	tmrpccore.Routes["proposal_diff"] = tmrpccore_server.NewRPCFunc(QueryProposal, "txhash,height")
+	tmrpccore.Routes["allow_list"] = tmrpccore_server.NewRPCFunc(QueryAllowList, "addr,height")
 	tmrpccore.Routes["rule"] = tmrpccore_server.NewRPCFunc(QueryGovParams, "height")
 	tmrpccore.Routes["gov_params"] = tmrpccore_server.NewRPCFunc(QueryGovParams, "height")
 	tmrpccore.Routes["subscribe"] = tmrpccore_server.NewRPCFunc(Subscribe, "query")
@@ -25,6 +29,14 @@ func AddRoutes() {
 	tmrpccore.Routes["vm_call"] = tmrpccore_server.NewRPCFunc(QueryVM, "addr,to,height,data")
 	tmrpccore.Routes["vm_estimate_gas"] = tmrpccore_server.NewRPCFunc(QueryEstimateGas, "addr,to,height,data")
 	tmrpccore.Routes["txn"] = tmrpccore_server.NewRPCFunc(QueryTxn, "")
+	tmrpccore.Routes["module_hashes"] = tmrpccore_server.NewRPCFunc(QueryModuleHashes, "height")
+	tmrpccore.Routes["invariants"] = tmrpccore_server.NewRPCFunc(QueryInvariants, "height")
+	tmrpccore.Routes["account_history"] = tmrpccore_server.NewRPCFunc(QueryAccountHistory, "addr,types,from_height,to_height,sort_by,order_by,page,per_page")
+	tmrpccore.Routes["staking_history"] = tmrpccore_server.NewRPCFunc(QueryStakingHistory, "addr,types,from_height,to_height,sort_by,order_by,page,per_page")
+	tmrpccore.Routes["delegatees"] = tmrpccore_server.NewRPCFunc(QueryDelegatees, "height,page,per_page")
+	tmrpccore.Routes["delegators"] = tmrpccore_server.NewRPCFunc(QueryDelegators, "addr,height,page,per_page")
+	tmrpccore.Routes["frozen_powers"] = tmrpccore_server.NewRPCFunc(QueryFrozenPowers, "addr,height,page,per_page")
+	tmrpccore.Routes["rewards"] = tmrpccore_server.NewRPCFunc(QueryRewards, "height,page,per_page")
 
 	AddEthRoutes()
 }
@@ -103,11 +103,18 @@ func (peer *PeerMock) Init(valCnt int) error {
 	initParams.InitVotingPower = int64(1_000_000 * valCnt)
 	initParams.InitTotalSupply = int64(100_000_000*500 + 1_000_000*valCnt)
 	initParams.MaxTotalSupply = int64(100_000_000*500 + 1_000_000*valCnt*2)
-	return commands.InitFilesWith(peer.Config, initParams, func(genDoc *types.GenesisDoc) {
+	return commands.InitFilesWith(peer.Config, initParams, func(genDoc *types.GenesisDoc) error {
 		appState := &genesis.GenesisAppState{}
-		_ = jsonx.Unmarshal(genDoc.AppState, appState)
+		if err := jsonx.Unmarshal(genDoc.AppState, appState); err != nil {
+			return err
+		}
 		appState.GovParams.GetValues().InflationCycleBlocks = 10
-		genDoc.AppState, _ = jsonx.Marshal(appState)
+		bz, err := jsonx.Marshal(appState)
+		if err != nil {
+			return err
+		}
+		genDoc.AppState = bz
+		return nil
 	})
 }
 
@@ -34,6 +34,27 @@ var endpoints = []Endpoint{
 		},
 		Response: "QueryResult",
 	},
+	{
+		Name:        "token",
+		Method:      "GET",
+		Description: "Query the native token information by its address",
+		Parameters: []Parameter{
+			{Name: "addr", Type: "string", Required: true, Description: "Token address (hex bytes)"},
+			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+		},
+		Response: "QueryResult",
+	},
+	{
+		Name:        "token_balance",
+		Method:      "GET",
+		Description: "Query the balance of the native token held by the account",
+		Parameters: []Parameter{
+			{Name: "token", Type: "string", Required: true, Description: "Token address (hex bytes)"},
+			{Name: "addr", Type: "string", Required: true, Description: "Account address (hex bytes)"},
+			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+		},
+		Response: "QueryResult",
+	},
 	{
 		Name:        "delegatee",
 		Method:      "GET",
@@ -72,6 +93,52 @@ var endpoints = []Endpoint{
 		},
 		Response: "QueryResult",
 	},
+	{
+		Name:        "delegatees",
+		Method:      "GET",
+		Description: "List delegatees with power and status",
+		Parameters: []Parameter{
+			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+			{Name: "page", Type: "integer", Required: false, Description: "Page number"},
+			{Name: "per_page", Type: "integer", Required: false, Description: "Results per page"},
+		},
+		Response: "QueryResult",
+	},
+	{
+		Name:        "delegators",
+		Method:      "GET",
+		Description: "List delegators of a delegatee",
+		Parameters: []Parameter{
+			{Name: "addr", Type: "string", Required: true, Description: "Delegatee address (hex bytes)"},
+			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+			{Name: "page", Type: "integer", Required: false, Description: "Page number"},
+			{Name: "per_page", Type: "integer", Required: false, Description: "Results per page"},
+		},
+		Response: "QueryResult",
+	},
+	{
+		Name:        "frozen_powers",
+		Method:      "GET",
+		Description: "List frozen (unbonding) powers by refund height",
+		Parameters: []Parameter{
+			{Name: "addr", Type: "string", Required: false, Description: "Owner address (hex bytes, default: all)"},
+			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+			{Name: "page", Type: "integer", Required: false, Description: "Page number"},
+			{Name: "per_page", Type: "integer", Required: false, Description: "Results per page"},
+		},
+		Response: "QueryResult",
+	},
+	{
+		Name:        "rewards",
+		Method:      "GET",
+		Description: "List rewards of all accounts",
+		Parameters: []Parameter{
+			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+			{Name: "page", Type: "integer", Required: false, Description: "Page number"},
+			{Name: "per_page", Type: "integer", Required: false, Description: "Results per page"},
+		},
+		Response: "QueryResult",
+	},
 	{
 		Name:        "reward",
 		Method:      "GET",
@@ -101,10 +168,12 @@ var endpoints = []Endpoint{
 	{
 		Name:        "proposals",
 		Method:      "GET",
-		Description: "Query proposal information",
+		Description: "List proposals being voted and frozen proposals",
 		Parameters: []Parameter{
-			{Name: "txhash", Type: "string", Required: true, Description: "Transaction hash (hex bytes)"},
+			{Name: "status", Type: "string", Required: false, Description: "Proposal status (voting/frozen, default: all)"},
 			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+			{Name: "page", Type: "integer", Required: false, Description: "Page number"},
+			{Name: "per_page", Type: "integer", Required: false, Description: "Results per page"},
 		},
 		Response: "QueryResult",
 	},
@@ -118,6 +187,26 @@ var endpoints = []Endpoint{
 		},
 		Response: "QueryResult",
 	},
+	{
+		Name:        "proposal_diff",
+		Method:      "GET",
+		Description: "Query the changes of governance parameters proposed by each option of the proposal",
+		Parameters: []Parameter{
+			{Name: "txhash", Type: "string", Required: true, Description: "Transaction hash (hex bytes)"},
+			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+		},
+		Response: "QueryResult",
+	},
+	{
+		Name:        "allow_list",
+		Method:      "GET",
+		Description: "Query the accounts allowed to deploy contracts (zero address) or to call the contract",
+		Parameters: []Parameter{
+			{Name: "addr", Type: "string", Required: true, Description: "Zero address for the deployers or the contract address (hex bytes)"},
+			{Name: "height", Type: "integer", Required: false, Description: "Block height (default: latest)"},
+		},
+		Response: "QueryResult",
+	},
 	{
 		Name:        "rule",
 		Method:      "GET",
@@ -680,9 +769,9 @@ func getTag(name string) string {
 	switch name {
 	case "subscribe", "unsubscribe", "tx_search":
 		return "WebSocket"
-	case "stakes", "stakes/total_power", "stakes/voting_power":
+	case "stakes", "stakes/total_power", "stakes/voting_power", "delegatees", "delegators", "frozen_powers":
 		return "Staking"
-	case "gov_params", "rule", "proposal", "proposals":
+	case "gov_params", "rule", "proposal", "proposals", "proposal_diff", "allow_list":
 		return "Governance"
 	default:
 		return "BEATOZ RPC"
@@ -37,6 +37,19 @@ func DeadAddress() Address {
 	return r
 }
 
+// DepositAddress returns the address of the account holding the deposits of governance proposals.
+func DepositAddress() Address {
+	r, _ := hex.DecodeString("000000000000000000000000000000000000DE90")
+	return r
+}
+
+// TreasuryAddress returns the address of the protocol-owned treasury account.
+// It receives a share of txs fee and inflation, and is spent only by governance proposals.
+func TreasuryAddress() Address {
+	r, _ := hex.DecodeString("0000000000000000000000000000000000007EA5")
+	return r
+}
+
 func HexToAddress(_hex string) (Address, error) {
 	if strings.HasPrefix(_hex, "0x") {
 		_hex = _hex[2:]
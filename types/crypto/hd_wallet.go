@@ -0,0 +1,177 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/beatoz/beatoz-go/libs"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	bip39 "github.com/cosmos/go-bip39"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// HardenedKeyStart is the first index of the hardened child keys (BIP-32).
+	HardenedKeyStart = uint32(0x80000000)
+
+	// DefaultHDPathPrefix is the BIP-44 path prefix of ethereum accounts used by MetaMask and most wallets.
+	// The last element is the index of an account.
+	DefaultHDPathPrefix = "m/44'/60'/0'/0"
+
+	DefaultMnemonicBits = 128 // 12 words
+)
+
+var (
+	hdMasterKeySeed = []byte("Bitcoin seed")
+	secp256k1N      = ethcrypto.S256().Params().N
+)
+
+// HDPath returns the BIP-44 path of the `index`-th account, `m/44'/60'/0'/0/{index}`.
+func HDPath(index uint32) string {
+	return fmt.Sprintf("%s/%d", DefaultHDPathPrefix, index)
+}
+
+// NewMnemonic generates a BIP-39 mnemonic of the english words from the random entropy of `bits`.
+// `bits` should be a multiple of 32 in [128, 256], i.e. 12, 15, 18, 21 or 24 words.
+func NewMnemonic(bits int) (string, xerrors.XError) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", xerrors.From(err)
+	}
+	defer libs.ClearCredential(entropy)
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", xerrors.From(err)
+	}
+	return mnemonic, nil
+}
+
+// MnemonicToSeed validates the words and the checksum of `mnemonic`
+// and returns the BIP-39 seed derived with the optional `passphrase`.
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, xerrors.XError) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, xerrors.NewOrdinary("invalid mnemonic")
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "invalid mnemonic")
+	}
+	return seed, nil
+}
+
+// ParseHDPath parses the derivation path like `m/44'/60'/0'/0/0`.
+// The hardened index is marked with `'` or `h`.
+func ParseHDPath(path string) ([]uint32, xerrors.XError) {
+	elems := strings.Split(strings.TrimSpace(path), "/")
+	if len(elems) < 2 || elems[0] != "m" {
+		return nil, xerrors.NewOrdinary(fmt.Sprintf("invalid derivation path: %q", path))
+	}
+
+	var ret []uint32
+	for _, elem := range elems[1:] {
+		hardened := strings.HasSuffix(elem, "'") || strings.HasSuffix(elem, "h")
+		if hardened {
+			elem = elem[:len(elem)-1]
+		}
+		idx, err := strconv.ParseUint(elem, 10, 32)
+		if err != nil || uint32(idx) >= HardenedKeyStart {
+			return nil, xerrors.NewOrdinary(fmt.Sprintf("invalid derivation path: %q", path))
+		}
+		if hardened {
+			idx += uint64(HardenedKeyStart)
+		}
+		ret = append(ret, uint32(idx))
+	}
+	return ret, nil
+}
+
+// DeriveHDPrvKey derives the secp256k1 private key of `path` from the BIP-39 `seed` (BIP-32).
+func DeriveHDPrvKey(seed []byte, path string) ([]byte, xerrors.XError) {
+	idxs, xerr := ParseHDPath(path)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	key, chainCode := hmacSHA512(hdMasterKeySeed, seed)
+	if !isValidHDKey(key) {
+		return nil, xerrors.NewOrdinary("invalid master key")
+	}
+	for _, idx := range idxs {
+		var err xerrors.XError
+		key, chainCode, err = deriveHDChild(key, chainCode, idx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func deriveHDChild(key, chainCode []byte, idx uint32) ([]byte, []byte, xerrors.XError) {
+	data := make([]byte, 0, 37)
+	if idx >= HardenedKeyStart {
+		data = append(data, 0x0)
+		data = append(data, key...)
+	} else {
+		prv, err := ethcrypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, xerrors.From(err)
+		}
+		data = append(data, ethcrypto.CompressPubkey(&prv.PublicKey)...)
+	}
+	data = binary.BigEndian.AppendUint32(data, idx)
+	defer libs.ClearCredential(data)
+
+	il, childChainCode := hmacSHA512(chainCode, data)
+	defer libs.ClearCredential(il)
+
+	if new(big.Int).SetBytes(il).Cmp(secp256k1N) >= 0 {
+		return nil, nil, xerrors.NewOrdinary(fmt.Sprintf("invalid child key at index %d", idx))
+	}
+	k := new(big.Int).SetBytes(il)
+	k.Add(k, new(big.Int).SetBytes(key))
+	k.Mod(k, secp256k1N)
+	if k.Sign() == 0 {
+		return nil, nil, xerrors.NewOrdinary(fmt.Sprintf("invalid child key at index %d", idx))
+	}
+	return k.FillBytes(make([]byte, 32)), childChainCode, nil
+}
+
+func hmacSHA512(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	_, _ = mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+func isValidHDKey(key []byte) bool {
+	k := new(big.Int).SetBytes(key)
+	return k.Sign() > 0 && k.Cmp(secp256k1N) < 0
+}
+
+// NewWalletKeyFromMnemonic creates the WalletKey of the key derived at `path` from `mnemonic`
+// and encrypts it with `pass` in the same way as NewWalletKey.
+func NewWalletKeyFromMnemonic(mnemonic, passphrase, path string, pass []byte) (*WalletKey, xerrors.XError) {
+	seed, xerr := MnemonicToSeed(mnemonic, passphrase)
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer libs.ClearCredential(seed)
+
+	prvKey, xerr := DeriveHDPrvKey(seed, path)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if pass != nil {
+		// without `pass`, the WalletKey keeps `prvKey` as the plaintext.
+		defer libs.ClearCredential(prvKey)
+	}
+
+	return NewWalletKeyWith(prvKey, pass), nil
+}
@@ -0,0 +1,120 @@
+package crypto_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/types/crypto"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestMnemonicToSeed(t *testing.T) {
+	// BIP-39 test vector
+	seed, err := crypto.MnemonicToSeed(testMnemonic, "TREZOR")
+	require.NoError(t, err)
+	require.Equal(t,
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		hex.EncodeToString(seed))
+
+	// extra spaces are ignored.
+	seed2, err := crypto.MnemonicToSeed("  "+strings.ReplaceAll(testMnemonic, " ", "  ")+" ", "TREZOR")
+	require.NoError(t, err)
+	require.Equal(t, seed, seed2)
+
+	// wrong checksum
+	_, err = crypto.MnemonicToSeed(strings.Repeat("abandon ", 12), "")
+	require.Error(t, err)
+	// unknown word
+	_, err = crypto.MnemonicToSeed(strings.Replace(testMnemonic, "about", "beatoz", 1), "")
+	require.Error(t, err)
+	// wrong length
+	_, err = crypto.MnemonicToSeed(strings.Repeat("abandon ", 10)+"about", "")
+	require.Error(t, err)
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := crypto.NewMnemonic(bits)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(mnemonic), bits*3/32)
+
+		_, err = crypto.MnemonicToSeed(mnemonic, "")
+		require.NoError(t, err)
+	}
+
+	_, err := crypto.NewMnemonic(100)
+	require.Error(t, err)
+}
+
+func TestParseHDPath(t *testing.T) {
+	idxs, err := crypto.ParseHDPath(crypto.HDPath(7))
+	require.NoError(t, err)
+	require.Equal(t, []uint32{
+		44 + crypto.HardenedKeyStart,
+		60 + crypto.HardenedKeyStart,
+		0 + crypto.HardenedKeyStart,
+		0, 7}, idxs)
+
+	idxs2, err := crypto.ParseHDPath("m/44h/60h/0h/0/7")
+	require.NoError(t, err)
+	require.Equal(t, idxs, idxs2)
+
+	for _, path := range []string{"", "m", "44'/60'", "m/44'/x", "m/2147483648", "m//0"} {
+		_, err := crypto.ParseHDPath(path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestDeriveHDPrvKey(t *testing.T) {
+	// BIP-32 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	prvKey, err := crypto.DeriveHDPrvKey(seed, "m/0'/1/2'/2/1000000000")
+	require.NoError(t, err)
+	require.Equal(t, "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8", hex.EncodeToString(prvKey))
+
+	// the same accounts as MetaMask
+	seed, err = crypto.MnemonicToSeed(testMnemonic, "")
+	require.NoError(t, err)
+	for i, expected := range []string{
+		"0x9858EfFD232B4033E47d90003D41EC34EcaEda94",
+		"0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0",
+	} {
+		prvKey, err := crypto.DeriveHDPrvKey(seed, crypto.HDPath(uint32(i)))
+		require.NoError(t, err)
+		prv, err2 := ethcrypto.ToECDSA(prvKey)
+		require.NoError(t, err2)
+		require.Equal(t, expected, ethcrypto.PubkeyToAddress(prv.PublicKey).Hex())
+	}
+}
+
+func TestNewWalletKeyFromMnemonic(t *testing.T) {
+	pass := []byte("abcdef")
+	wk, err := crypto.NewWalletKeyFromMnemonic(testMnemonic, "", crypto.HDPath(0), pass)
+	require.NoError(t, err)
+	require.True(t, wk.IsLock())
+	require.Equal(t, "9858EFFD232B4033E47D90003D41EC34ECAEDA94", wk.Address.String())
+
+	// it is saved and opened in the existing format.
+	buf := bytes.NewBuffer(nil)
+	_, err2 := wk.Save(buf)
+	require.NoError(t, err2)
+	wk2, err2 := crypto.OpenWalletKey(buf)
+	require.NoError(t, err2)
+	require.Equal(t, wk.Address, wk2.Address)
+	require.NoError(t, wk2.Unlock(pass))
+	require.Equal(t, "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727", hex.EncodeToString(wk2.PrvKey()))
+
+	// the same mnemonic and path always recover the same key.
+	wk3, err := crypto.NewWalletKeyFromMnemonic(testMnemonic, "", crypto.HDPath(0), nil)
+	require.NoError(t, err)
+	require.Equal(t, wk.Address, wk3.Address)
+	require.Equal(t, wk2.PrvKey(), wk3.PrvKey())
+
+	_, err = crypto.NewWalletKeyFromMnemonic(testMnemonic, "", "m/44'/60'/0'/0/x", pass)
+	require.Error(t, err)
+}
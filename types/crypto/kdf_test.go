@@ -0,0 +1,143 @@
+package crypto_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/libs"
+	"github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// the wallet key file created before the other key derivation functions were supported.
+// the passphrase is `pw`.
+const pbkdf2WalletKeyJSON = `{
+  "version": 1,
+  "address": "C39561B38084D2AFEDB97C4838DB94BD73FC41A1",
+  "algo": "secp256k1",
+  "cp": {
+    "ca": "aes-256-cbc",
+    "ct": "VniI1/YCcdSaistLohokANSUGKtBPwYd6LyNOZcd70AtcYI0q9moKYeHqMIzf4QY",
+    "ci": "/eY5N2CmQRox2nzwqGcXkA=="
+  },
+  "dkp": {
+    "ka": "pbkdf2",
+    "kh": "sha256",
+    "kc": 601132,
+    "ks": "BGw8y9rfkoQl/qZIIPPTmGrzymC2E8xApcMsI1lXSbI=",
+    "kl": 32
+  }
+}`
+
+func TestKDF(t *testing.T) {
+	pass := []byte("abcdef")
+	for _, kdf := range []string{crypto.KDFPbkdf2, crypto.KDFScrypt, crypto.KDFArgon2id} {
+		prvKey := secp256k1.GenPrivKey()
+		wk, err := crypto.NewWalletKeyWithKDF(prvKey, pass, kdf)
+		require.NoError(t, err)
+		require.Equal(t, kdf, wk.DKParams.Algo)
+
+		buf := bytes.NewBuffer(nil)
+		_, err2 := wk.Save(buf)
+		require.NoError(t, err2)
+		wk2, err2 := crypto.OpenWalletKey(buf)
+		require.NoError(t, err2)
+		require.Equal(t, wk.DKParams, wk2.DKParams)
+
+		require.Error(t, wk2.Unlock([]byte("wrong")), kdf)
+		require.NoError(t, wk2.Unlock(pass), kdf)
+		require.EqualValues(t, prvKey, wk2.PrvKey())
+
+		// LockWith keeps the key derivation function with a new salt.
+		wk2.LockWith([]byte("new pass"))
+		require.Equal(t, kdf, wk2.DKParams.Algo)
+		require.NotEqual(t, wk.DKParams.Salt, wk2.DKParams.Salt)
+		require.NoError(t, wk2.Unlock([]byte("new pass")))
+		require.EqualValues(t, prvKey, wk2.PrvKey())
+	}
+
+	_, err := crypto.NewWalletKeyWithKDF(secp256k1.GenPrivKey(), pass, "md5")
+	require.Error(t, err)
+}
+
+func TestKDFInvalidParams(t *testing.T) {
+	pass := []byte("abcdef")
+	scryptKey, err := crypto.NewWalletKeyWithKDF(secp256k1.GenPrivKey(), pass, crypto.KDFScrypt)
+	require.NoError(t, err)
+	argon2Key, err := crypto.NewWalletKeyWithKDF(secp256k1.GenPrivKey(), pass, crypto.KDFArgon2id)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		wk     *crypto.WalletKey
+		change func(wk *crypto.WalletKey)
+	}{
+		{scryptKey, func(wk *crypto.WalletKey) { wk.DKParams.N = 0 }},
+		{scryptKey, func(wk *crypto.WalletKey) { wk.DKParams.N = 1000 }},
+		{scryptKey, func(wk *crypto.WalletKey) { wk.DKParams.N = 1 << 30 }},
+		{scryptKey, func(wk *crypto.WalletKey) { wk.DKParams.R = 0 }},
+		{scryptKey, func(wk *crypto.WalletKey) { wk.DKParams.P = 1 << 20 }},
+		{argon2Key, func(wk *crypto.WalletKey) { wk.DKParams.Iter = 0 }},
+		{argon2Key, func(wk *crypto.WalletKey) { wk.DKParams.Threads = 0 }},
+		{argon2Key, func(wk *crypto.WalletKey) { wk.DKParams.Memory = 0 }},
+		{argon2Key, func(wk *crypto.WalletKey) { wk.DKParams.Memory = 1 << 30 }},
+		{argon2Key, func(wk *crypto.WalletKey) { wk.DKParams.DkLen = 0 }},
+	} {
+		dkp := *tc.wk.DKParams
+		tc.change(tc.wk)
+		require.Error(t, tc.wk.Unlock(pass))
+		require.True(t, tc.wk.IsLock())
+		*tc.wk.DKParams = dkp
+	}
+
+	require.NoError(t, scryptKey.Unlock(pass))
+	require.NoError(t, argon2Key.Unlock(pass))
+}
+
+func TestRekey(t *testing.T) {
+	wk, err := crypto.OpenWalletKey(strings.NewReader(pbkdf2WalletKeyJSON))
+	require.NoError(t, err)
+	require.NoError(t, wk.Unlock([]byte("pw")))
+	prvKey := wk.PrvKeyClone()
+	require.Equal(t, "1f901efd82cce65d41f1405065e8f0077a1f59a8e93744440389139886526d61", hex.EncodeToString(prvKey))
+
+	require.Error(t, wk.LockWithKDF([]byte("pw2"), "md5"))
+	require.False(t, wk.IsLock())
+
+	require.NoError(t, wk.LockWithKDF([]byte("pw2"), crypto.KDFArgon2id))
+	require.True(t, wk.IsLock())
+	require.Equal(t, crypto.KDFArgon2id, wk.DKParams.Algo)
+
+	buf := bytes.NewBuffer(nil)
+	_, err = wk.Save(buf)
+	require.NoError(t, err)
+	wk2, err := crypto.OpenWalletKey(buf)
+	require.NoError(t, err)
+	require.Equal(t, "C39561B38084D2AFEDB97C4838DB94BD73FC41A1", wk2.Address.String())
+	require.Error(t, wk2.Unlock([]byte("pw")))
+	require.NoError(t, wk2.Unlock([]byte("pw2")))
+	require.Equal(t, prvKey, wk2.PrvKey())
+}
+
+func TestSFilePVKDF(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key.json")
+	stateFile := filepath.Join(dir, "state.json")
+	pass := []byte("abcdef")
+
+	pv := crypto.GenSFilePV(keyFile, stateFile)
+	require.Error(t, pv.SetKDF("md5"))
+	require.NoError(t, pv.SetKDF(crypto.KDFScrypt))
+	pv.SaveWith(pass)
+
+	// the key derivation function is kept when the file is saved again.
+	pv2 := crypto.LoadOrGenSFilePV(keyFile, stateFile, pass)
+	require.Equal(t, pv.Key.PrivKey, pv2.Key.PrivKey)
+
+	wk, err := crypto.OpenWalletKey(libs.NewFileReader(keyFile))
+	require.NoError(t, err)
+	require.Equal(t, crypto.KDFScrypt, wk.DKParams.Algo)
+}
@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"github.com/beatoz/beatoz-go/libs"
+	"github.com/beatoz/beatoz-go/types/xerrors"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// ExportKeystore encrypts the private key of the unlocked `wk` into the Web3 Secret Storage (keystore v3) JSON
+// which can be read by geth, MetaMask, Foundry and so on.
+// The key is encrypted with aes-128-ctr and the key derived from `pass` by scrypt.
+// If `light` is true, the lighter scrypt parameters are used.
+func (wk *WalletKey) ExportKeystore(pass []byte, light bool) ([]byte, xerrors.XError) {
+	if wk.IsLock() {
+		return nil, xerrors.NewOrdinary("the wallet key is locked")
+	}
+
+	prv, err := ethcrypto.ToECDSA(wk.prvKey)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if light {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+	bz, err := keystore.EncryptKey(&keystore.Key{
+		Id:         id,
+		Address:    ethcrypto.PubkeyToAddress(prv.PublicKey),
+		PrivateKey: prv,
+	}, string(pass), scryptN, scryptP)
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+	return bz, nil
+}
+
+// ImportKeystore decrypts the Web3 Secret Storage (keystore v3) JSON with `keystorePass`
+// and returns the WalletKey of the private key encrypted with `pass`.
+// Both of scrypt and pbkdf2 are supported as the key derivation function of the keystore.
+func ImportKeystore(keyJSON, keystorePass, pass []byte) (*WalletKey, xerrors.XError) {
+	key, err := keystore.DecryptKey(keyJSON, string(keystorePass))
+	if err != nil {
+		return nil, xerrors.From(err)
+	}
+
+	prvKey := ethcrypto.FromECDSA(key.PrivateKey)
+	if pass != nil {
+		// without `pass`, the WalletKey keeps `prvKey` as the plaintext.
+		defer libs.ClearCredential(prvKey)
+	}
+	return NewWalletKeyWith(prvKey, pass), nil
+}
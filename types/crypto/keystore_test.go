@@ -0,0 +1,84 @@
+package crypto_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/beatoz/beatoz-go/libs/jsonx"
+	"github.com/beatoz/beatoz-go/types/crypto"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/require"
+)
+
+// the test vectors of https://ethereum.org/en/developers/docs/data-structures-and-encoding/web3-secret-storage/
+var keystoreVectors = []string{
+	`{
+  "crypto": {
+    "cipher": "aes-128-ctr",
+    "cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+    "ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+    "kdf": "scrypt",
+    "kdfparams": {"dklen": 32, "n": 262144, "r": 1, "p": 8, "salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+    "mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+  },
+  "id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+  "version": 3
+}`,
+	`{
+  "crypto": {
+    "cipher": "aes-128-ctr",
+    "cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+    "ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+    "kdf": "pbkdf2",
+    "kdfparams": {"c": 262144, "dklen": 32, "prf": "hmac-sha256", "salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+    "mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+  },
+  "id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+  "version": 3
+}`,
+}
+
+func TestImportKeystore(t *testing.T) {
+	pass := []byte("abcdef")
+	for _, v := range keystoreVectors {
+		wk, err := crypto.ImportKeystore([]byte(v), []byte("testpassword"), pass)
+		require.NoError(t, err)
+		require.True(t, wk.IsLock())
+		require.NoError(t, wk.Unlock(pass))
+		require.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(wk.PrvKey()))
+
+		_, err = crypto.ImportKeystore([]byte(v), []byte("wrongpassword"), pass)
+		require.Error(t, err)
+	}
+}
+
+func TestExportKeystore(t *testing.T) {
+	wk, err := crypto.NewWalletKeyFromMnemonic(testMnemonic, "", crypto.HDPath(0), []byte("abcdef"))
+	require.NoError(t, err)
+
+	// the locked wallet key can not be exported.
+	_, err = wk.ExportKeystore([]byte("ks-pass"), true)
+	require.Error(t, err)
+
+	require.NoError(t, wk.Unlock([]byte("abcdef")))
+	keyJSON, err := wk.ExportKeystore([]byte("ks-pass"), true)
+	require.NoError(t, err)
+
+	// the standard keystore v3 format
+	m := make(map[string]interface{})
+	require.NoError(t, jsonx.Unmarshal(keyJSON, &m))
+	require.EqualValues(t, 3, m["version"])
+	require.Equal(t, "9858effd232b4033e47d90003d41ec34ecaeda94", m["address"])
+	require.Equal(t, "aes-128-ctr", m["crypto"].(map[string]interface{})["cipher"])
+	require.Equal(t, "scrypt", m["crypto"].(map[string]interface{})["kdf"])
+
+	key, err2 := keystore.DecryptKey(keyJSON, "ks-pass")
+	require.NoError(t, err2)
+	require.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", key.Address.Hex())
+
+	// import again
+	wk2, err := crypto.ImportKeystore(keyJSON, []byte("ks-pass"), nil)
+	require.NoError(t, err)
+	require.Equal(t, wk.Address, wk2.Address)
+	require.Equal(t, wk.PrvKey(), wk2.PrvKey())
+}
@@ -51,6 +51,9 @@ type SFilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+	// the key derivation function and its parameters used to encrypt the key file.
+	// if it is nil, DefaultKDF is used.
+	dkParams *dkParams
 }
 
 // Save persists the SFilePVKey to its filePath.
@@ -79,7 +82,12 @@ func (pvKey SFilePVKey) SaveWith(s []byte) {
 	}
 
 	// build WalletKey contains jsonBytes
-	walKey := NewWalletKeyWith(pvKey.PrivKey.Bytes(), s)
+	// the same key derivation function and parameters as the loaded file are used again.
+	_dkParams := newDKParams(DefaultKDF)
+	if pvKey.dkParams != nil {
+		_dkParams = pvKey.dkParams.renew()
+	}
+	walKey := newWalletKeyWith(pvKey.PrivKey.Bytes(), s, _dkParams)
 	_, err := walKey.Save(libs.NewFileWriter(outFile))
 	if err != nil {
 		panic(err)
@@ -247,6 +255,7 @@ func loadSFilePV(keyFilePath, stateFilePath string, loadState bool, s []byte) *S
 	pvKey.PubKey = pvKey.PrivKey.PubKey()
 	pvKey.Address = abytes.HexBytes(pvKey.PubKey.Address())
 	pvKey.filePath = keyFilePath
+	pvKey.dkParams = walKey.DKParams
 
 	pvState := SFilePVLastSignState{}
 
@@ -327,6 +336,15 @@ func (pv *SFilePV) SaveWith(s []byte) {
 	pv.LastSignState.Save()
 }
 
+// SetKDF sets the key derivation function used to encrypt the key file when it is saved next time.
+func (pv *SFilePV) SetKDF(kdf string) xerrors.XError {
+	if err := ValidateKDF(kdf); err != nil {
+		return err
+	}
+	pv.Key.dkParams = newDKParams(kdf)
+	return nil
+}
+
 // Reset resets all fields in the SFilePV.
 // NOTE: Unsafe!
 //func (pv *SFilePV) Reset() {
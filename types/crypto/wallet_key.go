@@ -16,7 +16,9 @@ import (
 	"github.com/beatoz/beatoz-go/types/xerrors"
 	ethec "github.com/ethereum/go-ethereum/crypto/secp256k1"
 	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 )
 
 const (
@@ -34,10 +36,19 @@ type cipherTextParams struct {
 
 type dkParams struct {
 	Algo  string `json:"ka"`
-	Prf   string `json:"kh"`
-	Iter  int    `json:"kc"`
+	Prf   string `json:"kh,omitempty"`
+	Iter  int    `json:"kc,omitempty"`
 	Salt  []byte `json:"ks"`
 	DkLen int    `json:"kl"`
+
+	// scrypt
+	N int `json:"kn,omitempty"`
+	R int `json:"kr,omitempty"`
+	P int `json:"kp,omitempty"`
+
+	// argon2id (`Iter` is the number of passes)
+	Memory  uint32 `json:"km,omitempty"` // KiB
+	Threads uint8  `json:"kt,omitempty"`
 }
 
 type WalletKey struct {
@@ -51,59 +62,183 @@ type WalletKey struct {
 	pubKey []byte
 }
 
+const (
+	KDFPbkdf2   = "pbkdf2"
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+// DefaultKDF is the key derivation function used when the function is not specified.
+var DefaultKDF = KDFPbkdf2
+
+// ValidateKDF returns an error if `kdf` is not supported.
+func ValidateKDF(kdf string) xerrors.XError {
+	switch kdf {
+	case KDFPbkdf2, KDFScrypt, KDFArgon2id:
+		return nil
+	}
+	return xerrors.NewOrdinary(fmt.Sprintf("unsupported key derivation function: %q", kdf))
+}
+
+// newDKParams returns the parameters of `algo` with a new random salt.
+// The parameters of scrypt are the same as geth's standard ones,
+// and the parameters of argon2id are the second recommended option of RFC 9106.
 func newDKParams(algo string) *dkParams {
 	salt := make([]byte, DKLEN)
 	_, _ = rand.Read(salt)
 
 	_dkParams := &dkParams{
 		Algo:  algo,
-		Prf:   DefaultHasherName(),
-		Iter:  600000 + int(binary.BigEndian.Uint16(salt[:2])),
 		Salt:  salt,
 		DkLen: DKLEN,
 	}
+	switch algo {
+	case KDFScrypt:
+		_dkParams.N = 1 << 18
+		_dkParams.R = 8
+		_dkParams.P = 1
+	case KDFArgon2id:
+		_dkParams.Iter = 3
+		_dkParams.Memory = 64 * 1024
+		_dkParams.Threads = 4
+	default:
+		_dkParams.Algo = KDFPbkdf2
+		_dkParams.Prf = DefaultHasherName()
+		_dkParams.Iter = 600000 + int(binary.BigEndian.Uint16(salt[:2]))
+	}
 	return _dkParams
 }
 
-func NewWalletKeyWith(keyBytes, pass []byte) *WalletKey {
-	var _pubKey, _prvKey []byte
-	var _cipherTextParams *cipherTextParams
-	var _dkParams *dkParams
+// renew returns the copy of the parameters with a new random salt.
+func (dkp *dkParams) renew() *dkParams {
+	ret := newDKParams(dkp.Algo)
+	salt := ret.Salt
+	*ret = *dkp
+	ret.Salt = salt
+	if ret.Algo == KDFPbkdf2 {
+		ret.Iter = 600000 + int(binary.BigEndian.Uint16(salt[:2]))
+	}
+	return ret
+}
 
-	if pass != nil {
-		_dkParams = newDKParams("pbkdf2")
-		sk := pbkdf2.Key(pass, _dkParams.Salt, _dkParams.Iter, DKLEN, DefaultHasher)
-		defer libs.ClearCredential(sk)
+// The upper bounds of the costs of scrypt and argon2id read from a key file.
+// They are far above the defaults of newDKParams and keep a broken or malicious file
+// from exhausting the memory of the node.
+const (
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxArgon2Iter   = 64
+	maxArgon2Memory = 4 * 1024 * 1024 // KiB
+)
 
-		block, err := aes.NewCipher(sk)
-		if err != nil {
-			panic(err)
+// validate returns an error if the parameters of scrypt or argon2id are out of range.
+// argon2.IDKey panics on the zero number of passes or threads,
+// which are omitted from the file when they are zero.
+func (dkp *dkParams) validate() error {
+	switch dkp.Algo {
+	case KDFScrypt:
+		if dkp.N <= 1 || dkp.N > maxScryptN || dkp.N&(dkp.N-1) != 0 {
+			return fmt.Errorf("invalid scrypt N: %d", dkp.N)
+		}
+		if dkp.R <= 0 || dkp.R > maxScryptR {
+			return fmt.Errorf("invalid scrypt r: %d", dkp.R)
 		}
+		if dkp.P <= 0 || dkp.P > maxScryptP {
+			return fmt.Errorf("invalid scrypt p: %d", dkp.P)
+		}
+	case KDFArgon2id:
+		if dkp.Iter <= 0 || dkp.Iter > maxArgon2Iter {
+			return fmt.Errorf("invalid argon2id passes: %d", dkp.Iter)
+		}
+		if dkp.Threads == 0 {
+			return fmt.Errorf("invalid argon2id threads: %d", dkp.Threads)
+		}
+		if dkp.Memory < 8*uint32(dkp.Threads) || dkp.Memory > maxArgon2Memory {
+			return fmt.Errorf("invalid argon2id memory: %d KiB", dkp.Memory)
+		}
+	}
+	if dkp.DkLen <= 0 {
+		return fmt.Errorf("invalid derived key length: %d", dkp.DkLen)
+	}
+	return nil
+}
 
-		iv := make([]byte, block.BlockSize())
-		rand.Read(iv)
+// deriveKey derives the encryption key from `pass`.
+// The files created before the other functions were supported have no `Algo` and are derived by pbkdf2.
+func (dkp *dkParams) deriveKey(pass []byte) ([]byte, error) {
+	if err := dkp.validate(); err != nil {
+		return nil, err
+	}
+	switch dkp.Algo {
+	case KDFPbkdf2, "":
+		return pbkdf2.Key(pass, dkp.Salt, dkp.Iter, dkp.DkLen, DefaultHasher), nil
+	case KDFScrypt:
+		return scrypt.Key(pass, dkp.Salt, dkp.N, dkp.R, dkp.P, dkp.DkLen)
+	case KDFArgon2id:
+		return argon2.IDKey(pass, dkp.Salt, uint32(dkp.Iter), dkp.Memory, dkp.Threads, uint32(dkp.DkLen)), nil
+	}
+	return nil, fmt.Errorf("unsupported key derivation function: %q", dkp.Algo)
+}
 
-		plaintext, err := PKCS7Padding(keyBytes, block.BlockSize())
-		if err != nil {
-			panic(err)
-		}
+// encrypt encrypts `keyBytes` with the key derived from `pass`.
+func (dkp *dkParams) encrypt(keyBytes, pass []byte) *cipherTextParams {
+	sk, err := dkp.deriveKey(pass)
+	if err != nil {
+		panic(err)
+	}
+	defer libs.ClearCredential(sk)
+
+	block, err := aes.NewCipher(sk)
+	if err != nil {
+		panic(err)
+	}
 
-		ciphertext := make([]byte, len(plaintext))
-		cbc := cipher.NewCBCEncrypter(block, iv)
-		cbc.CryptBlocks(ciphertext, plaintext)
-		libs.ClearCredential(plaintext)
+	iv := make([]byte, block.BlockSize())
+	rand.Read(iv)
 
-		_cipherTextParams = &cipherTextParams{
-			Algo: SymmAlgo,
-			Text: ciphertext,
-			Iv:   iv,
-		}
+	plaintext, err := PKCS7Padding(keyBytes, block.BlockSize())
+	if err != nil {
+		panic(err)
+	}
+
+	ciphertext := make([]byte, len(plaintext))
+	cbc := cipher.NewCBCEncrypter(block, iv)
+	cbc.CryptBlocks(ciphertext, plaintext)
+	libs.ClearCredential(plaintext)
+
+	return &cipherTextParams{
+		Algo: SymmAlgo,
+		Text: ciphertext,
+		Iv:   iv,
+	}
+}
+
+func NewWalletKeyWith(keyBytes, pass []byte) *WalletKey {
+	return newWalletKeyWith(keyBytes, pass, newDKParams(DefaultKDF))
+}
+
+// NewWalletKeyWithKDF returns the WalletKey encrypted with the key derived from `pass` by `kdf`.
+func NewWalletKeyWithKDF(keyBytes, pass []byte, kdf string) (*WalletKey, xerrors.XError) {
+	if err := ValidateKDF(kdf); err != nil {
+		return nil, err
+	}
+	return newWalletKeyWith(keyBytes, pass, newDKParams(kdf)), nil
+}
+
+func newWalletKeyWith(keyBytes, pass []byte, _dkParams *dkParams) *WalletKey {
+	var _pubKey, _prvKey []byte
+	var _cipherTextParams *cipherTextParams
+
+	if pass != nil {
+		_cipherTextParams = _dkParams.encrypt(keyBytes, pass)
 	} else {
 		_cipherTextParams = &cipherTextParams{
 			Algo: SymmAlgo,
 			Text: keyBytes, // plaintext
 		}
 		_prvKey = append(keyBytes[:0:0], keyBytes...)
+		_dkParams = nil
 	}
 	_pubKey = tmsecp256k1.PrivKey(keyBytes).PubKey().Bytes()
 
@@ -158,7 +293,26 @@ func (wk *WalletKey) Lock() {
 	wk.prvKey = nil
 }
 
+// LockWith encrypts the key with `pass` by the same key derivation function and parameters as before,
+// or by DefaultKDF if it has not been encrypted.
 func (wk *WalletKey) LockWith(pass []byte) {
+	_dkParams := newDKParams(DefaultKDF)
+	if wk.DKParams != nil {
+		_dkParams = wk.DKParams.renew()
+	}
+	wk.lockWith(pass, _dkParams)
+}
+
+// LockWithKDF encrypts the key with the key derived from `pass` by `kdf`.
+func (wk *WalletKey) LockWithKDF(pass []byte, kdf string) xerrors.XError {
+	if err := ValidateKDF(kdf); err != nil {
+		return err
+	}
+	wk.lockWith(pass, newDKParams(kdf))
+	return nil
+}
+
+func (wk *WalletKey) lockWith(pass []byte, _dkParams *dkParams) {
 	if wk.prvKey == nil {
 		return
 	}
@@ -167,33 +321,7 @@ func (wk *WalletKey) LockWith(pass []byte) {
 		wk.prvKey = nil
 	}()
 
-	_dkParams := newDKParams("pbkdf2")
-	sk := pbkdf2.Key(pass, _dkParams.Salt, _dkParams.Iter, DKLEN, DefaultHasher)
-	defer libs.ClearCredential(sk)
-
-	block, err := aes.NewCipher(sk)
-	if err != nil {
-		panic(err)
-	}
-
-	iv := make([]byte, block.BlockSize())
-	rand.Read(iv)
-
-	plaintext, err := PKCS7Padding(wk.prvKey, block.BlockSize())
-	if err != nil {
-		panic(err)
-	}
-
-	ciphertext := make([]byte, len(plaintext))
-	cbc := cipher.NewCBCEncrypter(block, iv)
-	cbc.CryptBlocks(ciphertext, plaintext)
-	libs.ClearCredential(plaintext)
-
-	wk.CipherTextParams = &cipherTextParams{
-		Algo: SymmAlgo,
-		Text: ciphertext,
-		Iv:   iv,
-	}
+	wk.CipherTextParams = _dkParams.encrypt(wk.prvKey, pass)
 	wk.DKParams = _dkParams
 }
 
@@ -203,7 +331,15 @@ func (wk *WalletKey) Unlock(s []byte) error {
 	}
 
 	if s != nil {
-		sk := pbkdf2.Key(s, wk.DKParams.Salt, wk.DKParams.Iter, wk.DKParams.DkLen, DefaultHasher)
+		if wk.DKParams == nil {
+			return errors.New("wrong passphrase: the wallet key is not encrypted")
+		}
+		sk, err := wk.DKParams.deriveKey(s)
+		if err != nil {
+			return err
+		}
+		defer libs.ClearCredential(sk)
+
 		iv := wk.CipherTextParams.Iv
 		ciphertext := wk.CipherTextParams.Text
 		prvKey := make([]byte, len(ciphertext))
@@ -269,9 +405,17 @@ const DefaultWalletKeyDirPerm = 0700
 const DefaultWalletKeyDir = "walkeys"
 const DefaultValKeyDir = "walkeys/vals"
 
-func createWalletKeyFile(s []byte, dir string) (*WalletKey, error) {
-	wk := NewWalletKey(s)
-	filePath := filepath.Join(dir, fmt.Sprintf("wk%X.json", wk.Address))
+// WalletKeyFilePath returns the path of the wallet key file of `addr` in `dir`.
+func WalletKeyFilePath(dir string, addr types.Address) string {
+	return filepath.Join(dir, fmt.Sprintf("wk%X.json", addr))
+}
+
+func createWalletKeyFile(s []byte, dir, kdf string) (*WalletKey, error) {
+	wk, err := NewWalletKeyWithKDF(tmsecp256k1.GenPrivKey(), s, kdf)
+	if err != nil {
+		return nil, err
+	}
+	filePath := WalletKeyFilePath(dir, wk.Address)
 	if _, err := wk.Save(libs.NewFileWriter(filePath)); err != nil {
 		return nil, err
 	}
@@ -279,9 +423,14 @@ func createWalletKeyFile(s []byte, dir string) (*WalletKey, error) {
 }
 
 func CreateWalletKeyFiles(s []byte, cnt int, dir string) ([]*WalletKey, error) {
+	return CreateWalletKeyFilesWithKDF(s, cnt, dir, DefaultKDF)
+}
+
+// CreateWalletKeyFilesWithKDF creates `cnt` wallet key files encrypted by `kdf` in `dir`.
+func CreateWalletKeyFilesWithKDF(s []byte, cnt int, dir, kdf string) ([]*WalletKey, error) {
 	var wks []*WalletKey
 	for i := 0; i < cnt; i++ {
-		wk, err := createWalletKeyFile(s, dir)
+		wk, err := createWalletKeyFile(s, dir, kdf)
 		if err != nil {
 			return nil, err
 		}
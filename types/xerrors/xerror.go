@@ -81,6 +81,8 @@ var (
 	ErrNotVotingPeriod       = NewOrdinary("not voting period")
 	ErrDuplicatedKey         = NewOrdinary("already existed key")
 	ErrInvalidWeight         = NewOrdinary("invalid weight")
+	ErrInvalidGovParams      = NewOrdinary("invalid governance parameters")
+	ErrNotFoundToken         = NewOrdinary("not found token")
 )
 
 type XError interface {